@@ -3,15 +3,44 @@ package main
 import (
 	"fmt"
 	"game-library-management-system/src/app"
+	"os"
 )
 
+// main starts the HTTP server, or runs a maintenance command given as the first argument:
+//
+//	repair  rewrites games whose embedded developer is out of date
+//	import  adds the developers and games of a CSV or NDJSON file
+//	backup  writes the developers and games to a backup archive
+//	restore writes the developers and games of a backup archive
 func main() {
 	a, err := app.NewApp()
 	if err != nil {
 		fmt.Println("Error creating app", err)
+		os.Exit(1)
+	}
+
+	command := "serve"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	switch command {
+	case "serve":
+		err = a.Run()
+	case "repair":
+		err = a.Repair()
+	case "import":
+		err = a.Import(os.Args[2:])
+	case "backup":
+		err = a.Backup(os.Args[2:])
+	case "restore":
+		err = a.Restore(os.Args[2:])
+	default:
+		fmt.Println("Unknown command", command)
+		os.Exit(2)
 	}
-	err = a.Run()
 	if err != nil {
 		fmt.Println("Error running app", err)
+		os.Exit(1)
 	}
 }
@@ -1,17 +1,42 @@
 package configs
 
 import (
+	"fmt"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
 	DatabaseURI string `json:"db_uri"`
 	DBName      string `json:"db_name"`
 	Port        string `json:"port"`
+	Storage     string `json:"storage"`
+	SQLitePath  string `json:"sqlite_path"`
+	// LoanDays is how many days a checked out game may be kept.
+	LoanDays int `json:"loan_days"`
+	// HoldPickupDays is how many days a game reserved for a hold waits for the member.
+	HoldPickupDays int `json:"hold_pickup_days"`
+	// FineDailyRate is the late fee in cents for each started day a loan is overdue. Zero turns late fees off.
+	FineDailyRate int `json:"fine_daily_rate"`
+	// FineCap is the most a single overdue loan is fined, in cents.
+	FineCap int `json:"fine_cap"`
+	// FineThreshold is the open fine balance in cents above which a member may not borrow. Zero means any open fine.
+	FineThreshold int `json:"fine_threshold"`
+	// OverdueCheckMinutes is how often overdue loans are looked for and fined.
+	OverdueCheckMinutes int `json:"overdue_check_minutes"`
+	// LegacyRoutesSunset is the day the deprecated unversioned routes stop being served and start answering 410 Gone.
+	LegacyRoutesSunset time.Time `json:"legacy_routes_sunset"`
 }
 
+const (
+	StorageMongo  = "mongo"
+	StorageMemory = "memory"
+	StorageSQLite = "sqlite"
+)
+
 func Load() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
@@ -23,10 +48,95 @@ func Load() (*Config, error) {
 	if portStr == "" {
 		portStr = "8080"
 	}
+	storage := os.Getenv("Storage")
+	if storage == "" {
+		storage = StorageMongo
+	}
+	sqlitePath := os.Getenv("SQLitePath")
+	if sqlitePath == "" {
+		sqlitePath = "library.db"
+	}
+	loanDays, err := intEnv("LoanDays", 14)
+	if err != nil {
+		return nil, err
+	}
+	holdPickupDays, err := intEnv("HoldPickupDays", 3)
+	if err != nil {
+		return nil, err
+	}
+	fineDailyRate, err := nonNegativeIntEnv("FineDailyRate", 25)
+	if err != nil {
+		return nil, err
+	}
+	fineCap, err := intEnv("FineCap", 1000)
+	if err != nil {
+		return nil, err
+	}
+	fineThreshold, err := nonNegativeIntEnv("FineThreshold", 500)
+	if err != nil {
+		return nil, err
+	}
+	overdueCheckMinutes, err := intEnv("OverdueCheckMinutes", 60)
+	if err != nil {
+		return nil, err
+	}
+	legacyRoutesSunset, err := dateEnv("LegacyRoutesSunset", time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
-		DatabaseURI: DatabaseURI,
-		DBName:      DBName,
-		Port:        portStr,
+		DatabaseURI:         DatabaseURI,
+		DBName:              DBName,
+		Port:                portStr,
+		Storage:             storage,
+		SQLitePath:          sqlitePath,
+		LoanDays:            loanDays,
+		HoldPickupDays:      holdPickupDays,
+		FineDailyRate:       fineDailyRate,
+		FineCap:             fineCap,
+		FineThreshold:       fineThreshold,
+		OverdueCheckMinutes: overdueCheckMinutes,
+		LegacyRoutesSunset:  legacyRoutesSunset,
 	}, nil
 }
+
+// intEnv reads a positive integer from the environment variable name, or returns def if it is unset.
+func intEnv(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", name, value)
+	}
+	return n, nil
+}
+
+// nonNegativeIntEnv reads an integer that may be zero from the environment variable name, or returns def if it is unset.
+func nonNegativeIntEnv(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", name, value)
+	}
+	return n, nil
+}
+
+// dateEnv reads a date in the form 2006-01-02 from the environment variable name, or returns def if it is unset.
+// The date is the start of the day in UTC.
+func dateEnv(name string, def time.Time) (time.Time, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date like 2006-01-02, got %q", name, value)
+	}
+	return t, nil
+}
@@ -0,0 +1,31 @@
+package configs
+
+import "testing"
+
+// TestIntEnv checks which values the integer settings accept.
+func TestIntEnv(t *testing.T) {
+	tests := []struct {
+		value         string
+		positive      int
+		positiveOK    bool
+		nonNegative   int
+		nonNegativeOK bool
+	}{
+		{"", 7, true, 7, true},
+		{"3", 3, true, 3, true},
+		{"0", 0, false, 0, true},
+		{"-1", 0, false, 0, false},
+		{"ten", 0, false, 0, false},
+	}
+	for _, tt := range tests {
+		t.Setenv("Setting", tt.value)
+		n, err := intEnv("Setting", 7)
+		if n != tt.positive || (err == nil) != tt.positiveOK {
+			t.Errorf("intEnv(%q) = %d, %v", tt.value, n, err)
+		}
+		n, err = nonNegativeIntEnv("Setting", 7)
+		if n != tt.nonNegative || (err == nil) != tt.nonNegativeOK {
+			t.Errorf("nonNegativeIntEnv(%q) = %d, %v", tt.value, n, err)
+		}
+	}
+}
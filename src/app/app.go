@@ -1,19 +1,39 @@
 package app
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
 	"game-library-management-system/configs"
 	"game-library-management-system/src/handler"
 	"game-library-management-system/src/interface"
 	"game-library-management-system/src/logger"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
 	"game-library-management-system/src/repository"
+	"game-library-management-system/src/repository/memory"
+	"game-library-management-system/src/repository/sqlite"
 	"game-library-management-system/src/service"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
 type App struct {
-	config *configs.Config
-	server *Server
-	logger *zap.Logger
+	config      *configs.Config
+	server      *Server
+	logger      *zap.Logger
+	memoryStore *memory.Store
+	sqliteDB    *sql.DB
+	mongoClient *mongo.Client
 }
 
 // NewApp initializes a new App instance.
@@ -31,37 +51,229 @@ func NewApp() (*App, error) {
 	}
 
 	return &App{
-		config: config,
-		server: NewServer(config.Port),
-		logger: lgr,
+		config:      config,
+		server:      NewServer(config.Port),
+		logger:      lgr,
+		memoryStore: memory.NewStore(),
 	}, nil
 }
 
-// createDeveloperRepository  creates a new DeveloperRepository instance.
+// connectMongo connects to MongoDB on first use and returns the shared database.
+// The client is pinged with retries, so this blocks until the database is reachable.
+// Returns the database or an error if MongoDB cannot be reached.
+func (a *App) connectMongo() (*mongo.Database, error) {
+	if a.mongoClient == nil {
+		client, err := repository.Connect(context.Background(), a.config.DatabaseURI)
+		if err != nil {
+			return nil, err
+		}
+		a.mongoClient = client
+		a.logger.Info("Connected to MongoDB")
+	}
+	return a.mongoClient.Database(a.config.DBName), nil
+}
+
+// openSQLite opens the SQLite database file on first use and returns the shared handle.
+// Returns the database handle or an error if the file cannot be opened.
+func (a *App) openSQLite() (*sql.DB, error) {
+	if a.sqliteDB == nil {
+		db, err := sqlite.Open(a.config.SQLitePath)
+		if err != nil {
+			return nil, err
+		}
+		a.sqliteDB = db
+	}
+	return a.sqliteDB, nil
+}
+
+// createDeveloperRepository  creates a new DeveloperRepository instance for the configured storage.
 // Returns the DeveloperRepositorer interface or an error if the repository cannot be created.
 func (a *App) createDeveloperRepository() (_interface.DeveloperRepositorer, error) {
-	developerInterface, err := repository.NewDeveloperRepository(a.config.DatabaseURI, a.config.DBName)
-	if err != nil {
-		return nil, err
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewDeveloperRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewDeveloperRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewDeveloperRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
 	}
-	return developerInterface, nil
 }
 
-// createGameRepository  creates a new DeveloperRepository instance.
+// createGameRepository  creates a new GameRepository instance for the configured storage.
 // Returns the GameRepositorer interface or an error if the repository cannot be created.
 func (a *App) createGameRepository() (_interface.GameRepositorer, error) {
-	gameInterface, err := repository.NewGameRepository(a.config.DatabaseURI, a.config.DBName)
-	if err != nil {
-		return nil, err
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewGameRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewGameRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewGameRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
+	}
+}
+
+// createMemberRepository creates a new MemberRepository instance for the configured storage.
+// Returns the MemberRepositorer interface or an error if the repository cannot be created.
+func (a *App) createMemberRepository() (_interface.MemberRepositorer, error) {
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewMemberRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewMemberRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewMemberRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
+	}
+}
+
+// createLoanRepository creates a new LoanRepository instance for the configured storage.
+// Returns the LoanRepositorer interface or an error if the repository cannot be created.
+func (a *App) createLoanRepository() (_interface.LoanRepositorer, error) {
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewLoanRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewLoanRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewLoanRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
+	}
+}
+
+// createCopyRepository creates a new CopyRepository instance for the configured storage.
+// Returns the CopyRepositorer interface or an error if the repository cannot be created.
+func (a *App) createCopyRepository() (_interface.CopyRepositorer, error) {
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewCopyRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewCopyRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewCopyRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
+	}
+}
+
+// createHoldRepository creates a new HoldRepository instance for the configured storage.
+// Returns the HoldRepositorer interface or an error if the repository cannot be created.
+func (a *App) createHoldRepository() (_interface.HoldRepositorer, error) {
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewHoldRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewHoldRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewHoldRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
+	}
+}
+
+// createFineRepository creates a new FineRepository instance for the configured storage.
+// Returns the FineRepositorer interface or an error if the repository cannot be created.
+func (a *App) createFineRepository() (_interface.FineRepositorer, error) {
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		db, err := a.connectMongo()
+		if err != nil {
+			return nil, err
+		}
+		return repository.NewFineRepository(db), nil
+	case configs.StorageMemory:
+		return memory.NewFineRepository(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewFineRepository(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
+	}
+}
+
+// createTransactor creates a new Transactor instance for the configured storage.
+// Returns the Transactor interface or an error if it cannot be created.
+func (a *App) createTransactor() (_interface.Transactor, error) {
+	switch a.config.Storage {
+	case configs.StorageMongo:
+		if _, err := a.connectMongo(); err != nil {
+			return nil, err
+		}
+		return repository.NewTransactor(a.mongoClient), nil
+	case configs.StorageMemory:
+		return memory.NewTransactor(a.memoryStore), nil
+	case configs.StorageSQLite:
+		db, err := a.openSQLite()
+		if err != nil {
+			return nil, err
+		}
+		return sqlite.NewTransactor(db), nil
+	default:
+		return nil, fmt.Errorf("unknown storage %q", a.config.Storage)
 	}
-	return gameInterface, err
 }
 
 // createDeveloperService creates a new DeveloperService instance.
-// Takes DeveloperRepositorer and GameRepositorer interfaces as parameters.
+// Takes DeveloperRepositorer, GameRepositorer, LoanRepositorer, HoldRepositorer and Transactor interfaces as parameters.
 // Returns the DeveloperService instance or an error if the service cannot be created.
-func (a *App) createDeveloperService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer) (_interface.DeveloperServicer, error) {
-	developerService, err := service.NewDeveloperService(developerRepository, gameRepository, a.logger)
+func (a *App) createDeveloperService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, loanRepository _interface.LoanRepositorer, holdRepository _interface.HoldRepositorer, transactor _interface.Transactor) (_interface.DeveloperServicer, error) {
+	developerService, err := service.NewDeveloperService(developerRepository, gameRepository, loanRepository, holdRepository, transactor, a.logger)
 	if err != nil {
 		return nil, err
 	}
@@ -69,58 +281,483 @@ func (a *App) createDeveloperService(developerRepository _interface.DeveloperRep
 }
 
 // createGameService creates a new GameService instance.
-// Takes a GameRepositorer interface as a parameter.
+// Takes GameRepositorer, HoldRepositorer, LoanRepositorer, MemberRepositorer and Transactor interfaces as parameters.
 // Returns the GameService instance or an error if the service cannot be created.
-func (a *App) createGameService(gameRepository _interface.GameRepositorer) (_interface.GameServicer, error) {
-	gameService, err := service.NewGameService(gameRepository, a.logger)
+func (a *App) createGameService(gameRepository _interface.GameRepositorer, holdRepository _interface.HoldRepositorer, loanRepository _interface.LoanRepositorer, memberRepository _interface.MemberRepositorer, transactor _interface.Transactor) (_interface.GameServicer, error) {
+	gameService, err := service.NewGameService(gameRepository, holdRepository, loanRepository, memberRepository, transactor, a.holdPickupWindow(), a.logger)
 	if err != nil {
 		return nil, err
 	}
 	return gameService, nil
 }
 
-// setUpRoutes sets up the routes for the application using the provided handler.
-// Registers routes for developers and games.
-func (a *App) setUpRoutes(handler *handler.Handler) {
-	devsEndpoints := handler.RegisterRoutesForDevelopers()
-	for _, endpoint := range devsEndpoints {
-		a.server.Router.HandleFunc(endpoint.Path, endpoint.Handler).Methods(endpoint.Method)
+// createMemberService creates a new MemberService instance with the configured pickup window.
+// Takes MemberRepositorer, LoanRepositorer, HoldRepositorer, GameRepositorer, CopyRepositorer, FineRepositorer and Transactor interfaces as parameters.
+// Returns the MemberService instance or an error if the service cannot be created.
+func (a *App) createMemberService(memberRepository _interface.MemberRepositorer, loanRepository _interface.LoanRepositorer, holdRepository _interface.HoldRepositorer, gameRepository _interface.GameRepositorer, copyRepository _interface.CopyRepositorer, fineRepository _interface.FineRepositorer, transactor _interface.Transactor) (_interface.MemberServicer, error) {
+	memberService, err := service.NewMemberService(memberRepository, loanRepository, holdRepository, gameRepository, copyRepository, fineRepository, transactor, a.holdPickupWindow(), a.logger)
+	if err != nil {
+		return nil, err
+	}
+	return memberService, nil
+}
+
+// createCopyService creates a new CopyService instance.
+// Takes CopyRepositorer, GameRepositorer, HoldRepositorer, MemberRepositorer and Transactor interfaces as parameters.
+// Returns the CopyService instance or an error if the service cannot be created.
+func (a *App) createCopyService(copyRepository _interface.CopyRepositorer, gameRepository _interface.GameRepositorer, holdRepository _interface.HoldRepositorer, memberRepository _interface.MemberRepositorer, transactor _interface.Transactor) (_interface.CopyServicer, error) {
+	copyService, err := service.NewCopyService(copyRepository, gameRepository, holdRepository, memberRepository, transactor, a.holdPickupWindow(), a.logger)
+	if err != nil {
+		return nil, err
 	}
+	return copyService, nil
+}
 
-	gamesEndpoints := handler.RegisterRoutesForGames()
-	for _, endpoint := range gamesEndpoints {
-		a.server.Router.HandleFunc(endpoint.Path, endpoint.Handler).Methods(endpoint.Method)
+// createLoanService creates a new LoanService instance with the configured loan period and fine policy.
+// Takes LoanRepositorer, GameRepositorer, MemberRepositorer, CopyRepositorer, HoldRepositorer, FineRepositorer and Transactor interfaces as parameters.
+// Returns the LoanService instance or an error if the service cannot be created.
+func (a *App) createLoanService(loanRepository _interface.LoanRepositorer, gameRepository _interface.GameRepositorer, memberRepository _interface.MemberRepositorer, copyRepository _interface.CopyRepositorer, holdRepository _interface.HoldRepositorer, fineRepository _interface.FineRepositorer, transactor _interface.Transactor) (_interface.LoanServicer, error) {
+	loanPeriod := time.Duration(a.config.LoanDays) * 24 * time.Hour
+	loanService, err := service.NewLoanService(loanRepository, gameRepository, memberRepository, copyRepository, holdRepository, fineRepository, transactor, loanPeriod, a.holdPickupWindow(), a.finePolicy(), a.logger)
+	if err != nil {
+		return nil, err
 	}
+	return loanService, nil
+}
+
+// createHoldService creates a new HoldService instance with the configured pickup window.
+// Takes HoldRepositorer, GameRepositorer, MemberRepositorer, CopyRepositorer and Transactor interfaces as parameters.
+// Returns the HoldService instance or an error if the service cannot be created.
+func (a *App) createHoldService(holdRepository _interface.HoldRepositorer, gameRepository _interface.GameRepositorer, memberRepository _interface.MemberRepositorer, copyRepository _interface.CopyRepositorer, transactor _interface.Transactor) (_interface.HoldServicer, error) {
+	holdService, err := service.NewHoldService(holdRepository, gameRepository, memberRepository, copyRepository, transactor, a.holdPickupWindow(), a.logger)
+	if err != nil {
+		return nil, err
+	}
+	return holdService, nil
+}
+
+// createFineService creates a new FineService instance with the configured fine policy.
+// Takes FineRepositorer, MemberRepositorer, LoanRepositorer and Transactor interfaces as parameters.
+// Returns the FineService instance or an error if the service cannot be created.
+func (a *App) createFineService(fineRepository _interface.FineRepositorer, memberRepository _interface.MemberRepositorer, loanRepository _interface.LoanRepositorer, transactor _interface.Transactor) (_interface.FineServicer, error) {
+	fineService, err := service.NewFineService(fineRepository, memberRepository, loanRepository, transactor, a.finePolicy(), a.logger)
+	if err != nil {
+		return nil, err
+	}
+	return fineService, nil
+}
+
+// createImportService creates a new ImportService instance.
+// Takes DeveloperRepositorer, GameRepositorer and Transactor interfaces as parameters.
+// Returns the ImportService instance or an error if the service cannot be created.
+func (a *App) createImportService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, transactor _interface.Transactor) (_interface.ImportServicer, error) {
+	importService, err := service.NewImportService(developerRepository, gameRepository, transactor, a.logger)
+	if err != nil {
+		return nil, err
+	}
+	return importService, nil
+}
+
+// createExportService creates a new ExportService instance.
+// Takes DeveloperRepositorer and GameRepositorer interfaces as parameters.
+// Returns the ExportService instance or an error if the service cannot be created.
+func (a *App) createExportService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer) (_interface.ExportServicer, error) {
+	exportService, err := service.NewExportService(developerRepository, gameRepository, a.logger)
+	if err != nil {
+		return nil, err
+	}
+	return exportService, nil
+}
+
+// createBackupService creates a new BackupService instance.
+// Takes DeveloperRepositorer, GameRepositorer, CopyRepositorer and Transactor interfaces as parameters.
+// Returns the BackupService instance or an error if the service cannot be created.
+func (a *App) createBackupService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, copyRepository _interface.CopyRepositorer, transactor _interface.Transactor) (_interface.BackupServicer, error) {
+	backupService, err := service.NewBackupService(developerRepository, gameRepository, copyRepository, transactor, a.logger)
+	if err != nil {
+		return nil, err
+	}
+	return backupService, nil
+}
+
+// finePolicy returns the configured late fee rate, cap and borrowing threshold.
+func (a *App) finePolicy() service.FinePolicy {
+	return service.FinePolicy{
+		DailyRate: int64(a.config.FineDailyRate),
+		Cap:       int64(a.config.FineCap),
+		Threshold: int64(a.config.FineThreshold),
+	}
+}
+
+// holdPickupWindow returns how long a game reserved for a hold waits for the member.
+func (a *App) holdPickupWindow() time.Duration {
+	return time.Duration(a.config.HoldPickupDays) * 24 * time.Hour
+}
+
+// apiInfo describes the API in the OpenAPI document.
+var apiInfo = openapi.Info{
+	Title:       "Game Library Management System",
+	Description: "Developers, games and their copies, and the members who borrow them.",
+	Version:     "1.0.0",
+}
+
+// legacyRoutesDeprecated is the day the unversioned routes were deprecated in favour of /api/v1.
+var legacyRoutesDeprecated = time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+
+// apiVersion is a version of the API, served under /api/<Name> with its own OpenAPI document.
+type apiVersion struct {
+	Name      string
+	Info      openapi.Info
+	Endpoints []handler.Endpoint
+}
+
+// apiVersions returns the versions of the API, oldest first, each with the endpoints of its handler set.
+// A new version is added here with a handler set of its own built on the same services,
+// and is served side by side with the older ones.
+func apiVersions(services handler.Services) []apiVersion {
+	v1 := handler.NewHandler(services)
+	return []apiVersion{
+		{
+			Name: "v1",
+			Info: apiInfo,
+			Endpoints: slices.Concat(
+				v1.RegisterRoutesForDevelopers(),
+				v1.RegisterRoutesForGames(),
+				v1.RegisterRoutesForCopies(),
+				v1.RegisterRoutesForMembers(),
+				v1.RegisterRoutesForLoans(),
+				v1.RegisterRoutesForHolds(),
+				v1.RegisterRoutesForFines(),
+				v1.RegisterRoutesForImport(),
+				v1.RegisterRoutesForExport(),
+			),
+		},
+	}
+}
+
+// setUpRoutes sets up the routes for the application using the handler sets of the services.
+// Each API version is served under /api/<version>, with its OpenAPI document at openapi.json and a Swagger UI at docs.
+// The first version is also served at the root, as the deprecated routes it replaced, until the configured sunset.
+// From then on the root routes answer 410 Gone with a link to their successor.
+// Returns an error if an endpoint has no OpenAPI operation, so that an undocumented route fails at startup.
+func (a *App) setUpRoutes(services handler.Services) error {
+	versions := apiVersions(services)
+	for _, version := range versions {
+		prefix := "/api/" + version.Name
+		router := a.server.Router.PathPrefix(prefix).Subrouter()
+		router.Use(handler.BasePath(prefix))
+		if err := serveAPI(router, prefix, version); err != nil {
+			return err
+		}
+	}
+
+	// The legacy routes are registered last, so that the versioned ones are matched first.
+	// Their documentation describes the versioned routes that replace them.
+	legacy := a.server.Router.NewRoute().Subrouter()
+	successor := "/api/" + versions[0].Name
+	legacy.Use(handler.Deprecated(legacyRoutesDeprecated, a.config.LegacyRoutesSunset, successor))
+	return serveAPI(legacy, successor, versions[0])
+}
+
+// serveAPI registers the endpoints of a version on router, with their OpenAPI document and its Swagger UI,
+// whose scripts and styles are under docs.
+// serverURL is the path the document gives as the base of the endpoints.
+func serveAPI(router *mux.Router, serverURL string, version apiVersion) error {
+	document := openapi.NewDocument(version.Info, handler.Problem{})
+	document.Servers = []openapi.Server{{URL: serverURL}}
+	for _, endpoint := range version.Endpoints {
+		if err := document.Add(endpoint.Method, endpoint.Path, endpoint.Spec); err != nil {
+			return err
+		}
+		router.HandleFunc(endpoint.Path, endpoint.Handler).Methods(endpoint.Method)
+	}
+
+	serveDocument, err := document.Handler()
+	if err != nil {
+		return err
+	}
+	serveUI, err := openapi.UIHandler(version.Info.Title, serverURL+"/openapi.json", serverURL+"/docs")
+	if err != nil {
+		return err
+	}
+	router.HandleFunc("/openapi.json", serveDocument).Methods("GET")
+	router.HandleFunc("/docs", serveUI).Methods("GET")
+	router.HandleFunc("/docs/{file}", openapi.AssetsHandler()).Methods("GET")
+	return nil
+}
+
+// close releases the storage connections opened by the repositories.
+func (a *App) close() {
+	if a.mongoClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := a.mongoClient.Disconnect(ctx); err != nil {
+			a.logger.Error("Error disconnecting from MongoDB", zap.Error(err))
+		}
+	}
+	if a.sqliteDB != nil {
+		if err := a.sqliteDB.Close(); err != nil {
+			a.logger.Error("Error closing SQLite database", zap.Error(err))
+		}
+	}
+}
+
+// createServices initializes the repositories for the configured storage and the services built on them.
+// Returns the services or an error if any of them cannot be created.
+func (a *App) createServices() (handler.Services, error) {
+	var services handler.Services
+
+	developerRepository, err := a.createDeveloperRepository()
+	if err != nil {
+		return services, err
+	}
+
+	gameRepository, err := a.createGameRepository()
+	if err != nil {
+		return services, err
+	}
+
+	memberRepository, err := a.createMemberRepository()
+	if err != nil {
+		return services, err
+	}
+
+	loanRepository, err := a.createLoanRepository()
+	if err != nil {
+		return services, err
+	}
+
+	copyRepository, err := a.createCopyRepository()
+	if err != nil {
+		return services, err
+	}
+
+	holdRepository, err := a.createHoldRepository()
+	if err != nil {
+		return services, err
+	}
+
+	fineRepository, err := a.createFineRepository()
+	if err != nil {
+		return services, err
+	}
+
+	transactor, err := a.createTransactor()
+	if err != nil {
+		return services, err
+	}
+
+	if services.Games, err = a.createGameService(gameRepository, holdRepository, loanRepository, memberRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Developers, err = a.createDeveloperService(developerRepository, gameRepository, loanRepository, holdRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Members, err = a.createMemberService(memberRepository, loanRepository, holdRepository, gameRepository, copyRepository, fineRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Copies, err = a.createCopyService(copyRepository, gameRepository, holdRepository, memberRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Loans, err = a.createLoanService(loanRepository, gameRepository, memberRepository, copyRepository, holdRepository, fineRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Holds, err = a.createHoldService(holdRepository, gameRepository, memberRepository, copyRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Fines, err = a.createFineService(fineRepository, memberRepository, loanRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Import, err = a.createImportService(developerRepository, gameRepository, transactor); err != nil {
+		return services, err
+	}
+
+	if services.Export, err = a.createExportService(developerRepository, gameRepository); err != nil {
+		return services, err
+	}
+
+	if services.Backup, err = a.createBackupService(developerRepository, gameRepository, copyRepository, transactor); err != nil {
+		return services, err
+	}
+
+	return services, nil
 }
 
 // Run starts the application by initializing repositories, services, and setting up routes.
+// The background jobs run until the server stops.
 func (a *App) Run() error {
-	developerRepository, err := a.createDeveloperRepository()
+	defer a.close()
+
+	services, err := a.createServices()
 	if err != nil {
 		return err
 	}
 
-	gameRepository, err := a.createGameRepository()
+	if err := a.setUpRoutes(services); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.runJobs(ctx, services)
+
+	err = a.server.Start()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Import adds the developers and games of the file named by the command line arguments and prints the report as JSON.
+// The report is printed too when a read or storage error stops the import, before the error is returned.
+// The arguments are the flags -format, -dry-run and -batch-size followed by the file; the format defaults to the file extension.
+func (a *App) Import(args []string) error {
+	defer a.close()
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "file format, csv or ndjson (default: from the file extension)")
+	dryRun := flags.Bool("dry-run", false, "check the file and report without writing")
+	batchSize := flags.Int("batch-size", 0, "rows written per transaction (default 100)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import [-format csv|ndjson] [-dry-run] [-batch-size n] file")
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
+	defer file.Close()
 
-	gameService, err := a.createGameService(gameRepository)
+	services, err := a.createServices()
 	if err != nil {
 		return err
 	}
 
-	developerService, err := a.createDeveloperService(developerRepository, gameRepository)
+	report, err := services.Import.Import(context.Background(), model.ImportFile{Format: model.FileFormat(*format), Body: file},
+		model.ImportOptions{DryRun: *dryRun, BatchSize: *batchSize})
 	if err != nil {
+		if report != nil {
+			_ = printJSON(report)
+		}
 		return err
 	}
 
-	a.setUpRoutes(handler.NewHandler(developerService, gameService))
+	return printJSON(report)
+}
 
-	err = a.server.Start()
+// Backup writes the games and developers to the backup archive named by the command line arguments and prints its manifest as JSON.
+// An existing file is not overwritten, and the file is removed if the backup fails.
+func (a *App) Backup(args []string) error {
+	defer a.close()
+
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: backup file")
+	}
+	path := flags.Arg(0)
+
+	services, err := a.createServices()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	manifest, err := services.Backup.Backup(context.Background(), file)
+	if err == nil {
+		err = file.Close()
+	} else {
+		_ = file.Close()
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	return printJSON(manifest)
+}
+
+// Restore writes the games and developers of the backup archive named by the command line arguments and prints the report as JSON.
+// The arguments are the flags -on-conflict and -dry-run followed by the file.
+func (a *App) Restore(args []string) error {
+	defer a.close()
+
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	onConflict := flags.String("on-conflict", string(model.ConflictFail), "what to do with records already stored: fail, skip or replace")
+	dryRun := flags.Bool("dry-run", false, "check the archive and report without writing")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: restore [-on-conflict fail|skip|replace] [-dry-run] file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	services, err := a.createServices()
+	if err != nil {
+		return err
+	}
+
+	report, err := services.Backup.Restore(context.Background(), file, info.Size(),
+		model.RestoreOptions{OnConflict: model.ConflictPolicy(*onConflict), DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	return printJSON(report)
+}
+
+// printJSON prints v to standard output as indented JSON.
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// Repair rewrites games whose embedded developer copy diverged from the developers collection.
+// It is meant to be run once by hand after upgrading from a version that did not propagate developer updates.
+func (a *App) Repair() error {
+	defer a.close()
+
+	services, err := a.createServices()
+	if err != nil {
+		return err
+	}
+
+	repaired, err := services.Developers.RepairGameDevelopers(context.Background())
 	if err != nil {
 		return err
 	}
 
+	a.logger.Info("Repair finished", zap.Int64("games", repaired))
 	return nil
 }
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"game-library-management-system/src/handler"
+	"go.uber.org/zap"
+	"time"
+)
+
+// runJobs periodically fines overdue loans and expires the holds whose pickup window has ended.
+// The jobs run once at start and then every configured interval until ctx is cancelled.
+// Errors are logged and the jobs are tried again at the next interval.
+func (a *App) runJobs(ctx context.Context, services handler.Services) {
+	ticker := time.NewTicker(time.Duration(a.config.OverdueCheckMinutes) * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		if _, err := services.Fines.AssessOverdueLoans(ctx); err != nil {
+			a.logger.Error("Overdue loan job failed", zap.Error(err))
+		}
+		if _, err := services.Holds.ExpireHolds(ctx); err != nil {
+			a.logger.Error("Hold expiry job failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package apperr defines the error kinds shared by the repository, service and handler layers.
+// Repositories and services return errors of these kinds; the handler maps each kind to an HTTP status.
+// Errors of any other kind are treated as internal and their message is not shown to clients.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotFound means the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidID means an ID is not a well-formed identifier.
+	ErrInvalidID = errors.New("invalid id")
+	// ErrConflict means the request clashes with the current state of a resource.
+	ErrConflict = errors.New("conflict")
+	// ErrValidation means the request is well-formed but its content is not acceptable.
+	ErrValidation = errors.New("validation failed")
+	// ErrVersionMismatch means a conditional write expected another version of the resource.
+	// It is a kind of ErrConflict.
+	ErrVersionMismatch = fmt.Errorf("version mismatch: %w", ErrConflict)
+)
+
+// Error is an error of one of the kinds above with a message that is safe to show to clients.
+type Error struct {
+	Kind    error
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// NotFound returns an ErrNotFound error with a formatted message.
+func NotFound(format string, args ...any) error {
+	return &Error{Kind: ErrNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// InvalidID returns an ErrInvalidID error for the given ID.
+func InvalidID(id string) error {
+	return &Error{Kind: ErrInvalidID, Message: fmt.Sprintf("invalid id %q", id)}
+}
+
+// Conflict returns an ErrConflict error with a formatted message.
+func Conflict(format string, args ...any) error {
+	return &Error{Kind: ErrConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// VersionMismatch returns an ErrVersionMismatch error for a resource found at current instead of expected.
+func VersionMismatch(resource string, current, expected int64) error {
+	return &Error{Kind: ErrVersionMismatch, Message: fmt.Sprintf("%s is at version %d, not %d", resource, current, expected)}
+}
+
+// Validation returns an ErrValidation error with a formatted message.
+func Validation(format string, args ...any) error {
+	return &Error{Kind: ErrValidation, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,192 @@
+// Package backup writes and reads backup archives. An archive is a zip file holding one JSON lines file per
+// collection and a manifest.json with the number of records and the SHA-256 checksum of each file.
+package backup
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/model"
+	"hash"
+	"io"
+	"time"
+)
+
+const (
+	// Format is the Format of every manifest written by this package.
+	Format = "game-library-backup"
+	// Version is the layout of the archives written by this package. Archives of a later version cannot be read.
+	Version = 1
+
+	manifestFile = "manifest.json"
+)
+
+// ErrCorrupt is wrapped by the errors reporting an archive that cannot be read or does not match its manifest.
+var ErrCorrupt = errors.New("corrupt backup archive")
+
+// corrupt returns an error wrapping ErrCorrupt.
+func corrupt(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrCorrupt, fmt.Sprintf(format, args...))
+}
+
+// Writer writes a backup archive one collection at a time.
+type Writer struct {
+	zip      *zip.Writer
+	manifest model.BackupManifest
+	// collection is the collection being written and hash the checksum of its file so far.
+	collection *model.BackupCollection
+	hash       hash.Hash
+	encoder    *json.Encoder
+}
+
+// NewWriter creates a Writer of an archive created at createdAt.
+func NewWriter(w io.Writer, createdAt time.Time) *Writer {
+	return &Writer{
+		zip: zip.NewWriter(w),
+		manifest: model.BackupManifest{
+			Format:      Format,
+			Version:     Version,
+			CreatedAt:   createdAt.UTC(),
+			Collections: make([]model.BackupCollection, 0),
+		},
+	}
+}
+
+// Create starts the file of a collection. The records added until the next Create or Close go to it.
+func (w *Writer) Create(name string) error {
+	w.finish()
+	file := name + ".jsonl"
+	entry, err := w.zip.CreateHeader(&zip.FileHeader{Name: file, Method: zip.Deflate, Modified: w.manifest.CreatedAt})
+	if err != nil {
+		return err
+	}
+	w.collection = &model.BackupCollection{Name: name, File: file}
+	w.hash = sha256.New()
+	w.encoder = json.NewEncoder(io.MultiWriter(entry, w.hash))
+	return nil
+}
+
+// Add writes a record of the current collection as one line.
+func (w *Writer) Add(record any) error {
+	if w.collection == nil {
+		return errors.New("no collection to add to")
+	}
+	if err := w.encoder.Encode(record); err != nil {
+		return err
+	}
+	w.collection.Count++
+	return nil
+}
+
+// finish records the current collection in the manifest.
+func (w *Writer) finish() {
+	if w.collection == nil {
+		return
+	}
+	w.collection.SHA256 = hex.EncodeToString(w.hash.Sum(nil))
+	w.manifest.Collections = append(w.manifest.Collections, *w.collection)
+	w.collection = nil
+}
+
+// Close writes the manifest and finishes the archive. It returns the manifest.
+func (w *Writer) Close() (*model.BackupManifest, error) {
+	w.finish()
+	entry, err := w.zip.CreateHeader(&zip.FileHeader{Name: manifestFile, Method: zip.Deflate, Modified: w.manifest.CreatedAt})
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(w.manifest); err != nil {
+		return nil, err
+	}
+	if err := w.zip.Close(); err != nil {
+		return nil, err
+	}
+	return &w.manifest, nil
+}
+
+// Reader reads the collections of a backup archive.
+type Reader struct {
+	zip      *zip.Reader
+	Manifest model.BackupManifest
+}
+
+// NewReader opens the archive of the given size and reads its manifest.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, corrupt("%v", err)
+	}
+	reader := &Reader{zip: archive}
+
+	file, err := archive.Open(manifestFile)
+	if err != nil {
+		return nil, corrupt("no %s", manifestFile)
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(&reader.Manifest); err != nil {
+		return nil, corrupt("%s: %v", manifestFile, err)
+	}
+	if reader.Manifest.Format != Format {
+		return nil, corrupt("not a backup archive")
+	}
+	if reader.Manifest.Version < 1 || reader.Manifest.Version > Version {
+		return nil, fmt.Errorf("backup archive version %d is not supported, at most %d is", reader.Manifest.Version, Version)
+	}
+	return reader, nil
+}
+
+// collection returns the manifest entry of a collection.
+func (r *Reader) collection(name string) (model.BackupCollection, error) {
+	for _, c := range r.Manifest.Collections {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return model.BackupCollection{}, corrupt("no %s collection", name)
+}
+
+// Each calls fn with each record of a collection in the order they were added.
+// After the last record, the number of records and the checksum of the file are checked against the manifest.
+// Iteration stops at the first error returned by fn.
+func Each[T any](r *Reader, name string, fn func(T) error) error {
+	collection, err := r.collection(name)
+	if err != nil {
+		return err
+	}
+	file, err := r.zip.Open(collection.File)
+	if err != nil {
+		return corrupt("%s: %v", collection.File, err)
+	}
+	defer file.Close()
+
+	sum := sha256.New()
+	decoder := json.NewDecoder(io.TeeReader(file, sum))
+	count := 0
+	for {
+		var record T
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return corrupt("%s record %d: %v", collection.File, count+1, err)
+		}
+		count++
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+
+	if count != collection.Count {
+		return corrupt("%s has %d records, the manifest says %d", collection.File, count, collection.Count)
+	}
+	if checksum := hex.EncodeToString(sum.Sum(nil)); checksum != collection.SHA256 {
+		return corrupt("%s does not match its checksum", collection.File)
+	}
+	return nil
+}
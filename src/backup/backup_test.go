@@ -0,0 +1,195 @@
+package backup_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"game-library-management-system/src/backup"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type record struct {
+	ID   int
+	Name string
+}
+
+// write returns an archive of a developers and a games collection
+func write(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := backup.NewWriter(&buf, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	collections := []struct {
+		name    string
+		records []record
+	}{
+		{"developers", []record{{1, "CD Projekt"}, {2, "Valve \"Software\"\n"}}},
+		{"games", []record{{3, "The Witcher"}}},
+	}
+	for _, c := range collections {
+		if err := w.Create(c.name); err != nil {
+			t.Fatalf("Create(%s): %v", c.name, err)
+		}
+		for _, r := range c.records {
+			if err := w.Add(r); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+	}
+	if _, err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// rewrite returns a copy of an archive with the file name changed by edit, or left out if edit returns nil
+func rewrite(t *testing.T, archive []byte, name string, edit func([]byte) []byte) []byte {
+	t.Helper()
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open(%s): %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("ReadAll(%s): %v", f.Name, err)
+		}
+		if f.Name == name {
+			if content = edit(content); content == nil {
+				continue
+			}
+		}
+		entry, err := w.Create(f.Name)
+		if err != nil {
+			t.Fatalf("Create(%s): %v", f.Name, err)
+		}
+		if _, err := entry.Write(content); err != nil {
+			t.Fatalf("Write(%s): %v", f.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// read opens an archive and returns the records of a collection
+func read(archive []byte, name string) ([]record, error) {
+	r, err := backup.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	err = backup.Each(r, name, func(rec record) error {
+		records = append(records, rec)
+		return nil
+	})
+	return records, err
+}
+
+// TestRoundTrip checks that the records and manifest written are read back.
+func TestRoundTrip(t *testing.T) {
+	archive := write(t)
+	r, err := backup.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if r.Manifest.Format != backup.Format || r.Manifest.Version != backup.Version {
+		t.Errorf("manifest is %s version %d, want %s version %d", r.Manifest.Format, r.Manifest.Version, backup.Format, backup.Version)
+	}
+	if !r.Manifest.CreatedAt.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("manifest created at %v", r.Manifest.CreatedAt)
+	}
+	counts := map[string]int{}
+	for _, c := range r.Manifest.Collections {
+		counts[c.Name] = c.Count
+		if c.File != c.Name+".jsonl" || len(c.SHA256) != 64 {
+			t.Errorf("collection %s has file %q and checksum %q", c.Name, c.File, c.SHA256)
+		}
+	}
+	if len(counts) != 2 || counts["developers"] != 2 || counts["games"] != 1 {
+		t.Errorf("manifest counts = %v, want 2 developers and 1 game", counts)
+	}
+
+	developers, err := read(archive, "developers")
+	if err != nil {
+		t.Fatalf("Each(developers): %v", err)
+	}
+	want := []record{{1, "CD Projekt"}, {2, "Valve \"Software\"\n"}}
+	if len(developers) != len(want) || developers[0] != want[0] || developers[1] != want[1] {
+		t.Errorf("developers = %v, want %v", developers, want)
+	}
+}
+
+// TestCorrupt checks that archives not matching their manifest are reported as corrupt.
+func TestCorrupt(t *testing.T) {
+	archive := write(t)
+	tests := []struct {
+		name    string
+		archive []byte
+		read    string
+	}{
+		{"not a zip file", []byte("developers,games"), "developers"},
+		{"no manifest", rewrite(t, archive, "manifest.json", func([]byte) []byte { return nil }), "developers"},
+		{"not a backup manifest", rewrite(t, archive, "manifest.json", func(b []byte) []byte {
+			return bytes.Replace(b, []byte(backup.Format), []byte("something-else"), 1)
+		}), "developers"},
+		{"missing collection", archive, "members"},
+		{"missing file", rewrite(t, archive, "games.jsonl", func([]byte) []byte { return nil }), "games"},
+		{"changed record", rewrite(t, archive, "developers.jsonl", func(b []byte) []byte {
+			return bytes.Replace(b, []byte("CD Projekt"), []byte("CD Project"), 1)
+		}), "developers"},
+		{"removed record", rewrite(t, archive, "developers.jsonl", func(b []byte) []byte {
+			return b[bytes.IndexByte(b, '\n')+1:]
+		}), "developers"},
+		{"added record", rewrite(t, archive, "games.jsonl", func(b []byte) []byte {
+			return append(b, []byte("{\"ID\":4,\"Name\":\"Portal\"}\n")...)
+		}), "games"},
+		{"garbage record", rewrite(t, archive, "games.jsonl", func(b []byte) []byte {
+			return append(b, []byte("{\"ID\":\n")...)
+		}), "games"},
+	}
+	for _, tt := range tests {
+		if _, err := read(tt.archive, tt.read); !errors.Is(err, backup.ErrCorrupt) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, backup.ErrCorrupt)
+		}
+	}
+}
+
+// TestNewerVersion checks that an archive of a later version is refused without being called corrupt.
+func TestNewerVersion(t *testing.T) {
+	archive := rewrite(t, write(t), "manifest.json", func(b []byte) []byte {
+		return []byte(strings.Replace(string(b), `"Version": 1`, `"Version": 2`, 1))
+	})
+	_, err := read(archive, "developers")
+	if err == nil || errors.Is(err, backup.ErrCorrupt) {
+		t.Errorf("error = %v, want an unsupported version", err)
+	}
+}
+
+// TestEachStops checks that the error of fn stops the iteration and is returned as it is.
+func TestEachStops(t *testing.T) {
+	archive := write(t)
+	r, err := backup.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	errStop := errors.New("stop")
+	calls := 0
+	err = backup.Each(r, "developers", func(record) error {
+		calls++
+		return errStop
+	})
+	if err != errStop || calls != 1 {
+		t.Errorf("Each = %v after %d calls, want %v after 1", err, calls, errStop)
+	}
+}
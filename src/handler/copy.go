@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// GetCopies handles the HTTP request to retrieve a page of the copies of a game.
+// Supports the cursor/limit pagination query parameters.
+func (h *Handler) GetCopies(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	query, err := parseCopyQuery(id, r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.copyService.ListCopies(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// GetCopy handles the HTTP request to retrieve a copy by ID.
+// Replies 304 Not Modified when If-None-Match matches the copy's ETag.
+func (h *Handler) GetCopy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	gameCopy, err := h.copyService.GetCopyById(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if notModified(w, r, gameCopy.Version) {
+		return
+	}
+	setETag(w, gameCopy.Version)
+	writeJSON(w, http.StatusOK, gameCopy)
+}
+
+// LookupCopy handles the HTTP request to find a copy by the barcode query parameter.
+func (h *Handler) LookupCopy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	barcode := r.URL.Query().Get("barcode")
+	if barcode == "" {
+		writeProblem(w, r, http.StatusBadRequest, "barcode is required")
+		return
+	}
+
+	gameCopy, err := h.copyService.GetCopyByBarcode(ctx, barcode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, gameCopy.Version)
+	w.Header().Set("Content-Location", resourcePath(r, "/copies/"+gameCopy.ID.Hex()))
+	writeJSON(w, http.StatusOK, gameCopy)
+}
+
+// CreateCopy handles the HTTP request to add a copy to a game.
+// The copy starts out available.
+func (h *Handler) CreateCopy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var gameCopy model.Copy
+	if err := decodeJSON(r, &gameCopy); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.copyService.AddCopy(ctx, id, gameCopy)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, created.Version)
+	w.Header().Set("Location", resourcePath(r, "/copies/"+created.ID.Hex()))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// UpdateCopy handles the HTTP request to update a copy's barcode, condition, platform and shelf location.
+// The If-Match header, or else a non-zero Version in the body, must match the stored version.
+func (h *Handler) UpdateCopy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+	var gameCopy model.Copy
+	if err := decodeJSON(r, &gameCopy); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if version != 0 {
+		gameCopy.Version = version
+	}
+
+	updated, err := h.copyService.UpdateCopy(ctx, id, gameCopy)
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, updated.Version)
+	w.Header().Set("Location", r.URL.Path)
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// DeleteCopy handles the HTTP request to delete a copy by ID.
+// Copies on loan cannot be deleted. An If-Match header must match the stored version.
+func (h *Handler) DeleteCopy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	if err := h.copyService.DeleteCopy(ctx, id, version); err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutesForCopies registers the routes for the copies of games.
+func (h *Handler) RegisterRoutesForCopies() []Endpoint {
+	return []Endpoint{
+		{Path: "/games/{id}/copies", Handler: h.GetCopies, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "copies",
+			Summary:    "List the copies of a game",
+			Parameters: cursorParams(),
+			Responses:  []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of the copies of the game, in the order they were added.", model.Page[model.Copy]{})},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/games/{id}/copies", Handler: h.CreateCopy, Method: "POST", Spec: &openapi.Operation{
+			Tag:       "copies",
+			Summary:   "Add a copy to a game",
+			Request:   openapi.JSON("The copy. It starts out available.", model.Copy{}),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusCreated, "The created copy.", model.Copy{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/copies/lookup", Handler: h.LookupCopy, Method: "GET", Spec: &openapi.Operation{
+			Tag:     "copies",
+			Summary: "Find a copy by barcode",
+			Parameters: []openapi.Parameter{
+				{Name: "barcode", In: "query", Type: "string", Required: true, Description: "The barcode of the copy."},
+			},
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The copy.", model.Copy{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/copies/{id}", Handler: h.GetCopy, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "copies",
+			Summary:    "Get a copy",
+			Parameters: []openapi.Parameter{ifNoneMatchParam},
+			Responses: []openapi.Response{
+				openapi.JSONResponse(http.StatusOK, "The copy, with its version as the ETag.", model.Copy{}),
+				notModifiedResponse,
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/copies/{id}", Handler: h.UpdateCopy, Method: "PUT", Spec: &openapi.Operation{
+			Tag:         "copies",
+			Summary:     "Update a copy",
+			Description: "Updates the barcode, condition, platform and shelf location. Without If-Match, a non-zero Version in the body must match.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Request:     openapi.JSON("The copy.", model.Copy{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated copy.", model.Copy{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/copies/{id}", Handler: h.DeleteCopy, Method: "DELETE", Spec: &openapi.Operation{
+			Tag:         "copies",
+			Summary:     "Delete a copy",
+			Description: "Copies on loan cannot be deleted.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Responses:   []openapi.Response{noContentResponse},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+		}},
+	}
+}
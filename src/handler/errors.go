@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/validation"
+	"net/http"
+)
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Errors lists the individual field violations of a validation failure.
+	Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// errorStatuses maps the apperr kinds to HTTP status codes.
+var errorStatuses = []struct {
+	kind   error
+	status int
+}{
+	{apperr.ErrNotFound, http.StatusNotFound},
+	{apperr.ErrInvalidID, http.StatusBadRequest},
+	{apperr.ErrConflict, http.StatusConflict},
+	{apperr.ErrValidation, http.StatusUnprocessableEntity},
+}
+
+// writeError writes err as a problem document.
+// Errors of a known apperr kind get their status and message; anything else is reported
+// as a 500 without details, since it may carry driver internals. The service layer logs those.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := errorProblem(r, err)
+	encodeProblem(w, problem.Status, problem)
+}
+
+// errorProblem creates the problem document that writeError writes for err.
+func errorProblem(r *http.Request, err error) Problem {
+	var fieldErrors validation.Errors
+	if errors.As(err, &fieldErrors) {
+		problem := newProblem(r, http.StatusUnprocessableEntity, "the request has invalid fields")
+		problem.Errors = fieldErrors
+		return problem
+	}
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.kind) {
+			return newProblem(r, e.status, err.Error())
+		}
+	}
+	return newProblem(r, http.StatusInternalServerError, "")
+}
+
+// writeProblem writes a problem document with the given status and detail.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	encodeProblem(w, status, newProblem(r, status, detail))
+}
+
+// newProblem creates a problem document for the request.
+func newProblem(r *http.Request, status int, detail string) Problem {
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	}
+}
+
+// encodeProblem writes a problem document, which may extend Problem with more members, with the status code.
+func encodeProblem(w http.ResponseWriter, status int, problem any) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"game-library-management-system/src/apperr"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errPreconditionFailed is returned by ifMatchVersion for an If-Match header that cannot match any version.
+var errPreconditionFailed = errors.New("the If-Match header does not match the current version")
+
+// etag returns the entity tag of a resource at the given version.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// setETag sets the ETag header for a resource at the given version.
+// Resources stored before versioning have version 0 and get no ETag.
+func setETag(w http.ResponseWriter, version int64) {
+	if version > 0 {
+		w.Header().Set("ETag", etag(version))
+	}
+}
+
+// ifMatchVersion returns the version required by the If-Match header of a write request.
+// It returns 0 when there is no header or it is "*", so the write is unconditional.
+// Weak or malformed entity tags can never match and yield errPreconditionFailed.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if strings.Contains(header, ",") {
+		return 0, errors.New("If-Match with more than one entity tag is not supported")
+	}
+	unquoted, ok := strings.CutPrefix(header, `"`)
+	if !ok {
+		return 0, errPreconditionFailed
+	}
+	unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	if !ok {
+		return 0, errPreconditionFailed
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errPreconditionFailed
+	}
+	return version, nil
+}
+
+// writeIfMatchError writes an error from ifMatchVersion.
+func writeIfMatchError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, errPreconditionFailed) {
+		status = http.StatusPreconditionFailed
+	}
+	writeProblem(w, r, status, err.Error())
+}
+
+// writeWriteError writes an error from a conditional write like writeError,
+// except that a version mismatch is reported as 412 when the version came from If-Match.
+func writeWriteError(w http.ResponseWriter, r *http.Request, err error) {
+	if r.Header.Get("If-Match") != "" && errors.Is(err, apperr.ErrVersionMismatch) {
+		writeProblem(w, r, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+	writeError(w, r, err)
+}
+
+// notModified reports whether the If-None-Match header of a read request matches the resource version.
+// If it does, it writes a 304 response and the caller must not write a body.
+func notModified(w http.ResponseWriter, r *http.Request, version int64) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" || version <= 0 {
+		return false
+	}
+	current := etag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			setETag(w, version)
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
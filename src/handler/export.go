@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"fmt"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"net/http"
+)
+
+// exportContentTypes maps the export formats to the content types they are served with.
+var exportContentTypes = map[model.FileFormat]string{
+	model.FormatCSV:    "text/csv; charset=utf-8",
+	model.FormatNDJSON: "application/x-ndjson",
+	model.FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportWriter sends the headers of an export with its first bytes, so that an error found before
+// anything was written can still be replied as a problem document.
+type exportWriter struct {
+	w        http.ResponseWriter
+	format   model.FileFormat
+	filename string
+	written  bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.written {
+		e.written = true
+		e.w.Header().Set("Content-Type", exportContentTypes[e.format])
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, e.filename, e.format))
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
+
+// exportFormat reads the format query parameter, which defaults to csv.
+func exportFormat(r *http.Request) model.FileFormat {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return model.FileFormat(format)
+	}
+	return model.FormatCSV
+}
+
+// finishExport replies an error of an export. An error after the export started cannot be
+// replied anymore, so the connection is aborted to keep the client from taking the file as complete.
+// The service has logged the error.
+func finishExport(w http.ResponseWriter, r *http.Request, out *exportWriter, err error) {
+	if err == nil {
+		return
+	}
+	if !out.written {
+		writeError(w, r, err)
+		return
+	}
+	panic(http.ErrAbortHandler)
+}
+
+// ExportGames handles the HTTP request to download the games as a file.
+// Supports the format (csv, ndjson or xlsx) query parameter and the filter and sort parameters of GetGames.
+func (h *Handler) ExportGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := parseGameQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	out := &exportWriter{w: w, format: exportFormat(r), filename: "games"}
+	err = h.exportService.ExportGames(ctx, query.Filter, query.Sort, out.format, out)
+	finishExport(w, r, out, err)
+}
+
+// ExportDevelopers handles the HTTP request to download the developers as a file.
+// Supports the format (csv, ndjson or xlsx) query parameter and the filter and sort parameters of GetDevelopers.
+func (h *Handler) ExportDevelopers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := parseDeveloperQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	out := &exportWriter{w: w, format: exportFormat(r), filename: "developers"}
+	err = h.exportService.ExportDevelopers(ctx, query.Filter, query.Sort, out.format, out)
+	finishExport(w, r, out, err)
+}
+
+// RegisterRoutesForExport registers the routes for exporting games and developers.
+func (h *Handler) RegisterRoutesForExport() []Endpoint {
+	return []Endpoint{
+		{Path: "/export/games", Handler: h.ExportGames, Method: "GET", Spec: &openapi.Operation{
+			Tag:         "catalogue",
+			Summary:     "Export games",
+			Description: "Takes the filters and sort of the game listing, without paging.",
+			Parameters:  append([]openapi.Parameter{exportFormatParam, sortParam("title", "genre", "year")}, gameFilterParams...),
+			Responses:   []openapi.Response{exportResponse},
+			Errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/export/developers", Handler: h.ExportDevelopers, Method: "GET", Spec: &openapi.Operation{
+			Tag:         "catalogue",
+			Summary:     "Export developers",
+			Description: "Takes the filters and sort of the developer listing, without paging.",
+			Parameters:  append([]openapi.Parameter{exportFormatParam, sortParam("name")}, developerFilterParams...),
+			Responses:   []openapi.Response{exportResponse},
+			Errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+	}
+}
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/handler"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/memory"
+	"game-library-management-system/src/service"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// failingExport is an export service that writes some bytes of a file, if any, then fails
+type failingExport struct {
+	written string
+}
+
+var errExport = errors.New("export failed")
+
+func (f failingExport) ExportGames(ctx context.Context, filter model.GameFilter, sort string, format model.FileFormat, w io.Writer) error {
+	if f.written != "" {
+		io.WriteString(w, f.written)
+	}
+	return errExport
+}
+
+func (f failingExport) ExportDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, format model.FileFormat, w io.Writer) error {
+	return f.ExportGames(ctx, model.GameFilter{}, sort, format, w)
+}
+
+// TestExport checks the replies of an export: the file with its headers, or a problem document for an error found before writing.
+func TestExport(t *testing.T) {
+	store := memory.NewStore()
+	exportService, err := service.NewExportService(memory.NewDeveloperRepository(store), memory.NewGameRepository(store), zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewExportService: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		export      handler.Services
+		target      string
+		status      int
+		contentType string
+		disposition string
+	}{
+		{"csv by default", handler.Services{Export: exportService}, "/export/developers", http.StatusOK, "text/csv; charset=utf-8", `attachment; filename="developers.csv"`},
+		{"xlsx", handler.Services{Export: exportService}, "/export/games?format=xlsx", http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", `attachment; filename="games.xlsx"`},
+		{"unsupported format", handler.Services{Export: exportService}, "/export/games?format=pdf", http.StatusUnprocessableEntity, "application/problem+json", ""},
+		{"invalid filter", handler.Services{Export: exportService}, "/export/games?yearFrom=soon", http.StatusBadRequest, "application/problem+json", ""},
+		{"error before writing", handler.Services{Export: failingExport{}}, "/export/games", http.StatusInternalServerError, "application/problem+json", ""},
+	}
+	for _, tt := range tests {
+		router := mux.NewRouter()
+		for _, endpoint := range handler.NewHandler(tt.export).RegisterRoutesForExport() {
+			router.HandleFunc(endpoint.Path, endpoint.Handler).Methods(endpoint.Method)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d (%s)", tt.name, rec.Code, tt.status, rec.Body)
+		}
+		if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("%s: Content-Type = %q, want %q", tt.name, got, tt.contentType)
+		}
+		if got := rec.Header().Get("Content-Disposition"); got != tt.disposition {
+			t.Errorf("%s: Content-Disposition = %q, want %q", tt.name, got, tt.disposition)
+		}
+	}
+}
+
+// TestExportAborted checks that an export failing after its first bytes aborts the reply instead of ending it like a complete file.
+func TestExportAborted(t *testing.T) {
+	h := handler.NewHandler(handler.Services{Export: failingExport{written: "ID,Title\n"}})
+	rec := httptest.NewRecorder()
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", r)
+		}
+		if rec.Code != http.StatusOK || rec.Body.String() != "ID,Title\n" {
+			t.Errorf("reply = %d %q, want the bytes written before the error", rec.Code, rec.Body)
+		}
+		if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="games.csv"` {
+			t.Errorf("Content-Disposition = %q", got)
+		}
+	}()
+	h.ExportGames(rec, httptest.NewRequest(http.MethodGet, "/export/games", nil))
+	t.Error("ExportGames returned after the export failed")
+}
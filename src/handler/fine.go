@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// payRequest is the body of a request to pay a fine. The amount is in cents.
+type payRequest struct {
+	Amount int64
+}
+
+// GetFines handles the HTTP request to retrieve a page of fines in the order they were first assessed.
+// Supports the member, loan and status filters, and cursor/limit pagination query parameters.
+func (h *Handler) GetFines(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := parseFineQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.fineService.ListFines(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// GetFine handles the HTTP request to retrieve a fine by ID.
+func (h *Handler) GetFine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	fine, err := h.fineService.GetFineById(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, fine.Version)
+	writeJSON(w, http.StatusOK, fine)
+}
+
+// GetMemberFines handles the HTTP request to retrieve the fines of a member and the balance they owe.
+func (h *Handler) GetMemberFines(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	account, err := h.fineService.GetFineAccount(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, account)
+}
+
+// PayFine handles the HTTP request to pay the amount given in the body towards an open fine.
+// An If-Match header must match the stored version.
+func (h *Handler) PayFine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	var request payRequest
+	if err := decodeJSON(r, &request); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fine, err := h.fineService.PayFine(ctx, id, request.Amount, version)
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, fine.Version)
+	writeJSON(w, http.StatusOK, fine)
+}
+
+// WaiveFine handles the HTTP request to waive an open fine.
+// An If-Match header must match the stored version.
+func (h *Handler) WaiveFine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	fine, err := h.fineService.WaiveFine(ctx, id, version)
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, fine.Version)
+	writeJSON(w, http.StatusOK, fine)
+}
+
+// AssessOverdueLoans handles the HTTP request to mark overdue loans and fine them right away
+// instead of waiting for the background job. Replies with the number of loans assessed.
+func (h *Handler) AssessOverdueLoans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	assessed, err := h.fineService.AssessOverdueLoans(ctx)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"Assessed": assessed})
+}
+
+// RegisterRoutesForFines registers the routes for fines, including the fine account of a member.
+func (h *Handler) RegisterRoutesForFines() []Endpoint {
+	return []Endpoint{
+		{Path: "/fines", Handler: h.GetFines, Method: "GET", Spec: &openapi.Operation{
+			Tag:     "fines",
+			Summary: "List fines",
+			Parameters: cursorParams(
+				memberFilterParam,
+				openapi.Query("loan", "string", "Only the fine of the loan with this ID."),
+				statusParam(model.FineOpen, model.FinePaid, model.FineWaived),
+			),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of fines in the order they were first assessed.", model.Page[model.Fine]{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/fines/assess", Handler: h.AssessOverdueLoans, Method: "POST", Spec: &openapi.Operation{
+			Tag:       "fines",
+			Summary:   "Mark overdue loans and fine them",
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The number of loans assessed.", map[string]int{})},
+		}},
+		{Path: "/fines/{id}", Handler: h.GetFine, Method: "GET", Spec: &openapi.Operation{
+			Tag:       "fines",
+			Summary:   "Get a fine",
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The fine.", model.Fine{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/fines/{id}/pay", Handler: h.PayFine, Method: "POST", Spec: &openapi.Operation{
+			Tag:         "fines",
+			Summary:     "Pay towards a fine",
+			Description: "The fine is paid once nothing is left to pay.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Request:     openapi.JSON("The amount paid, in cents.", payRequest{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated fine.", model.Fine{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/fines/{id}/waive", Handler: h.WaiveFine, Method: "POST", Spec: &openapi.Operation{
+			Tag:        "fines",
+			Summary:    "Waive a fine",
+			Parameters: []openapi.Parameter{ifMatchParam},
+			Responses:  []openapi.Response{openapi.JSONResponse(http.StatusOK, "The waived fine.", model.Fine{})},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+		}},
+		{Path: "/members/{id}/fines", Handler: h.GetMemberFines, Method: "GET", Spec: &openapi.Operation{
+			Tag:       "fines",
+			Summary:   "Get the fine account of a member",
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The fines of the member and the balance they owe.", model.FineAccount{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+	}
+}
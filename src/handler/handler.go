@@ -4,46 +4,93 @@ import (
 	"encoding/json"
 	_interface "game-library-management-system/src/interface"
 	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
 	"github.com/gorilla/mux"
 	"net/http"
+	"path"
 )
 
+const defaultSearchLimit = 20
+
 type Endpoint struct {
 	Path    string
 	Handler http.HandlerFunc
 	Method  string
+	// Spec documents the endpoint in the OpenAPI document. Every endpoint must have one.
+	Spec *openapi.Operation
 }
 
 type Handler struct {
-	developerService _interface.DeveloperRepositorer
+	developerService _interface.DeveloperServicer
 	gameService      _interface.GameServicer
+	memberService    _interface.MemberServicer
+	loanService      _interface.LoanServicer
+	copyService      _interface.CopyServicer
+	holdService      _interface.HoldServicer
+	fineService      _interface.FineServicer
+	importService    _interface.ImportServicer
+	exportService    _interface.ExportServicer
+}
+
+// Services are the services a Handler serves requests with.
+type Services struct {
+	Developers _interface.DeveloperServicer
+	Games      _interface.GameServicer
+	Members    _interface.MemberServicer
+	Loans      _interface.LoanServicer
+	Copies     _interface.CopyServicer
+	Holds      _interface.HoldServicer
+	Fines      _interface.FineServicer
+	Import     _interface.ImportServicer
+	Export     _interface.ExportServicer
+	// Backup is used by the backup and restore commands, not by any route.
+	Backup _interface.BackupServicer
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
 }
 
 // NewHandler creates a new Handler instance.
-func NewHandler(developerService _interface.DeveloperRepositorer, gameService _interface.GameServicer) *Handler {
+func NewHandler(services Services) *Handler {
 	return &Handler{
-		developerService: developerService,
-		gameService:      gameService,
+		developerService: services.Developers,
+		gameService:      services.Games,
+		memberService:    services.Members,
+		loanService:      services.Loans,
+		copyService:      services.Copies,
+		holdService:      services.Holds,
+		fineService:      services.Fines,
+		importService:    services.Import,
+		exportService:    services.Export,
 	}
 }
 
-// GetDevelopers handles the HTTP request to retrieve all developers.
+// GetDevelopers handles the HTTP request to retrieve a page of developers.
+// Supports the mainHq and name filters, sort, and cursor/limit pagination query parameters.
 func (h *Handler) GetDevelopers(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	developers, err := h.developerService.GetAllDevelopers(ctx)
+	query, err := parseDeveloperQuery(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(developers)
+	page, err := h.developerService.ListDevelopers(ctx, query)
 	if err != nil {
+		writeError(w, r, err)
 		return
 	}
+
+	writeJSON(w, http.StatusOK, page)
 }
 
 // GetDeveloper handles the HTTP request to retrieve a developer by ID.
+// Replies 304 Not Modified when If-None-Match matches the developer's ETag.
 func (h *Handler) GetDeveloper(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -52,13 +99,14 @@ func (h *Handler) GetDeveloper(w http.ResponseWriter, r *http.Request) {
 
 	developer, err := h.developerService.GetDeveloperById(ctx, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
 		return
 	}
-	err = json.NewEncoder(w).Encode(developer)
-	if err != nil {
+	if notModified(w, r, developer.Version) {
 		return
 	}
+	setETag(w, developer.Version)
+	writeJSON(w, http.StatusOK, developer)
 }
 
 // CreateDeveloper handles the HTTP request to create a new developer.
@@ -66,69 +114,103 @@ func (h *Handler) CreateDeveloper(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var developer model.Developer
-	if err := json.NewDecoder(r.Body).Decode(&developer); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &developer); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if _, err := h.developerService.AddDeveloper(ctx, developer); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	created, err := h.developerService.AddDeveloper(ctx, developer)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+	setETag(w, created.Version)
+	w.Header().Set("Location", path.Join(r.URL.Path, created.ID.Hex()))
+	writeJSON(w, http.StatusCreated, created)
 }
 
 // UpdateDeveloper handles the HTTP request to update an existing developer.
+// The If-Match header, or else a non-zero Version in the body, must match the stored version.
 func (h *Handler) UpdateDeveloper(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	vars := mux.Vars(r)
 	id := vars["id"]
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
 	var developer model.Developer
-	if err := json.NewDecoder(r.Body).Decode(&developer); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &developer); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
+	if version != 0 {
+		developer.Version = version
+	}
 
-	if _, err := h.developerService.UpdateDeveloper(ctx, id, developer); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	updated, err := h.developerService.UpdateDeveloper(ctx, id, developer)
+	if err != nil {
+		writeWriteError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
+	setETag(w, updated.Version)
+	w.Header().Set("Location", r.URL.Path)
+	writeJSON(w, http.StatusOK, updated)
 }
 
 // DeleteDeveloper handles the HTTP request to delete a developer by ID.
+// The onGames query parameter selects cascade (default), restrict or reassign;
+// reassign also needs the target developer ID in reassignTo. An If-Match header must match the stored version.
 func (h *Handler) DeleteDeveloper(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	if err := h.developerService.DeleteDeveloper(ctx, id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+	opts := model.DeleteDeveloperOptions{
+		OnGames:    model.GamesPolicy(r.URL.Query().Get("onGames")),
+		ReassignTo: r.URL.Query().Get("reassignTo"),
+		Version:    version,
+	}
+
+	if err := h.developerService.DeleteDeveloper(ctx, id, opts); err != nil {
+		writeWriteError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// GetGames handles the HTTP request to retrieve all games.
+// GetGames handles the HTTP request to retrieve a page of games.
+// Supports the genre, yearFrom, yearTo, available, developer and title filters,
+// sort, and cursor/limit pagination query parameters.
 func (h *Handler) GetGames(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	games, err := h.gameService.GetAllGames(ctx)
+	query, err := parseGameQuery(r.URL.Query())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(games)
+	page, err := h.gameService.ListGames(ctx, query)
 	if err != nil {
+		writeError(w, r, err)
 		return
 	}
+
+	writeJSON(w, http.StatusOK, page)
 }
 
 // GetGame handles the HTTP request to retrieve a game by ID.
+// Replies 304 Not Modified when If-None-Match matches the game's ETag.
 func (h *Handler) GetGame(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -137,13 +219,42 @@ func (h *Handler) GetGame(w http.ResponseWriter, r *http.Request) {
 
 	game, err := h.gameService.GetGameById(ctx, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
+		return
+	}
+	if notModified(w, r, game.Version) {
+		return
+	}
+	setETag(w, game.Version)
+	writeJSON(w, http.StatusOK, game)
+}
+
+// SearchGames handles the HTTP request to search games by the q query parameter.
+// Results are ranked by relevance; limit caps their number and defaults to 20.
+func (h *Handler) SearchGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		writeProblem(w, r, http.StatusBadRequest, "missing q query parameter")
+		return
+	}
+	limit, err := intParam(r.URL.Query(), "limit")
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
-	err = json.NewEncoder(w).Encode(game)
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	results, err := h.gameService.SearchGames(ctx, q, limit)
 	if err != nil {
+		writeError(w, r, err)
 		return
 	}
+
+	writeJSON(w, http.StatusOK, results)
 }
 
 // CreateGame handles the HTTP request to create a new game.
@@ -151,41 +262,124 @@ func (h *Handler) CreateGame(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var game model.Game
-	if err := json.NewDecoder(r.Body).Decode(&game); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decodeJSON(r, &game); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	if _, err := h.gameService.AddGame(ctx, game); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	created, err := h.gameService.AddGame(ctx, game)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusCreated)
+	setETag(w, created.Version)
+	w.Header().Set("Location", path.Join(r.URL.Path, created.ID.Hex()))
+	writeJSON(w, http.StatusCreated, created)
 }
 
-// UpdateGameAvailability handles the HTTP request to update a game's availability.
+// UpdateGameAvailability handles the HTTP request to toggle a game's availability.
 func (h *Handler) UpdateGameAvailability(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	if _, err := h.gameService.UpdateAvailability(ctx, id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	updated, err := h.gameService.UpdateAvailability(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
+	setETag(w, updated.Version)
+	w.Header().Set("Location", path.Dir(r.URL.Path))
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// UpdateGame handles the HTTP request to replace a game.
+// The If-Match header, or else a non-zero Version in the body, must match the stored version.
+func (h *Handler) UpdateGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+	var game model.Game
+	if err := decodeJSON(r, &game); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if version != 0 {
+		game.Version = version
+	}
+
+	updated, err := h.gameService.UpdateGame(ctx, id, game)
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, updated.Version)
+	w.Header().Set("Location", r.URL.Path)
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// PatchGame handles the HTTP request to partially update a game.
+// The body is a JSON Merge Patch, or a JSON Patch when sent as application/json-patch+json.
+// A merge patch that only sets Available is applied with SetAvailability.
+// An If-Match header must match the stored version.
+func (h *Handler) PatchGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+	w.Header().Set("Accept-Patch", "application/merge-patch+json, application/json-patch+json")
+	patch, err := readPatch(w, r)
+	if err != nil {
+		writeProblem(w, r, patchErrorStatus(err), err.Error())
+		return
+	}
+	patch.Version = version
+
+	var updated *model.Game
+	if available, ok := availabilityPatch(patch); ok {
+		updated, err = h.gameService.SetAvailability(ctx, id, available, patch.Version)
+	} else {
+		updated, err = h.gameService.PatchGame(ctx, id, patch)
+	}
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, updated.Version)
+	w.Header().Set("Location", r.URL.Path)
+	writeJSON(w, http.StatusOK, updated)
 }
 
 // DeleteGame handles the HTTP request to delete a game by ID.
+// An If-Match header must match the stored version.
 func (h *Handler) DeleteGame(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	if err := h.gameService.DeleteGame(ctx, id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	if err := h.gameService.DeleteGame(ctx, id, version); err != nil {
+		writeWriteError(w, r, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent)
@@ -196,39 +390,146 @@ func (h *Handler) FindGamesByDeveloper(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	vars := mux.Vars(r)
-	id := vars["id"]
+	developer := vars["developer"]
 
-	games, err := h.gameService.FindGamesByDeveloper(ctx, id)
+	games, err := h.gameService.FindGamesByDeveloper(ctx, developer)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(games)
-	if err != nil {
-		return
-	}
+	writeJSON(w, http.StatusOK, games)
 }
 
 // RegisterRoutesForDevelopers registers the routes for developers.
 func (h *Handler) RegisterRoutesForDevelopers() []Endpoint {
 	return []Endpoint{
-		{Path: "/developers", Handler: h.GetDevelopers, Method: "GET"},
-		{Path: "/developers/{id}", Handler: h.GetDeveloper, Method: "GET"},
-		{Path: "/developers", Handler: h.CreateDeveloper, Method: "POST"},
-		{Path: "/developers/{id}", Handler: h.UpdateDeveloper, Method: "PUT"},
-		{Path: "/developers/{id}", Handler: h.DeleteDeveloper, Method: "DELETE"},
+		{Path: "/developers", Handler: h.GetDevelopers, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "developers",
+			Summary:    "List developers",
+			Parameters: pageParams(sortParam("name"), developerFilterParams...),
+			Responses:  []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of developers.", model.Page[model.Developer]{})},
+			Errors:     []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/developers/{id}", Handler: h.GetDeveloper, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "developers",
+			Summary:    "Get a developer",
+			Parameters: []openapi.Parameter{ifNoneMatchParam},
+			Responses: []openapi.Response{
+				openapi.JSONResponse(http.StatusOK, "The developer, with its version as the ETag.", model.Developer{}),
+				notModifiedResponse,
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/developers", Handler: h.CreateDeveloper, Method: "POST", Spec: &openapi.Operation{
+			Tag:       "developers",
+			Summary:   "Create a developer",
+			Request:   openapi.JSON("The developer. ID and Version are assigned.", model.Developer{}),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusCreated, "The created developer.", model.Developer{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/developers/{id}", Handler: h.UpdateDeveloper, Method: "PUT", Spec: &openapi.Operation{
+			Tag:         "developers",
+			Summary:     "Update a developer",
+			Description: "The games of the developer are updated with it. Without If-Match, a non-zero Version in the body must match.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Request:     openapi.JSON("The developer.", model.Developer{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated developer.", model.Developer{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/developers/{id}", Handler: h.DeleteDeveloper, Method: "DELETE", Spec: &openapi.Operation{
+			Tag:     "developers",
+			Summary: "Delete a developer",
+			Parameters: []openapi.Parameter{
+				ifMatchParam,
+				{Name: "onGames", In: "query", Type: "string", Description: "What happens to the games of the developer, cascade by default.",
+					Enum: []string{string(model.GamesCascade), string(model.GamesRestrict), string(model.GamesReassign)}},
+				openapi.Query("reassignTo", "string", "The ID of the developer the games move to when onGames is reassign."),
+			},
+			Responses: []openapi.Response{noContentResponse},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity},
+		}},
 	}
 }
 
 // RegisterRoutesForGames registers the routes for games.
 func (h *Handler) RegisterRoutesForGames() []Endpoint {
 	return []Endpoint{
-		{Path: "/games", Handler: h.GetGames, Method: "GET"},
-		{Path: "/games/{id}", Handler: h.GetGame, Method: "GET"},
-		{Path: "/games", Handler: h.CreateGame, Method: "POST"},
-		{Path: "/games/{id}", Handler: h.UpdateGameAvailability, Method: "PUT"},
-		{Path: "/games/{id}", Handler: h.DeleteGame, Method: "DELETE"},
-		{Path: "/games/developer/{developer}", Handler: h.FindGamesByDeveloper, Method: "GET"},
+		{Path: "/games", Handler: h.GetGames, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "games",
+			Summary:    "List games",
+			Parameters: pageParams(sortParam("title", "genre", "year"), gameFilterParams...),
+			Responses:  []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of games.", model.Page[model.Game]{})},
+			Errors:     []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/games/search", Handler: h.SearchGames, Method: "GET", Spec: &openapi.Operation{
+			Tag:     "games",
+			Summary: "Search games",
+			Parameters: []openapi.Parameter{
+				{Name: "q", In: "query", Type: "string", Required: true, Description: "The words to search the title, genre and developer for."},
+				openapi.Query("limit", "integer", "The most results to return, 20 by default."),
+			},
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The matching games, most relevant first.", []model.GameSearchResult{})},
+			Errors:    []int{http.StatusBadRequest},
+		}},
+		{Path: "/games/{id}", Handler: h.GetGame, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "games",
+			Summary:    "Get a game",
+			Parameters: []openapi.Parameter{ifNoneMatchParam},
+			Responses: []openapi.Response{
+				openapi.JSONResponse(http.StatusOK, "The game, with its version as the ETag.", model.Game{}),
+				notModifiedResponse,
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/games", Handler: h.CreateGame, Method: "POST", Spec: &openapi.Operation{
+			Tag:       "games",
+			Summary:   "Create a game",
+			Request:   openapi.JSON("The game. Only the ID of its Developer is read; ID, Version and the copy counts are assigned.", model.Game{}),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusCreated, "The created game.", model.Game{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/games/{id}", Handler: h.UpdateGame, Method: "PUT", Spec: &openapi.Operation{
+			Tag:         "games",
+			Summary:     "Replace a game",
+			Description: "Without If-Match, a non-zero Version in the body must match.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Request:     openapi.JSON("The game. Only the ID of its Developer is read.", model.Game{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated game.", model.Game{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/games/{id}", Handler: h.PatchGame, Method: "PATCH", Spec: &openapi.Operation{
+			Tag:        "games",
+			Summary:    "Update part of a game",
+			Parameters: []openapi.Parameter{ifMatchParam},
+			Request: &openapi.Body{
+				Description: "A JSON Merge Patch of the game, or a JSON Patch sent as application/json-patch+json.",
+				Types:       []string{"application/merge-patch+json", "application/json-patch+json", "application/json"},
+				Required:    true,
+			},
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated game.", model.Game{})},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed,
+				http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/games/{id}/toggle", Handler: h.UpdateGameAvailability, Method: "POST", Spec: &openapi.Operation{
+			Tag:         "games",
+			Summary:     "Toggle the availability of a game",
+			Description: "Only games without copies can be toggled. A game made available goes to the next hold in its queue instead.",
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated game.", model.Game{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		}},
+		{Path: "/games/{id}", Handler: h.DeleteGame, Method: "DELETE", Spec: &openapi.Operation{
+			Tag:        "games",
+			Summary:    "Delete a game",
+			Parameters: []openapi.Parameter{ifMatchParam},
+			Responses:  []openapi.Response{noContentResponse},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+		}},
+		{Path: "/games/developer/{developer}", Handler: h.FindGamesByDeveloper, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "games",
+			Summary:    "List the games of a developer",
+			Parameters: []openapi.Parameter{{Name: "developer", In: "path", Type: "string", Description: "The name of the developer."}},
+			Responses:  []openapi.Response{openapi.JSONResponse(http.StatusOK, "The games of the developer.", []model.Game{})},
+		}},
 	}
 }
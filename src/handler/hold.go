@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// holdRequest is the body of a request to place a hold.
+type holdRequest struct {
+	MemberID string
+}
+
+// GetHolds handles the HTTP request to retrieve a page of holds in the order they were placed.
+// Supports the game, member and status filters, and cursor/limit pagination query parameters.
+func (h *Handler) GetHolds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := parseHoldQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.holdService.ListHolds(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// GetHold handles the HTTP request to retrieve a hold by ID.
+func (h *Handler) GetHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	hold, err := h.holdService.GetHoldById(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, hold.Version)
+	writeJSON(w, http.StatusOK, hold)
+}
+
+// PlaceHold handles the HTTP request to queue the member given in the body for a game.
+// Replies 409 Conflict if the game is available or the member already has a hold on it.
+func (h *Handler) PlaceHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var request holdRequest
+	if err := decodeJSON(r, &request); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hold, err := h.holdService.PlaceHold(ctx, id, request.MemberID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, hold.Version)
+	w.Header().Set("Location", resourcePath(r, "/holds/"+hold.ID.Hex()))
+	writeJSON(w, http.StatusCreated, hold)
+}
+
+// CancelHold handles the HTTP request to cancel an open hold.
+// An If-Match header must match the stored version.
+func (h *Handler) CancelHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	hold, err := h.holdService.CancelHold(ctx, id, version)
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, hold.Version)
+	writeJSON(w, http.StatusOK, hold)
+}
+
+// ExpireHolds handles the HTTP request to expire the holds whose pickup window has ended.
+// Replies with the holds that expired.
+func (h *Handler) ExpireHolds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	holds, err := h.holdService.ExpireHolds(ctx)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, holds)
+}
+
+// RegisterRoutesForHolds registers the routes for holds, including placing a hold on a game.
+func (h *Handler) RegisterRoutesForHolds() []Endpoint {
+	return []Endpoint{
+		{Path: "/holds", Handler: h.GetHolds, Method: "GET", Spec: &openapi.Operation{
+			Tag:     "holds",
+			Summary: "List holds",
+			Parameters: cursorParams(
+				gameFilterParam,
+				memberFilterParam,
+				statusParam(model.HoldWaiting, model.HoldReady, model.HoldFulfilled, model.HoldCancelled, model.HoldExpired),
+			),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of holds in the order they were placed.", model.Page[model.Hold]{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/holds/expire", Handler: h.ExpireHolds, Method: "POST", Spec: &openapi.Operation{
+			Tag:       "holds",
+			Summary:   "Expire the holds whose pickup window has ended",
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The holds that expired.", []model.Hold{})},
+		}},
+		{Path: "/holds/{id}", Handler: h.GetHold, Method: "GET", Spec: &openapi.Operation{
+			Tag:       "holds",
+			Summary:   "Get a hold",
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The hold.", model.Hold{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/holds/{id}/cancel", Handler: h.CancelHold, Method: "POST", Spec: &openapi.Operation{
+			Tag:        "holds",
+			Summary:    "Cancel a hold",
+			Parameters: []openapi.Parameter{ifMatchParam},
+			Responses:  []openapi.Response{openapi.JSONResponse(http.StatusOK, "The cancelled hold.", model.Hold{})},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+		}},
+		{Path: "/games/{id}/holds", Handler: h.PlaceHold, Method: "POST", Spec: &openapi.Operation{
+			Tag:         "holds",
+			Summary:     "Place a hold on a game",
+			Description: "Fails if the game is available or the member already has a hold on it.",
+			Request:     openapi.JSON("The member to queue.", holdRequest{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusCreated, "The hold.", model.Hold{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		}},
+	}
+}
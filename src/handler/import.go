@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"mime"
+	"net/http"
+)
+
+// maxImportSize caps the size of an import file.
+const maxImportSize = 32 << 20
+
+// importFormats maps the content types accepted by the import endpoint to file formats.
+var importFormats = map[string]model.FileFormat{
+	"text/csv":             model.FormatCSV,
+	"application/x-ndjson": model.FormatNDJSON,
+	"application/ndjson":   model.FormatNDJSON,
+}
+
+// importFormat picks the format of an import file from the format query parameter, or else from the content type.
+func importFormat(r *http.Request) (model.FileFormat, bool) {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return model.FileFormat(format), true
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+	format, ok := importFormats[mediaType]
+	return format, ok
+}
+
+// importProblem is the problem document of an import that stopped midway, with the report of what it wrote.
+type importProblem struct {
+	Problem
+	Report *model.ImportReport `json:"report"`
+}
+
+// Import handles the HTTP request to import developers and games from the CSV or NDJSON file in the body.
+// Supports the format, dryRun and batchSize query parameters. Replies with the import report, listing the
+// rows that were skipped because of errors. An import stopped by a read or storage error replies with a problem
+// document that carries the report of the batches written before it.
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format, ok := importFormat(r)
+	if !ok {
+		writeProblem(w, r, http.StatusUnsupportedMediaType, "send text/csv or application/x-ndjson, or give the format parameter")
+		return
+	}
+	var opts model.ImportOptions
+	dryRun, err := boolParam(r.URL.Query(), "dryRun")
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	opts.DryRun = dryRun != nil && *dryRun
+	if opts.BatchSize, err = intParam(r.URL.Query(), "batchSize"); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file := model.ImportFile{Format: format, Body: http.MaxBytesReader(w, r.Body, maxImportSize)}
+	report, err := h.importService.Import(ctx, file, opts)
+	if err != nil {
+		problem := errorProblem(r, err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem = newProblem(r, http.StatusRequestEntityTooLarge, err.Error())
+		}
+		if report == nil {
+			encodeProblem(w, problem.Status, problem)
+			return
+		}
+		encodeProblem(w, problem.Status, importProblem{Problem: problem, Report: report})
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// RegisterRoutesForImport registers the route for importing developers and games.
+func (h *Handler) RegisterRoutesForImport() []Endpoint {
+	return []Endpoint{
+		{Path: "/import", Handler: h.Import, Method: "POST", Spec: &openapi.Operation{
+			Tag:         "catalogue",
+			Summary:     "Import developers and games",
+			Description: "Rows with errors are skipped and reported; the others are written in batches. If reading the file or writing a batch fails, the problem document has a report member with the batches written before.",
+			Parameters: []openapi.Parameter{
+				{Name: "format", In: "query", Type: "string", Description: "The file format, taken from the content type by default.",
+					Enum: []string{string(model.FormatCSV), string(model.FormatNDJSON)}},
+				openapi.Query("dryRun", "boolean", "Checks the file and reports what would be imported without writing."),
+				openapi.Query("batchSize", "integer", "The rows written per transaction, 100 by default and at most 1000."),
+			},
+			Request: &openapi.Body{
+				Description: "A CSV file with a header row, or an NDJSON file, of at most 32 MiB.",
+				Types:       []string{"text/csv", "application/x-ndjson"},
+				Schema:      "",
+				Required:    true,
+			},
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The import report.", model.ImportReport{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity},
+		}},
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+)
+
+// checkoutRequest is the body of a checkout request.
+// Barcode optionally picks the copy to lend.
+type checkoutRequest struct {
+	MemberID string
+	Barcode  string
+}
+
+// returnRequest is the optional body of a return request.
+// Barcode picks the returned copy when several copies of the game are out.
+type returnRequest struct {
+	Barcode string
+}
+
+// GetLoans handles the HTTP request to retrieve a page of loans, oldest first.
+// Supports the game, member, active and overdue filters, and cursor/limit pagination query parameters.
+func (h *Handler) GetLoans(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := parseLoanQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.loanService.ListLoans(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// GetLoan handles the HTTP request to retrieve a loan by ID.
+func (h *Handler) GetLoan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	loan, err := h.loanService.GetLoanById(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, loan)
+}
+
+// CheckoutGame handles the HTTP request to lend a game, or one of its copies, to the member given in the body.
+// Replies 409 Conflict if the game or the requested copy is not available.
+func (h *Handler) CheckoutGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var request checkoutRequest
+	if err := decodeJSON(r, &request); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	loan, err := h.loanService.Checkout(ctx, id, request.MemberID, request.Barcode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", resourcePath(r, "/loans/"+loan.ID.Hex()))
+	writeJSON(w, http.StatusCreated, loan)
+}
+
+// ReturnGame handles the HTTP request to return a checked out game or copy.
+// Replies 409 Conflict if the game is not checked out.
+func (h *Handler) ReturnGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	var request returnRequest
+	if err := decodeJSON(r, &request); err != nil && !errors.Is(err, io.EOF) {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	loan, err := h.loanService.Return(ctx, id, request.Barcode)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", resourcePath(r, "/loans/"+loan.ID.Hex()))
+	writeJSON(w, http.StatusOK, loan)
+}
+
+// RegisterRoutesForLoans registers the routes for loans, including checkout and return of games.
+func (h *Handler) RegisterRoutesForLoans() []Endpoint {
+	return []Endpoint{
+		{Path: "/loans", Handler: h.GetLoans, Method: "GET", Spec: &openapi.Operation{
+			Tag:     "loans",
+			Summary: "List loans",
+			Parameters: cursorParams(
+				gameFilterParam,
+				memberFilterParam,
+				openapi.Query("active", "boolean", "Only loans still out, or only returned loans."),
+				openapi.Query("overdue", "boolean", "Only loans found overdue, or only the others."),
+			),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of loans, oldest first.", model.Page[model.Loan]{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/loans/{id}", Handler: h.GetLoan, Method: "GET", Spec: &openapi.Operation{
+			Tag:       "loans",
+			Summary:   "Get a loan",
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The loan.", model.Loan{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/games/{id}/checkout", Handler: h.CheckoutGame, Method: "POST", Spec: &openapi.Operation{
+			Tag:         "loans",
+			Summary:     "Lend a game to a member",
+			Description: "Fails if the game or the requested copy is not available, or the member may not borrow.",
+			Request:     openapi.JSON("The member, and optionally the barcode of the copy to lend.", checkoutRequest{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusCreated, "The loan.", model.Loan{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/games/{id}/return", Handler: h.ReturnGame, Method: "POST", Spec: &openapi.Operation{
+			Tag:     "loans",
+			Summary: "Return a game",
+			Request: &openapi.Body{
+				Description: "The barcode of the returned copy, needed when several copies of the game are out.",
+				Types:       []string{"application/json"},
+				Schema:      returnRequest{},
+			},
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The closed loan.", model.Loan{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		}},
+	}
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"github.com/gorilla/mux"
+	"net/http"
+	"path"
+)
+
+// GetMembers handles the HTTP request to retrieve a page of members.
+// Supports the status and name filters, sort, and cursor/limit pagination query parameters.
+func (h *Handler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query, err := parseMemberQuery(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.memberService.ListMembers(ctx, query)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// GetMember handles the HTTP request to retrieve a member by ID.
+// Replies 304 Not Modified when If-None-Match matches the member's ETag.
+func (h *Handler) GetMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	member, err := h.memberService.GetMemberById(ctx, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if notModified(w, r, member.Version) {
+		return
+	}
+	setETag(w, member.Version)
+	writeJSON(w, http.StatusOK, member)
+}
+
+// LookupMember handles the HTTP request to find a member by the email or cardNumber query parameter.
+// Exactly one of them must be given.
+func (h *Handler) LookupMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	email := r.URL.Query().Get("email")
+	cardNumber := r.URL.Query().Get("cardNumber")
+	if (email == "") == (cardNumber == "") {
+		writeProblem(w, r, http.StatusBadRequest, "exactly one of email and cardNumber is required")
+		return
+	}
+
+	var member *model.Member
+	var err error
+	if email != "" {
+		member, err = h.memberService.GetMemberByEmail(ctx, email)
+	} else {
+		member, err = h.memberService.GetMemberByCardNumber(ctx, cardNumber)
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, member.Version)
+	w.Header().Set("Content-Location", path.Join(path.Dir(r.URL.Path), member.ID.Hex()))
+	writeJSON(w, http.StatusOK, member)
+}
+
+// CreateMember handles the HTTP request to register a new member.
+func (h *Handler) CreateMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var member model.Member
+	if err := decodeJSON(r, &member); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.memberService.AddMember(ctx, member)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	setETag(w, created.Version)
+	w.Header().Set("Location", path.Join(r.URL.Path, created.ID.Hex()))
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// UpdateMember handles the HTTP request to update an existing member, including their status.
+// The If-Match header, or else a non-zero Version in the body, must match the stored version.
+func (h *Handler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+	var member model.Member
+	if err := decodeJSON(r, &member); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if version != 0 {
+		member.Version = version
+	}
+
+	updated, err := h.memberService.UpdateMember(ctx, id, member)
+	if err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	setETag(w, updated.Version)
+	w.Header().Set("Location", r.URL.Path)
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// DeleteMember handles the HTTP request to delete a member by ID.
+// Members with games checked out cannot be deleted. An If-Match header must match the stored version.
+func (h *Handler) DeleteMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		writeIfMatchError(w, r, err)
+		return
+	}
+
+	if err := h.memberService.DeleteMember(ctx, id, version); err != nil {
+		writeWriteError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRoutesForMembers registers the routes for members.
+func (h *Handler) RegisterRoutesForMembers() []Endpoint {
+	return []Endpoint{
+		{Path: "/members", Handler: h.GetMembers, Method: "GET", Spec: &openapi.Operation{
+			Tag:     "members",
+			Summary: "List members",
+			Parameters: pageParams(sortParam("name"),
+				statusParam(model.MemberActive, model.MemberSuspended, model.MemberExpired),
+				openapi.Query("name", "string", "Only members whose name contains this, ignoring case."),
+			),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "One page of members.", model.Page[model.Member]{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/members/lookup", Handler: h.LookupMember, Method: "GET", Spec: &openapi.Operation{
+			Tag:         "members",
+			Summary:     "Find a member by email or card number",
+			Description: "Exactly one of email and cardNumber must be given.",
+			Parameters: []openapi.Parameter{
+				openapi.Query("email", "string", "The email of the member."),
+				openapi.Query("cardNumber", "string", "The library card number of the member."),
+			},
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusOK, "The member.", model.Member{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/members/{id}", Handler: h.GetMember, Method: "GET", Spec: &openapi.Operation{
+			Tag:        "members",
+			Summary:    "Get a member",
+			Parameters: []openapi.Parameter{ifNoneMatchParam},
+			Responses: []openapi.Response{
+				openapi.JSONResponse(http.StatusOK, "The member, with its version as the ETag.", model.Member{}),
+				notModifiedResponse,
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		}},
+		{Path: "/members", Handler: h.CreateMember, Method: "POST", Spec: &openapi.Operation{
+			Tag:       "members",
+			Summary:   "Register a member",
+			Request:   openapi.JSON("The member. ID and Version are assigned.", model.Member{}),
+			Responses: []openapi.Response{openapi.JSONResponse(http.StatusCreated, "The registered member.", model.Member{})},
+			Errors:    []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/members/{id}", Handler: h.UpdateMember, Method: "PUT", Spec: &openapi.Operation{
+			Tag:         "members",
+			Summary:     "Update a member",
+			Description: "Updates the member, including their status. Without If-Match, a non-zero Version in the body must match.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Request:     openapi.JSON("The member.", model.Member{}),
+			Responses:   []openapi.Response{openapi.JSONResponse(http.StatusOK, "The updated member.", model.Member{})},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity},
+		}},
+		{Path: "/members/{id}", Handler: h.DeleteMember, Method: "DELETE", Spec: &openapi.Operation{
+			Tag:         "members",
+			Summary:     "Delete a member",
+			Description: "Members with games checked out cannot be deleted.",
+			Parameters:  []openapi.Parameter{ifMatchParam},
+			Responses:   []openapi.Response{noContentResponse},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed},
+		}},
+	}
+}
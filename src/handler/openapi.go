@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/openapi"
+	"net/http"
+	"slices"
+)
+
+// The parameters and responses shared by the OpenAPI operations of the routes.
+var (
+	ifMatchParam     = openapi.Header("If-Match", "Makes the write conditional on the resource still having this ETag.")
+	ifNoneMatchParam = openapi.Header("If-None-Match", "Replies 304 Not Modified if the resource still has one of these ETags.")
+
+	notModifiedResponse = openapi.EmptyResponse(http.StatusNotModified, "The resource still has the ETag given in If-None-Match.")
+	noContentResponse   = openapi.EmptyResponse(http.StatusNoContent, "Done.")
+
+	memberFilterParam = openapi.Query("member", "string", "Only those of the member with this ID.")
+	gameFilterParam   = openapi.Query("game", "string", "Only those of the game with this ID.")
+)
+
+// sortParam returns the sort parameter of a listing that can be sorted by the given fields.
+func sortParam(fields ...string) openapi.Parameter {
+	p := openapi.Query("sort", "string", "Sorts by a field, in descending order when prefixed with -. Defaults to creation order.")
+	for _, f := range fields {
+		p.Enum = append(p.Enum, f, "-"+f)
+	}
+	return p
+}
+
+// pageParams returns the filter parameters of a paged listing followed by its sort and pagination parameters.
+func pageParams(sort openapi.Parameter, filters ...openapi.Parameter) []openapi.Parameter {
+	return cursorParams(append(slices.Clone(filters), sort)...)
+}
+
+// cursorParams returns the filter parameters of a paged listing in creation order followed by its pagination parameters.
+func cursorParams(filters ...openapi.Parameter) []openapi.Parameter {
+	return append(slices.Clone(filters),
+		openapi.Query("cursor", "string", "The Next value of the previous page. Keep the same filters and sort when following it."),
+		openapi.Query("limit", "integer", "The page size, 20 by default and at most 100."),
+	)
+}
+
+// gameFilterParams are the filter parameters of a game listing.
+var gameFilterParams = []openapi.Parameter{
+	openapi.Query("genre", "string", "Only games of this genre."),
+	openapi.Query("developer", "string", "Only games of the developer with this ID."),
+	openapi.Query("available", "boolean", "Only available or unavailable games."),
+	openapi.Query("yearFrom", "integer", "Only games published in this year or later."),
+	openapi.Query("yearTo", "integer", "Only games published in this year or earlier."),
+	openapi.Query("title", "string", "Only games whose title starts with this, ignoring case."),
+}
+
+// developerFilterParams are the filter parameters of a developer listing.
+var developerFilterParams = []openapi.Parameter{
+	openapi.Query("mainHq", "string", "Only developers with this headquarters."),
+	openapi.Query("name", "string", "Only developers whose name contains this, ignoring case."),
+}
+
+// statusParam returns the parameter filtering by one of the given statuses.
+func statusParam[S ~string](statuses ...S) openapi.Parameter {
+	p := openapi.Query("status", "string", "Only those with this status.")
+	for _, s := range statuses {
+		p.Enum = append(p.Enum, string(s))
+	}
+	return p
+}
+
+// exportFormatParam is the format parameter of the exports.
+var exportFormatParam = openapi.Parameter{
+	Name:        "format",
+	In:          "query",
+	Description: "The file format, csv by default.",
+	Type:        "string",
+	Enum:        []string{string(model.FormatCSV), string(model.FormatNDJSON), string(model.FormatXLSX)},
+}
+
+// exportResponse is the file downloaded from an export.
+var exportResponse = openapi.Response{
+	Status:      http.StatusOK,
+	Description: "The file, streamed as it is written.",
+	Body: &openapi.Body{
+		Types:  []string{"text/csv", exportContentTypes[model.FormatNDJSON], exportContentTypes[model.FormatXLSX]},
+		Schema: openapi.Binary{},
+	},
+}
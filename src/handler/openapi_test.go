@@ -0,0 +1,40 @@
+package handler_test
+
+import (
+	"game-library-management-system/src/handler"
+	"game-library-management-system/src/openapi"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEndpointsHaveSpecs adds the endpoints of every RegisterRoutesFor method to an OpenAPI document,
+// so that a route registered without a spec, or documented twice, fails the build.
+func TestEndpointsHaveSpecs(t *testing.T) {
+	h := reflect.ValueOf(handler.NewHandler(handler.Services{}))
+	document := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"}, handler.Problem{})
+
+	registered := 0
+	for i := range h.NumMethod() {
+		name := h.Type().Method(i).Name
+		if !strings.HasPrefix(name, "RegisterRoutesFor") {
+			continue
+		}
+		endpoints, ok := h.Method(i).Call(nil)[0].Interface().([]handler.Endpoint)
+		if !ok {
+			t.Fatalf("%s does not return []handler.Endpoint", name)
+		}
+		for _, endpoint := range endpoints {
+			if endpoint.Handler == nil {
+				t.Errorf("%s: %s %s has no handler", name, endpoint.Method, endpoint.Path)
+			}
+			if err := document.Add(endpoint.Method, endpoint.Path, endpoint.Spec); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+		registered += len(endpoints)
+	}
+	if registered == 0 {
+		t.Fatal("no endpoints found")
+	}
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/model"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// decodeJSON decodes the request body into v, rejecting fields that v does not have.
+func decodeJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// parseGameQuery reads the filter, sort and pagination parameters of a game listing.
+func parseGameQuery(values url.Values) (model.GameQuery, error) {
+	query := model.GameQuery{
+		Filter: model.GameFilter{
+			Genre:       values.Get("genre"),
+			DeveloperID: values.Get("developer"),
+			TitlePrefix: values.Get("title"),
+		},
+		Sort:   values.Get("sort"),
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Filter.YearFrom, err = intParam(values, "yearFrom"); err != nil {
+		return query, err
+	}
+	if query.Filter.YearTo, err = intParam(values, "yearTo"); err != nil {
+		return query, err
+	}
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+	if query.Filter.Available, err = boolParam(values, "available"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseDeveloperQuery reads the filter, sort and pagination parameters of a developer listing.
+func parseDeveloperQuery(values url.Values) (model.DeveloperQuery, error) {
+	query := model.DeveloperQuery{
+		Filter: model.DeveloperFilter{
+			MainHq:        values.Get("mainHq"),
+			NameSubstring: values.Get("name"),
+		},
+		Sort:   values.Get("sort"),
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseMemberQuery reads the filter, sort and pagination parameters of a member listing.
+func parseMemberQuery(values url.Values) (model.MemberQuery, error) {
+	query := model.MemberQuery{
+		Filter: model.MemberFilter{
+			Status:        model.MemberStatus(values.Get("status")),
+			NameSubstring: values.Get("name"),
+		},
+		Sort:   values.Get("sort"),
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseLoanQuery reads the filter and pagination parameters of a loan listing.
+func parseLoanQuery(values url.Values) (model.LoanQuery, error) {
+	query := model.LoanQuery{
+		Filter: model.LoanFilter{
+			GameID:   values.Get("game"),
+			MemberID: values.Get("member"),
+		},
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Filter.Active, err = boolParam(values, "active"); err != nil {
+		return query, err
+	}
+	if query.Filter.Overdue, err = boolParam(values, "overdue"); err != nil {
+		return query, err
+	}
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseCopyQuery reads the pagination parameters of the listing of the copies of a game.
+func parseCopyQuery(gameID string, values url.Values) (model.CopyQuery, error) {
+	query := model.CopyQuery{
+		GameID: gameID,
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseHoldQuery reads the filter and pagination parameters of a hold listing.
+func parseHoldQuery(values url.Values) (model.HoldQuery, error) {
+	query := model.HoldQuery{
+		Filter: model.HoldFilter{
+			GameID:   values.Get("game"),
+			MemberID: values.Get("member"),
+			Status:   model.HoldStatus(values.Get("status")),
+		},
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseFineQuery reads the filter and pagination parameters of a fine listing.
+func parseFineQuery(values url.Values) (model.FineQuery, error) {
+	query := model.FineQuery{
+		Filter: model.FineFilter{
+			MemberID: values.Get("member"),
+			LoanID:   values.Get("loan"),
+			Status:   model.FineStatus(values.Get("status")),
+		},
+		Cursor: values.Get("cursor"),
+	}
+
+	var err error
+	if query.Limit, err = intParam(values, "limit"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// boolParam parses an optional boolean query parameter, returning nil when it is absent.
+func boolParam(values url.Values, name string) (*bool, error) {
+	v := values.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", name, v)
+	}
+	return &b, nil
+}
+
+// intParam parses an optional integer query parameter, returning 0 when it is absent.
+func intParam(values url.Values, name string) (int, error) {
+	v := values.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, v)
+	}
+	return n, nil
+}
+
+// maxPatchSize caps the size of a patch document.
+const maxPatchSize = 1 << 20
+
+// patchFormats maps the content types accepted by PATCH endpoints to patch formats.
+// Plain JSON is read as a merge patch.
+var patchFormats = map[string]model.PatchFormat{
+	"application/merge-patch+json": model.MergePatch,
+	"application/json":             model.MergePatch,
+	"application/json-patch+json":  model.JSONPatch,
+}
+
+// errUnsupportedPatch is returned by readPatch for a content type not in patchFormats.
+var errUnsupportedPatch = errors.New("unsupported patch content type, use application/merge-patch+json or application/json-patch+json")
+
+// readPatch reads the patch document of a PATCH request and picks its format from the content type.
+func readPatch(w http.ResponseWriter, r *http.Request) (model.Patch, error) {
+	format := model.MergePatch
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return model.Patch{}, errUnsupportedPatch
+		}
+		var ok bool
+		if format, ok = patchFormats[mediaType]; !ok {
+			return model.Patch{}, errUnsupportedPatch
+		}
+	}
+
+	document, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		return model.Patch{}, err
+	}
+	if !json.Valid(document) {
+		return model.Patch{}, errors.New("the patch is not valid JSON")
+	}
+	return model.Patch{Format: format, Document: document}, nil
+}
+
+// availabilityPatch reports whether p is a merge patch that only sets the availability of a game, and to what.
+// The key is matched exactly, as merge patches are.
+func availabilityPatch(p model.Patch) (available bool, ok bool) {
+	if p.Format != model.MergePatch {
+		return false, false
+	}
+	var document map[string]*bool
+	if err := json.Unmarshal(p.Document, &document); err != nil || len(document) != 1 || document["Available"] == nil {
+		return false, false
+	}
+	return *document["Available"], true
+}
+
+// patchErrorStatus returns the status for an error from readPatch.
+func patchErrorStatus(err error) int {
+	if errors.Is(err, errUnsupportedPatch) {
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusBadRequest
+}
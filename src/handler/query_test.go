@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"game-library-management-system/src/model"
+	"testing"
+)
+
+// TestAvailabilityPatch checks which patches are applied with SetAvailability.
+func TestAvailabilityPatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		patch     model.Patch
+		available bool
+		ok        bool
+	}{
+		{"available", model.Patch{Format: model.MergePatch, Document: []byte(`{"Available": true}`)}, true, true},
+		{"unavailable", model.Patch{Format: model.MergePatch, Document: []byte(`{"Available": false}`)}, false, true},
+		{"lower case", model.Patch{Format: model.MergePatch, Document: []byte(`{"available": true}`)}, false, false},
+		{"null", model.Patch{Format: model.MergePatch, Document: []byte(`{"Available": null}`)}, false, false},
+		{"not a boolean", model.Patch{Format: model.MergePatch, Document: []byte(`{"Available": "yes"}`)}, false, false},
+		{"other fields", model.Patch{Format: model.MergePatch, Document: []byte(`{"Available": true, "Genre": "RPG"}`)}, false, false},
+		{"other field", model.Patch{Format: model.MergePatch, Document: []byte(`{"Genre": "RPG"}`)}, false, false},
+		{"json patch", model.Patch{Format: model.JSONPatch, Document: []byte(`[{"op": "replace", "path": "/Available", "value": true}]`)}, false, false},
+	}
+	for _, tt := range tests {
+		available, ok := availabilityPatch(tt.patch)
+		if available != tt.available || ok != tt.ok {
+			t.Errorf("%s: availabilityPatch = %v, %v, want %v, %v", tt.name, available, ok, tt.available, tt.ok)
+		}
+	}
+}
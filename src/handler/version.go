@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/gorilla/mux"
+	"net/http"
+	"time"
+)
+
+type basePathKey struct{}
+
+// BasePath returns a middleware telling the handlers that their routes are mounted under prefix,
+// so that the resources they link to are under it too.
+func BasePath(prefix string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), basePathKey{}, prefix)))
+		})
+	}
+}
+
+// resourcePath returns the path of a resource under the prefix the routes of the request are mounted at.
+func resourcePath(r *http.Request, p string) string {
+	prefix, _ := r.Context().Value(basePathKey{}).(string)
+	return prefix + p
+}
+
+// Deprecated returns a middleware marking the responses of deprecated routes.
+// The Deprecation header (RFC 9745) gives when they were deprecated and the Sunset header (RFC 8594) when they
+// stop being served. A successor-version link points to the same path under successor.
+// From the sunset on, the routes answer 410 Gone with the same headers instead of being served.
+func Deprecated(since time.Time, sunset time.Time, successor string) mux.MiddlewareFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	sunsetDate := sunset.UTC().Format(http.TimeFormat)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			successorPath := successor + r.URL.EscapedPath()
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Set("Sunset", sunsetDate)
+			w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successorPath))
+			if !time.Now().Before(sunset) {
+				writeProblem(w, r, http.StatusGone, fmt.Sprintf("this route was retired on %s, use %s", sunset.UTC().Format(time.DateOnly), successorPath))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
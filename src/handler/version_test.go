@@ -0,0 +1,48 @@
+package handler_test
+
+import (
+	"game-library-management-system/src/handler"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestDeprecated checks that deprecated routes are served with their deprecation headers until the sunset and are gone from then on.
+func TestDeprecated(t *testing.T) {
+	since := time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		sunset time.Time
+		status int
+	}{
+		{"before the sunset", time.Now().Add(24 * time.Hour), http.StatusOK},
+		{"after the sunset", time.Now().Add(-24 * time.Hour), http.StatusGone},
+	}
+	for _, tt := range tests {
+		router := mux.NewRouter()
+		router.Use(handler.Deprecated(since, tt.sunset, "/api/v1"))
+		router.HandleFunc("/games/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/42", nil))
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.status)
+		}
+		if link := rec.Header().Get("Link"); link != `</api/v1/games/42>; rel="successor-version"` {
+			t.Errorf("%s: Link = %q", tt.name, link)
+		}
+		if got := rec.Header().Get("Deprecation"); got != "@1792195200" {
+			t.Errorf("%s: Deprecation = %q", tt.name, got)
+		}
+		if got := rec.Header().Get("Sunset"); got != tt.sunset.UTC().Format(http.TimeFormat) {
+			t.Errorf("%s: Sunset = %q", tt.name, got)
+		}
+		if tt.status == http.StatusGone && rec.Header().Get("Content-Type") != "application/problem+json" {
+			t.Errorf("%s: Content-Type = %q, want a problem document", tt.name, rec.Header().Get("Content-Type"))
+		}
+	}
+}
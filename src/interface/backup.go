@@ -0,0 +1,12 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+	"io"
+)
+
+type BackupServicer interface {
+	Backup(ctx context.Context, w io.Writer) (*model.BackupManifest, error)
+	Restore(ctx context.Context, archive io.ReaderAt, size int64, opts model.RestoreOptions) (*model.RestoreReport, error)
+}
@@ -0,0 +1,27 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+)
+
+type CopyRepositorer interface {
+	ListCopies(ctx context.Context, gameID string) ([]model.Copy, error)
+	PageCopies(ctx context.Context, query model.CopyQuery) (*model.Page[model.Copy], error)
+	GetCopyById(ctx context.Context, id string) (*model.Copy, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (*model.Copy, error)
+	CountCopies(ctx context.Context, gameID string) (total int, available int, err error)
+	AddCopy(ctx context.Context, gameCopy model.Copy) (*model.Copy, error)
+	UpdateCopy(ctx context.Context, id string, gameCopy model.Copy) (*model.Copy, error)
+	SetCopyAvailability(ctx context.Context, id string, available bool, version int64) (*model.Copy, error)
+	DeleteCopy(ctx context.Context, id string, version int64) error
+}
+
+type CopyServicer interface {
+	ListCopies(ctx context.Context, query model.CopyQuery) (*model.Page[model.Copy], error)
+	GetCopyById(ctx context.Context, id string) (*model.Copy, error)
+	GetCopyByBarcode(ctx context.Context, barcode string) (*model.Copy, error)
+	AddCopy(ctx context.Context, gameID string, gameCopy model.Copy) (*model.Copy, error)
+	UpdateCopy(ctx context.Context, id string, gameCopy model.Copy) (*model.Copy, error)
+	DeleteCopy(ctx context.Context, id string, version int64) error
+}
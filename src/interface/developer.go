@@ -7,16 +7,20 @@ import (
 
 type DeveloperRepositorer interface {
 	GetAllDevelopers(ctx context.Context) ([]model.Developer, error)
+	ListDevelopers(ctx context.Context, query model.DeveloperQuery) (*model.Page[model.Developer], error)
+	StreamDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, fn func(model.Developer) error) error
 	GetDeveloperById(ctx context.Context, id string) (*model.Developer, error)
 	AddDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error)
 	UpdateDeveloper(ctx context.Context, id string, developer model.Developer) (*model.Developer, error)
-	DeleteDeveloper(ctx context.Context, id string) error
+	RestoreDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error)
+	DeleteDeveloper(ctx context.Context, id string, version int64) error
 }
 
 type DeveloperServicer interface {
-	GetAllDevelopers(ctx context.Context) ([]model.Developer, error)
+	ListDevelopers(ctx context.Context, query model.DeveloperQuery) (*model.Page[model.Developer], error)
 	GetDeveloperById(ctx context.Context, id string) (*model.Developer, error)
 	AddDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error)
 	UpdateDeveloper(ctx context.Context, id string, developer model.Developer) (*model.Developer, error)
-	DeleteDeveloper(ctx context.Context, id string) error
+	DeleteDeveloper(ctx context.Context, id string, opts model.DeleteDeveloperOptions) error
+	RepairGameDevelopers(ctx context.Context) (int64, error)
 }
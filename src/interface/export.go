@@ -0,0 +1,12 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+	"io"
+)
+
+type ExportServicer interface {
+	ExportGames(ctx context.Context, filter model.GameFilter, sort string, format model.FileFormat, w io.Writer) error
+	ExportDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, format model.FileFormat, w io.Writer) error
+}
@@ -0,0 +1,23 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+)
+
+type FineRepositorer interface {
+	ListFines(ctx context.Context, filter model.FineFilter) ([]model.Fine, error)
+	PageFines(ctx context.Context, query model.FineQuery) (*model.Page[model.Fine], error)
+	GetFineById(ctx context.Context, id string) (*model.Fine, error)
+	AddFine(ctx context.Context, fine model.Fine) (*model.Fine, error)
+	UpdateFine(ctx context.Context, id string, fine model.Fine) (*model.Fine, error)
+}
+
+type FineServicer interface {
+	ListFines(ctx context.Context, query model.FineQuery) (*model.Page[model.Fine], error)
+	GetFineById(ctx context.Context, id string) (*model.Fine, error)
+	GetFineAccount(ctx context.Context, memberID string) (*model.FineAccount, error)
+	PayFine(ctx context.Context, id string, amount int64, version int64) (*model.Fine, error)
+	WaiveFine(ctx context.Context, id string, version int64) (*model.Fine, error)
+	AssessOverdueLoans(ctx context.Context) (int, error)
+}
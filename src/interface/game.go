@@ -7,20 +7,33 @@ import (
 
 type GameRepositorer interface {
 	GetAllGames(ctx context.Context) ([]model.Game, error)
+	ListGames(ctx context.Context, query model.GameQuery) (*model.Page[model.Game], error)
+	StreamGames(ctx context.Context, filter model.GameFilter, sort string, fn func(model.Game) error) error
 	GetGameById(ctx context.Context, id string) (*model.Game, error)
 	AddGame(ctx context.Context, game model.Game) (*model.Game, error)
+	UpdateGame(ctx context.Context, id string, game model.Game) (*model.Game, error)
+	RestoreGame(ctx context.Context, game model.Game) (*model.Game, error)
 	UpdateAvailability(ctx context.Context, id string) (*model.Game, error)
-	DeleteGame(ctx context.Context, id string) error
+	SetAvailability(ctx context.Context, id string, available bool, version int64) (*model.Game, error)
+	SetCopyCounts(ctx context.Context, id string, copies int, available int) (*model.Game, error)
+	DeleteGame(ctx context.Context, id string, version int64) error
 	FindGamesByDeveloper(ctx context.Context, developerName string) ([]model.Game, error)
 	DeleteManyGamesByDeveloper(ctx context.Context, developerID string) error
+	CountGamesByDeveloper(ctx context.Context, developerID string) (int64, error)
+	ReassignGames(ctx context.Context, developerID string, developer model.Developer) error
+	UpdateGamesDeveloper(ctx context.Context, developer model.Developer) (int64, error)
 }
 
 type GameServicer interface {
-	GetAllGames(ctx context.Context) ([]model.Game, error)
+	ListGames(ctx context.Context, query model.GameQuery) (*model.Page[model.Game], error)
 	GetGameById(ctx context.Context, id string) (*model.Game, error)
+	SearchGames(ctx context.Context, query string, limit int) ([]model.GameSearchResult, error)
 	AddGame(ctx context.Context, game model.Game) (*model.Game, error)
+	UpdateGame(ctx context.Context, id string, game model.Game) (*model.Game, error)
+	PatchGame(ctx context.Context, id string, patch model.Patch) (*model.Game, error)
 	UpdateAvailability(ctx context.Context, id string) (*model.Game, error)
-	DeleteGame(ctx context.Context, id string) error
+	SetAvailability(ctx context.Context, id string, available bool, version int64) (*model.Game, error)
+	DeleteGame(ctx context.Context, id string, version int64) error
 	FindGamesByDeveloper(ctx context.Context, developerName string) ([]model.Game, error)
 	DeleteManyGamesByDeveloper(ctx context.Context, developerID string) error
 }
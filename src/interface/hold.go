@@ -0,0 +1,22 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+)
+
+type HoldRepositorer interface {
+	ListHolds(ctx context.Context, filter model.HoldFilter) ([]model.Hold, error)
+	PageHolds(ctx context.Context, query model.HoldQuery) (*model.Page[model.Hold], error)
+	GetHoldById(ctx context.Context, id string) (*model.Hold, error)
+	AddHold(ctx context.Context, hold model.Hold) (*model.Hold, error)
+	UpdateHold(ctx context.Context, id string, hold model.Hold) (*model.Hold, error)
+}
+
+type HoldServicer interface {
+	ListHolds(ctx context.Context, query model.HoldQuery) (*model.Page[model.Hold], error)
+	GetHoldById(ctx context.Context, id string) (*model.Hold, error)
+	PlaceHold(ctx context.Context, gameID string, memberID string) (*model.Hold, error)
+	CancelHold(ctx context.Context, id string, version int64) (*model.Hold, error)
+	ExpireHolds(ctx context.Context) ([]model.Hold, error)
+}
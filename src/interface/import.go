@@ -0,0 +1,10 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+)
+
+type ImportServicer interface {
+	Import(ctx context.Context, file model.ImportFile, opts model.ImportOptions) (*model.ImportReport, error)
+}
@@ -0,0 +1,23 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+	"time"
+)
+
+type LoanRepositorer interface {
+	ListLoans(ctx context.Context, filter model.LoanFilter) ([]model.Loan, error)
+	PageLoans(ctx context.Context, query model.LoanQuery) (*model.Page[model.Loan], error)
+	GetLoanById(ctx context.Context, id string) (*model.Loan, error)
+	AddLoan(ctx context.Context, loan model.Loan) (*model.Loan, error)
+	CloseLoan(ctx context.Context, id string, returnedAt time.Time) (*model.Loan, error)
+	MarkOverdue(ctx context.Context, id string) (*model.Loan, error)
+}
+
+type LoanServicer interface {
+	ListLoans(ctx context.Context, query model.LoanQuery) (*model.Page[model.Loan], error)
+	GetLoanById(ctx context.Context, id string) (*model.Loan, error)
+	Checkout(ctx context.Context, gameID string, memberID string, barcode string) (*model.Loan, error)
+	Return(ctx context.Context, gameID string, barcode string) (*model.Loan, error)
+}
@@ -0,0 +1,26 @@
+package _interface
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+)
+
+type MemberRepositorer interface {
+	ListMembers(ctx context.Context, query model.MemberQuery) (*model.Page[model.Member], error)
+	GetMemberById(ctx context.Context, id string) (*model.Member, error)
+	GetMemberByEmail(ctx context.Context, email string) (*model.Member, error)
+	GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error)
+	AddMember(ctx context.Context, member model.Member) (*model.Member, error)
+	UpdateMember(ctx context.Context, id string, member model.Member) (*model.Member, error)
+	DeleteMember(ctx context.Context, id string, version int64) error
+}
+
+type MemberServicer interface {
+	ListMembers(ctx context.Context, query model.MemberQuery) (*model.Page[model.Member], error)
+	GetMemberById(ctx context.Context, id string) (*model.Member, error)
+	GetMemberByEmail(ctx context.Context, email string) (*model.Member, error)
+	GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error)
+	AddMember(ctx context.Context, member model.Member) (*model.Member, error)
+	UpdateMember(ctx context.Context, id string, member model.Member) (*model.Member, error)
+	DeleteMember(ctx context.Context, id string, version int64) error
+}
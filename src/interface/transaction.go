@@ -0,0 +1,7 @@
+package _interface
+
+import "context"
+
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,55 @@
+package model
+
+import "time"
+
+// BackupManifest describes the content of a backup archive.
+type BackupManifest struct {
+	// Format identifies the file as a backup archive and Version is the layout of the archive.
+	Format      string
+	Version     int
+	CreatedAt   time.Time
+	Collections []BackupCollection
+}
+
+// BackupCollection is one collection in a backup archive, stored as one JSON object per line in File.
+// SHA256 is the hex checksum of File.
+type BackupCollection struct {
+	Name   string
+	File   string
+	Count  int
+	SHA256 string
+}
+
+// ConflictPolicy decides what a restore does with a record whose ID is already stored.
+type ConflictPolicy string
+
+const (
+	// ConflictFail refuses to restore anything if any record is already stored.
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictSkip keeps the stored record.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictReplace overwrites the stored record with the one from the archive.
+	ConflictReplace ConflictPolicy = "replace"
+)
+
+// RestoreOptions controls how a backup archive is restored.
+type RestoreOptions struct {
+	// OnConflict is the conflict policy. Empty means ConflictFail.
+	OnConflict ConflictPolicy
+	// DryRun checks the archive and reports what would be restored without writing anything.
+	DryRun bool
+}
+
+// RestoreCounts is what a restore did with the records of one collection.
+type RestoreCounts struct {
+	Created  int
+	Replaced int
+	Skipped  int
+}
+
+// RestoreReport is the outcome of a restore.
+type RestoreReport struct {
+	DryRun     bool
+	Developers RestoreCounts
+	Games      RestoreCounts
+}
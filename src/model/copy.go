@@ -0,0 +1,35 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// CopyCondition describes the physical state of a copy.
+type CopyCondition string
+
+const (
+	CopyNew  CopyCondition = "new"
+	CopyGood CopyCondition = "good"
+	CopyFair CopyCondition = "fair"
+	CopyPoor CopyCondition = "poor"
+)
+
+// Copy is one physical copy of a game that the library owns. Barcodes are unique across the library.
+type Copy struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	GameID        primitive.ObjectID `bson:"game_id"`
+	Barcode       string             `bson:"barcode"`
+	Condition     CopyCondition      `bson:"condition"`
+	Platform      string             `bson:"platform"`
+	ShelfLocation string             `bson:"shelf_location"`
+	// Available is false while the copy is on loan.
+	Available bool `bson:"available"`
+	// Version starts at 1 and is incremented by every write to the copy.
+	Version int64 `bson:"version"`
+}
+
+// CopyQuery selects one page of the copies of a game in the order they were added.
+// Cursor is the Next value of the previous page.
+type CopyQuery struct {
+	GameID string
+	Cursor string
+	Limit  int
+}
@@ -6,4 +6,40 @@ type Developer struct {
 	ID     primitive.ObjectID `bson:"_id"`
 	Name   string             `bson:"name"`
 	MainHq string             `bson:"mainhq"`
+	// Version starts at 1 and is incremented by every update of the developer.
+	Version int64 `bson:"version"`
+}
+
+// DeveloperFilter narrows a developer listing. Zero values mean no restriction.
+type DeveloperFilter struct {
+	MainHq        string
+	NameSubstring string
+}
+
+// DeveloperQuery selects one page of developers.
+// Sort is name or -name; empty sorts by creation order. Cursor is the Next value of the previous page.
+type DeveloperQuery struct {
+	Filter DeveloperFilter
+	Sort   string
+	Cursor string
+	Limit  int
+}
+
+// GamesPolicy decides what happens to a developer's games when the developer is deleted.
+type GamesPolicy string
+
+const (
+	// GamesCascade deletes the games together with the developer.
+	GamesCascade GamesPolicy = "cascade"
+	// GamesRestrict refuses to delete a developer that still has games.
+	GamesRestrict GamesPolicy = "restrict"
+	// GamesReassign moves the games to another developer before deleting.
+	GamesReassign GamesPolicy = "reassign"
+)
+
+type DeleteDeveloperOptions struct {
+	OnGames    GamesPolicy
+	ReassignTo string
+	// Version, if non-zero, must match the stored version of the developer.
+	Version int64
 }
@@ -0,0 +1,13 @@
+package model
+
+// FileFormat names the format of a tabular file.
+type FileFormat string
+
+const (
+	// FormatCSV is comma-separated values with a header row naming the columns.
+	FormatCSV FileFormat = "csv"
+	// FormatNDJSON is one JSON object per line.
+	FormatNDJSON FileFormat = "ndjson"
+	// FormatXLSX is an Office Open XML workbook with a single sheet. It can be written but not read.
+	FormatXLSX FileFormat = "xlsx"
+)
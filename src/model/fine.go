@@ -0,0 +1,68 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// FineStatus is the state of a fine.
+type FineStatus string
+
+const (
+	// FineOpen fines have a balance left to pay.
+	FineOpen FineStatus = "open"
+	// FinePaid fines were paid in full.
+	FinePaid FineStatus = "paid"
+	// FineWaived fines were forgiven and no longer grow.
+	FineWaived FineStatus = "waived"
+)
+
+// Fine is the late fee of an overdue loan. There is at most one fine per loan; its Amount grows
+// with each day the game is late, up to a cap, until the game is returned or the fine is waived.
+// Amounts are in cents.
+type Fine struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	LoanID   primitive.ObjectID `bson:"loan_id"`
+	MemberID primitive.ObjectID `bson:"member_id"`
+	GameID   primitive.ObjectID `bson:"game_id"`
+	DaysLate int                `bson:"days_late"`
+	Amount   int64              `bson:"amount"`
+	Paid     int64              `bson:"paid"`
+	Status   FineStatus         `bson:"status"`
+	// AssessedAt is when Amount was last computed.
+	AssessedAt time.Time `bson:"assessed_at"`
+	// ClosedAt is set when the fine is paid in full or waived.
+	ClosedAt *time.Time `bson:"closed_at"`
+	// Version starts at 1 and is incremented by every write to the fine.
+	Version int64 `bson:"version"`
+}
+
+// Balance returns what is left to pay of the fine.
+func (f Fine) Balance() int64 {
+	if f.Status == FineWaived {
+		return 0
+	}
+	return f.Amount - f.Paid
+}
+
+// FineFilter narrows a fine listing. Zero values mean no restriction.
+type FineFilter struct {
+	MemberID string
+	LoanID   string
+	Status   FineStatus
+}
+
+// FineQuery selects one page of fines in the order they were first assessed.
+// Cursor is the Next value of the previous page.
+type FineQuery struct {
+	Filter FineFilter
+	Cursor string
+	Limit  int
+}
+
+// FineAccount is the fines of a member and the total they owe.
+type FineAccount struct {
+	MemberID primitive.ObjectID
+	Balance  int64
+	Fines    []Fine
+}
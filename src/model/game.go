@@ -9,4 +9,38 @@ type Game struct {
 	Genre           string             `bson:"genre"`
 	PublicationYear int                `bson:"year"`
 	Available       bool               `bson:"available"`
+	// Copies is the number of physical copies of the game and AvailableCopies the number of them not on loan.
+	// Both are derived from the copies, and so is Available once a game has any.
+	Copies          int `bson:"copies"`
+	AvailableCopies int `bson:"available_copies"`
+	// Version starts at 1 and is incremented by every write to the game.
+	Version int64 `bson:"version"`
+}
+
+// GameFilter narrows a game listing. Zero values mean no restriction.
+type GameFilter struct {
+	Genre       string
+	YearFrom    int
+	YearTo      int
+	Available   *bool
+	DeveloperID string
+	TitlePrefix string
+}
+
+// GameQuery selects one page of games.
+// Sort is one of title, genre or year, optionally prefixed with "-" for descending order;
+// empty sorts by creation order. Cursor is the Next value of the previous page.
+type GameQuery struct {
+	Filter GameFilter
+	Sort   string
+	Cursor string
+	Limit  int
+}
+
+// GameSearchResult is a game matched by a free-text search.
+// Highlights maps the matched field names to their HTML-escaped text with the matched words wrapped in <em> tags.
+type GameSearchResult struct {
+	Game       Game
+	Score      float64
+	Highlights map[string]string
 }
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// HoldStatus is the state of a hold in its game's queue.
+type HoldStatus string
+
+const (
+	// HoldWaiting holds are queued until the game becomes available.
+	HoldWaiting HoldStatus = "waiting"
+	// HoldReady holds have the game, or one of its copies, reserved until ExpiresAt.
+	HoldReady HoldStatus = "ready"
+	// HoldFulfilled holds ended with the member checking out the reserved game.
+	HoldFulfilled HoldStatus = "fulfilled"
+	// HoldCancelled holds were withdrawn before they were fulfilled.
+	HoldCancelled HoldStatus = "cancelled"
+	// HoldExpired holds were not picked up in time.
+	HoldExpired HoldStatus = "expired"
+)
+
+// IsOpen reports whether a hold with this status is still in the queue or waiting for pickup.
+func (s HoldStatus) IsOpen() bool {
+	return s == HoldWaiting || s == HoldReady
+}
+
+// Hold queues a member for a game that is not available. Holds of a game are served in the
+// order they were placed. When the game, or one of its copies, is returned it is reserved for
+// the first waiting hold, which becomes ready, instead of becoming available to everyone.
+type Hold struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	GameID   primitive.ObjectID `bson:"game_id"`
+	MemberID primitive.ObjectID `bson:"member_id"`
+	Status   HoldStatus         `bson:"status"`
+	PlacedAt time.Time          `bson:"placed_at"`
+	// CopyID is the reserved copy of a ready hold, or nil for a game without copies.
+	CopyID *primitive.ObjectID `bson:"copy_id"`
+	// ReadyAt and ExpiresAt are set when the hold becomes ready; the member has until ExpiresAt to pick the game up.
+	ReadyAt   *time.Time `bson:"ready_at"`
+	ExpiresAt *time.Time `bson:"expires_at"`
+	// ClosedAt is set when the hold is fulfilled, cancelled or expires.
+	ClosedAt *time.Time `bson:"closed_at"`
+	// Version starts at 1 and is incremented by every write to the hold.
+	Version int64 `bson:"version"`
+}
+
+// HoldFilter narrows a hold listing. Zero values mean no restriction.
+type HoldFilter struct {
+	GameID   string
+	MemberID string
+	Status   HoldStatus
+	// ExpiredBy selects holds whose pickup window ended at or before this time.
+	ExpiredBy time.Time
+}
+
+// HoldQuery selects one page of holds in the order they were placed.
+// Cursor is the Next value of the previous page.
+type HoldQuery struct {
+	Filter HoldFilter
+	Cursor string
+	Limit  int
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"game-library-management-system/src/apperr"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ParseID parses a hex-encoded ObjectID as used in URLs and request bodies.
+// Returns an apperr.ErrInvalidID error when id is malformed.
+func ParseID(id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return primitive.NilObjectID, apperr.InvalidID(id)
+	}
+	return oid, nil
+}
@@ -0,0 +1,39 @@
+package model
+
+import "io"
+
+// ImportFile is a file of developers and games to import.
+type ImportFile struct {
+	Format FileFormat
+	Body   io.Reader
+}
+
+// ImportOptions controls how an import is run.
+type ImportOptions struct {
+	// DryRun checks every row and reports what would be imported without writing anything.
+	DryRun bool
+	// BatchSize is the number of rows written per transaction. Zero means the default.
+	BatchSize int
+}
+
+// ImportRowError is a problem with one row of an import file. Line is where the row starts in the file.
+type ImportRowError struct {
+	Line    int
+	Field   string
+	Message string
+}
+
+// ImportReport is the outcome of an import. Rows with errors are skipped; the others are imported.
+type ImportReport struct {
+	DryRun             bool
+	Rows               int
+	DevelopersCreated  int
+	DevelopersExisting int
+	GamesCreated       int
+	// Batches is the number of transactions committed, zero for a dry run.
+	Batches int
+	// Stopped is set when a read or storage error ended the import early. The counts then cover the rows read
+	// before it, less those of the batch that was not written.
+	Stopped bool
+	Errors  []ImportRowError
+}
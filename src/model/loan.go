@@ -0,0 +1,40 @@
+package model
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Loan records a game checked out by a member. ReturnedAt is nil while the game is out.
+// CopyID is the copy that was lent, or nil for a game without copies.
+type Loan struct {
+	ID           primitive.ObjectID  `bson:"_id"`
+	GameID       primitive.ObjectID  `bson:"game_id"`
+	CopyID       *primitive.ObjectID `bson:"copy_id"`
+	MemberID     primitive.ObjectID  `bson:"member_id"`
+	CheckedOutAt time.Time           `bson:"checked_out_at"`
+	DueAt        time.Time           `bson:"due_at"`
+	ReturnedAt   *time.Time          `bson:"returned_at"`
+	// Overdue is set once the loan is found still out after DueAt, or is returned late.
+	Overdue bool `bson:"overdue"`
+}
+
+// LoanFilter narrows a loan listing. Zero values mean no restriction.
+type LoanFilter struct {
+	GameID   string
+	MemberID string
+	// Active selects loans that are still out (true) or returned (false).
+	Active *bool
+	// Overdue selects loans that are marked overdue (true) or not (false).
+	Overdue *bool
+	// DueBefore selects loans due before this time.
+	DueBefore time.Time
+}
+
+// LoanQuery selects one page of loans, oldest first.
+// Cursor is the Next value of the previous page.
+type LoanQuery struct {
+	Filter LoanFilter
+	Cursor string
+	Limit  int
+}
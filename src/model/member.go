@@ -0,0 +1,48 @@
+package model
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// MemberStatus is the standing of a member's library membership.
+type MemberStatus string
+
+const (
+	// MemberActive members may borrow games.
+	MemberActive MemberStatus = "active"
+	// MemberSuspended members are barred from borrowing until the library lifts the suspension.
+	MemberSuspended MemberStatus = "suspended"
+	// MemberExpired members have to renew their membership before borrowing again.
+	MemberExpired MemberStatus = "expired"
+)
+
+// Member is a library patron who can borrow games.
+// Email and CardNumber are unique, so either of them identifies a member.
+type Member struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"name"`
+	Email      string             `bson:"email"`
+	CardNumber string             `bson:"card_number"`
+	Status     MemberStatus       `bson:"status"`
+	// Version starts at 1 and is incremented by every update of the member.
+	Version int64 `bson:"version"`
+}
+
+// IsActive reports whether the member may borrow games.
+// Members registered before statuses existed have none and count as active.
+func (m Member) IsActive() bool {
+	return m.Status == MemberActive || m.Status == ""
+}
+
+// MemberFilter narrows a member listing. Zero values mean no restriction.
+type MemberFilter struct {
+	Status        MemberStatus
+	NameSubstring string
+}
+
+// MemberQuery selects one page of members.
+// Sort is name or -name; empty sorts by creation order. Cursor is the Next value of the previous page.
+type MemberQuery struct {
+	Filter MemberFilter
+	Sort   string
+	Cursor string
+	Limit  int
+}
@@ -0,0 +1,10 @@
+package model
+
+// Page is one page of a listing.
+// Next is an opaque cursor for the following page and is empty on the last page.
+// Total is the number of items matching the filter across all pages.
+type Page[T any] struct {
+	Items []T
+	Next  string
+	Total int64
+}
@@ -0,0 +1,19 @@
+package model
+
+// PatchFormat names the format of a patch document.
+type PatchFormat string
+
+const (
+	// MergePatch is a JSON Merge Patch (RFC 7386).
+	MergePatch PatchFormat = "merge"
+	// JSONPatch is a JSON Patch (RFC 6902).
+	JSONPatch PatchFormat = "json"
+)
+
+// Patch is a partial update to a resource, applied to its JSON representation.
+type Patch struct {
+	Format   PatchFormat
+	Document []byte
+	// Version, if non-zero, must match the version of the resource the patch is applied to.
+	Version int64
+}
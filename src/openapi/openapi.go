@@ -0,0 +1,245 @@
+// Package openapi builds an OpenAPI 3 document from the operations routes are registered with.
+// Request and response bodies are described by Go values whose types are turned into schemas,
+// so the document follows the types the handlers decode and encode.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Version is the OpenAPI version of the documents built by this package.
+const Version = "3.0.3"
+
+// Operation describes what a route does.
+type Operation struct {
+	Summary     string
+	Description string
+	// Tag groups the operation with the others of the same resource.
+	Tag string
+	// Parameters are the query and header parameters. Path parameters are taken from the path
+	// and only need to be listed to describe them.
+	Parameters []Parameter
+	Request    *Body
+	Responses  []Response
+	// Errors are the statuses answered with a problem document.
+	Errors []int
+}
+
+// Parameter is a parameter of an operation. In is query, header or path, and Type a JSON schema type.
+type Parameter struct {
+	Name        string
+	In          string
+	Description string
+	Type        string
+	Enum        []string
+	Required    bool
+}
+
+// Query returns an optional query parameter of the given JSON schema type.
+func Query(name string, typ string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Type: typ}
+}
+
+// Header returns an optional string header parameter.
+func Header(name string, description string) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Type: "string"}
+}
+
+// Body is a request or response body. Schema is a value of the type sent in each of the content types in Types.
+type Body struct {
+	Description string
+	Types       []string
+	Schema      any
+	Required    bool
+}
+
+// JSON returns a required JSON body of the type of v.
+func JSON(description string, v any) *Body {
+	return &Body{Description: description, Types: []string{"application/json"}, Schema: v, Required: true}
+}
+
+// Response is a response of an operation. Body is nil for a response without content.
+type Response struct {
+	Status      int
+	Description string
+	Body        *Body
+}
+
+// JSONResponse returns a response with a JSON body of the type of v.
+func JSONResponse(status int, description string, v any) Response {
+	return Response{Status: status, Description: description, Body: &Body{Types: []string{"application/json"}, Schema: v}}
+}
+
+// EmptyResponse returns a response without content.
+func EmptyResponse(status int, description string) Response {
+	return Response{Status: status, Description: description}
+}
+
+// Info is the information about the API at the top of a document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a base URL the paths of a document are relative to.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components components                      `json:"components"`
+
+	schemas *schemaRegistry
+	// problem is the schema of the problem documents answering Errors.
+	problem *Schema
+}
+
+type components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type operation struct {
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []parameter         `json:"parameters,omitempty"`
+	RequestBody *requestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]response `json:"responses"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]mediaType `json:"content"`
+}
+
+type response struct {
+	Description string               `json:"description"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// NewDocument creates an empty document. Errors of the operations are answered with a problem document
+// of the type of problem, sent as application/problem+json.
+func NewDocument(info Info, problem any) *Document {
+	d := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      make(map[string]map[string]operation),
+		Components: components{Schemas: make(map[string]*Schema)},
+	}
+	d.schemas = &schemaRegistry{components: d.Components.Schemas, types: make(map[string]string)}
+	d.problem = d.schemas.schemaOf(problem)
+	return d
+}
+
+// pathParams matches the variables of a route path. A variable may carry a pattern after a colon.
+var pathParams = regexp.MustCompile(`\{([^}:]+)(?::[^}]*)?}`)
+
+// Add documents the route with the given method and path. Every route must have an operation,
+// with a summary and at least one response, and a route may only be added once.
+func (d *Document) Add(method string, path string, op *Operation) error {
+	if op == nil {
+		return fmt.Errorf("%s %s has no OpenAPI operation", method, path)
+	}
+	if op.Summary == "" || len(op.Responses) == 0 {
+		return fmt.Errorf("%s %s needs a summary and at least one response", method, path)
+	}
+
+	// Path variables with a pattern are written without it, as OpenAPI paths have none.
+	specPath := pathParams.ReplaceAllString(path, "{$1}")
+	methods := d.Paths[specPath]
+	if methods == nil {
+		methods = make(map[string]operation)
+		d.Paths[specPath] = methods
+	}
+	key := strings.ToLower(method)
+	if _, ok := methods[key]; ok {
+		return fmt.Errorf("%s %s is added twice", method, path)
+	}
+
+	out := operation{
+		Summary:     op.Summary,
+		Description: op.Description,
+		Responses:   make(map[string]response),
+	}
+	if op.Tag != "" {
+		out.Tags = []string{op.Tag}
+	}
+	for _, match := range pathParams.FindAllStringSubmatch(path, -1) {
+		p := Parameter{Name: match[1], In: "path", Type: "string"}
+		if i := slices.IndexFunc(op.Parameters, func(q Parameter) bool { return q.In == "path" && q.Name == p.Name }); i >= 0 {
+			p = op.Parameters[i]
+		}
+		p.Required = true
+		out.Parameters = append(out.Parameters, d.parameter(p))
+	}
+	for _, p := range op.Parameters {
+		if p.In != "path" {
+			out.Parameters = append(out.Parameters, d.parameter(p))
+		}
+	}
+	if op.Request != nil {
+		out.RequestBody = &requestBody{
+			Description: op.Request.Description,
+			Required:    op.Request.Required,
+			Content:     d.content(op.Request),
+		}
+	}
+	for _, r := range op.Responses {
+		out.Responses[fmt.Sprint(r.Status)] = response{Description: r.Description, Content: d.content(r.Body)}
+	}
+	for _, status := range op.Errors {
+		out.Responses[fmt.Sprint(status)] = response{
+			Description: http.StatusText(status),
+			Content:     map[string]mediaType{"application/problem+json": {Schema: d.problem}},
+		}
+	}
+
+	methods[key] = out
+	return nil
+}
+
+// parameter returns the document form of a parameter.
+func (d *Document) parameter(p Parameter) parameter {
+	schema := &Schema{Type: p.Type}
+	for _, v := range p.Enum {
+		schema.Enum = append(schema.Enum, v)
+	}
+	return parameter{Name: p.Name, In: p.In, Description: p.Description, Required: p.Required, Schema: schema}
+}
+
+// content returns the media types of a body, or nil if there is no body.
+func (d *Document) content(body *Body) map[string]mediaType {
+	if body == nil || len(body.Types) == 0 {
+		return nil
+	}
+	schema := d.schemas.schemaOf(body.Schema)
+	content := make(map[string]mediaType, len(body.Types))
+	for _, t := range body.Types {
+		content[t] = mediaType{Schema: schema}
+	}
+	return content
+}
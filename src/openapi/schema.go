@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"encoding/json"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Schema is an OpenAPI schema object.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Binary is a Body schema for content that is not JSON, like a file download.
+type Binary struct{}
+
+var (
+	binaryType   = reflect.TypeFor[Binary]()
+	objectIDType = reflect.TypeFor[primitive.ObjectID]()
+	timeType     = reflect.TypeFor[time.Time]()
+	rawType      = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemaRegistry turns Go types into schemas. Named struct types become components referenced by name.
+type schemaRegistry struct {
+	components map[string]*Schema
+	// types maps the component names to the types they were made from, to keep names unique.
+	types map[string]string
+}
+
+// schemaOf returns the schema of the type of v, or an empty schema, which allows anything, if v is nil.
+func (r *schemaRegistry) schemaOf(v any) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return r.schema(reflect.TypeOf(v))
+}
+
+// schema returns the schema of the JSON encoding of t.
+func (r *schemaRegistry) schema(t reflect.Type) *Schema {
+	switch t {
+	case binaryType:
+		return &Schema{Type: "string", Format: "binary"}
+	case objectIDType:
+		return &Schema{Type: "string", Pattern: "^[0-9a-f]{24}$"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Pointer:
+		s := r.schema(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+		return r.component(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// component returns a reference to the component of a named struct type, adding it on first use.
+func (r *schemaRegistry) component(t reflect.Type) *Schema {
+	name := componentName(t)
+	if r.types[name] != "" && r.types[name] != t.String() {
+		// Another package has a type of the same name.
+		name = componentName(t) + "From" + exportedName(t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:])
+	}
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if r.types[name] != "" {
+		return ref
+	}
+	r.types[name] = t.String()
+	// The placeholder stops recursive types from being expanded again.
+	r.components[name] = &Schema{}
+	*r.components[name] = *r.object(t)
+	return ref
+}
+
+// object returns the schema of a struct, with the fields encoding/json writes as its properties.
+func (r *schemaRegistry) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		if field.Anonymous && name == field.Name && field.Type.Kind() == reflect.Struct {
+			// Embedded structs are flattened into their parent.
+			for k, v := range r.object(field.Type).Properties {
+				s.Properties[k] = v
+			}
+			continue
+		}
+		s.Properties[name] = r.schema(field.Type)
+	}
+	return s
+}
+
+// jsonName returns the property name encoding/json gives a field, or false if the field is not encoded.
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+		return "", false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+// componentName returns the component name of a named type. Type arguments are appended to the name,
+// so Page[model.Game] is PageGame.
+func componentName(t reflect.Type) string {
+	name, args, generic := strings.Cut(t.Name(), "[")
+	if generic {
+		for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+			name += arg[strings.LastIndex(arg, ".")+1:]
+		}
+	}
+	return exportedName(name)
+}
+
+// exportedName returns name with its first letter in upper case.
+func exportedName(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(first)) + name[size:]
+}
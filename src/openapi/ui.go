@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"path"
+)
+
+//go:embed swagger.html
+var swaggerHTML string
+
+var swaggerTemplate = template.Must(template.New("swagger").Parse(swaggerHTML))
+
+// swaggerFiles are the Swagger UI scripts and styles, vendored from swagger-ui-dist.
+//
+//go:embed swagger-ui/swagger-ui.css swagger-ui/swagger-ui-bundle.js
+var swaggerFiles embed.FS
+
+// Handler returns a handler serving the document as JSON. The document is encoded once,
+// so operations added afterwards are not served.
+func (d *Document) Handler() (http.HandlerFunc, error) {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}, nil
+}
+
+// UIHandler returns a handler serving a Swagger UI page for the document at specURL.
+// The page loads the Swagger UI scripts and styles from assetsURL, where AssetsHandler serves them.
+func UIHandler(title string, specURL string, assetsURL string) (http.HandlerFunc, error) {
+	var page bytes.Buffer
+	err := swaggerTemplate.Execute(&page, struct{ Title, SpecURL, AssetsURL string }{title, specURL, assetsURL})
+	if err != nil {
+		return nil, err
+	}
+	body := page.Bytes()
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(body)
+	}, nil
+}
+
+// AssetsHandler returns a handler serving the Swagger UI scripts and styles embedded in the binary.
+// The file is the last element of the request path, so the handler can be mounted under any path.
+func AssetsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, swaggerFiles, "swagger-ui/"+path.Base(r.URL.Path))
+	}
+}
@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestUIAssetsEmbedded checks that every script and style the Swagger UI page links to is served from the binary.
+func TestUIAssetsEmbedded(t *testing.T) {
+	serveUI, err := UIHandler("test", "/api/openapi.json", "/api/docs")
+	if err != nil {
+		t.Fatalf("UIHandler: %v", err)
+	}
+	page := httptest.NewRecorder()
+	serveUI(page, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+
+	links := regexp.MustCompile(`(?:href|src)="(/api/docs/[^"]+)"`).FindAllStringSubmatch(page.Body.String(), -1)
+	if len(links) != 2 {
+		t.Fatalf("page links to %d assets, want the script and the styles:\n%s", len(links), page.Body)
+	}
+	serveAsset := AssetsHandler()
+	for _, link := range links {
+		asset := httptest.NewRecorder()
+		serveAsset(asset, httptest.NewRequest(http.MethodGet, link[1], nil))
+		if asset.Code != http.StatusOK || asset.Body.Len() == 0 {
+			t.Errorf("GET %s = %d with %d bytes, want 200 with content", link[1], asset.Code, asset.Body.Len())
+		}
+		if ct := asset.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/css") && !strings.HasPrefix(ct, "text/javascript") {
+			t.Errorf("GET %s has Content-Type %q", link[1], ct)
+		}
+	}
+
+	missing := httptest.NewRecorder()
+	serveAsset(missing, httptest.NewRequest(http.MethodGet, "/api/docs/LICENSE", nil))
+	if missing.Code != http.StatusNotFound {
+		t.Errorf("GET of a file that is not embedded = %d, want 404", missing.Code)
+	}
+}
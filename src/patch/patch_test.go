@@ -0,0 +1,134 @@
+package patch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"game-library-management-system/src/patch"
+	"reflect"
+	"testing"
+)
+
+// sameJSON reports whether two JSON documents hold the same value.
+func sameJSON(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var x, y any
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// TestApply checks each JSON Patch operation, array indexes, pointer escapes and the errors.
+func TestApply(t *testing.T) {
+	const doc = `{"a": 1, "b": {"c": [1, 2, 3]}, "x/y": 1, "m~n": 2}`
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+		err   error
+	}{
+		{"add member", `[{"op": "add", "path": "/d", "value": 4}]`, `{"a": 1, "b": {"c": [1, 2, 3]}, "x/y": 1, "m~n": 2, "d": 4}`, nil},
+		{"add replaces member", `[{"op": "add", "path": "/a", "value": [true]}]`, `{"a": [true], "b": {"c": [1, 2, 3]}, "x/y": 1, "m~n": 2}`, nil},
+		{"add inside array", `[{"op": "add", "path": "/b/c/1", "value": 9}]`, `{"a": 1, "b": {"c": [1, 9, 2, 3]}, "x/y": 1, "m~n": 2}`, nil},
+		{"add after last element", `[{"op": "add", "path": "/b/c/3", "value": 9}]`, `{"a": 1, "b": {"c": [1, 2, 3, 9]}, "x/y": 1, "m~n": 2}`, nil},
+		{"add with -", `[{"op": "add", "path": "/b/c/-", "value": 9}]`, `{"a": 1, "b": {"c": [1, 2, 3, 9]}, "x/y": 1, "m~n": 2}`, nil},
+		{"add out of range", `[{"op": "add", "path": "/b/c/5", "value": 9}]`, "", patch.ErrInvalid},
+		{"add under missing parent", `[{"op": "add", "path": "/z/d", "value": 9}]`, "", patch.ErrInvalid},
+		{"add whole document", `[{"op": "add", "path": "", "value": {"z": 0}}]`, `{"z": 0}`, nil},
+		{"remove member", `[{"op": "remove", "path": "/a"}]`, `{"b": {"c": [1, 2, 3]}, "x/y": 1, "m~n": 2}`, nil},
+		{"remove element", `[{"op": "remove", "path": "/b/c/0"}]`, `{"a": 1, "b": {"c": [2, 3]}, "x/y": 1, "m~n": 2}`, nil},
+		{"remove missing member", `[{"op": "remove", "path": "/z"}]`, "", patch.ErrInvalid},
+		{"remove past last element", `[{"op": "remove", "path": "/b/c/3"}]`, "", patch.ErrInvalid},
+		{"remove with -", `[{"op": "remove", "path": "/b/c/-"}]`, "", patch.ErrInvalid},
+		{"replace member", `[{"op": "replace", "path": "/a", "value": "one"}]`, `{"a": "one", "b": {"c": [1, 2, 3]}, "x/y": 1, "m~n": 2}`, nil},
+		{"replace element", `[{"op": "replace", "path": "/b/c/2", "value": 0}]`, `{"a": 1, "b": {"c": [1, 2, 0]}, "x/y": 1, "m~n": 2}`, nil},
+		{"replace missing member", `[{"op": "replace", "path": "/z", "value": 0}]`, "", patch.ErrInvalid},
+		{"replace without value", `[{"op": "replace", "path": "/a"}]`, "", patch.ErrInvalid},
+		{"move member", `[{"op": "move", "from": "/a", "path": "/b/a"}]`, `{"b": {"c": [1, 2, 3], "a": 1}, "x/y": 1, "m~n": 2}`, nil},
+		{"move element", `[{"op": "move", "from": "/b/c/0", "path": "/b/c/-"}]`, `{"a": 1, "b": {"c": [2, 3, 1]}, "x/y": 1, "m~n": 2}`, nil},
+		{"move into child", `[{"op": "move", "from": "/b", "path": "/b/d"}]`, "", patch.ErrInvalid},
+		{"move missing member", `[{"op": "move", "from": "/z", "path": "/a"}]`, "", patch.ErrInvalid},
+		{"copy member", `[{"op": "copy", "from": "/b/c", "path": "/d"}]`, `{"a": 1, "b": {"c": [1, 2, 3]}, "d": [1, 2, 3], "x/y": 1, "m~n": 2}`, nil},
+		{"copy is independent", `[{"op": "copy", "from": "/b/c", "path": "/d"}, {"op": "remove", "path": "/d/0"}]`, `{"a": 1, "b": {"c": [1, 2, 3]}, "d": [2, 3], "x/y": 1, "m~n": 2}`, nil},
+		{"test", `[{"op": "test", "path": "/b/c", "value": [1, 2, 3]}]`, doc, nil},
+		{"test number by value", `[{"op": "test", "path": "/a", "value": 1.0}]`, doc, nil},
+		{"test failed", `[{"op": "test", "path": "/a", "value": 2}]`, "", patch.ErrTestFailed},
+		{"test of another type", `[{"op": "test", "path": "/a", "value": "1"}]`, "", patch.ErrTestFailed},
+		{"test missing member", `[{"op": "test", "path": "/z", "value": 1}]`, "", patch.ErrInvalid},
+		{"failed test undoes earlier operations", `[{"op": "replace", "path": "/a", "value": 2}, {"op": "test", "path": "/a", "value": 1}]`, "", patch.ErrTestFailed},
+		{"~1 is /", `[{"op": "replace", "path": "/x~1y", "value": 5}]`, `{"a": 1, "b": {"c": [1, 2, 3]}, "x/y": 5, "m~n": 2}`, nil},
+		{"~0 is ~", `[{"op": "remove", "path": "/m~0n"}]`, `{"a": 1, "b": {"c": [1, 2, 3]}, "x/y": 1}`, nil},
+		{"path without /", `[{"op": "remove", "path": "a"}]`, "", patch.ErrInvalid},
+		{"index with leading zero", `[{"op": "remove", "path": "/b/c/01"}]`, "", patch.ErrInvalid},
+		{"negative index", `[{"op": "remove", "path": "/b/c/-1"}]`, "", patch.ErrInvalid},
+		{"path through a value", `[{"op": "add", "path": "/a/b", "value": 1}]`, "", patch.ErrInvalid},
+		{"unknown operation", `[{"op": "increment", "path": "/a", "value": 1}]`, "", patch.ErrInvalid},
+		{"not a list of operations", `{"op": "remove", "path": "/a"}`, "", patch.ErrInvalid},
+	}
+	for _, tt := range tests {
+		got, err := patch.Apply([]byte(doc), []byte(tt.patch))
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !sameJSON(t, got, []byte(tt.want)) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestApplyKeepsNumbers checks that numbers are written back as they were, even beyond float64 precision.
+func TestApplyKeepsNumbers(t *testing.T) {
+	got, err := patch.Apply([]byte(`{"big": 12345678901234567890, "n": 1}`), []byte(`[{"op": "remove", "path": "/n"}]`))
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if string(got) != `{"big":12345678901234567890}` {
+		t.Errorf("got %s", got)
+	}
+}
+
+// TestMerge checks merge patches, including that member names are matched exactly.
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"replace member", `{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{"add member", `{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{"remove member", `{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{"remove missing member", `{"a": "b"}`, `{"z": null}`, `{"a": "b"}`},
+		{"arrays are replaced", `{"a": [{"b": "c"}]}`, `{"a": [1]}`, `{"a": [1]}`},
+		{"nested objects are merged", `{"a": {"b": "c", "d": "e"}}`, `{"a": {"b": "f", "d": null}}`, `{"a": {"b": "f"}}`},
+		{"object replaces value", `{"a": "b"}`, `{"a": {"c": null, "d": 1}}`, `{"a": {"d": 1}}`},
+		{"non-object patch replaces document", `{"a": "b"}`, `["c"]`, `["c"]`},
+		{"empty patch", `{"a": "b"}`, `{}`, `{"a": "b"}`},
+		{"names are case-sensitive", `{"Available": true}`, `{"available": false}`, `{"Available": true, "available": false}`},
+		{"removal is case-sensitive", `{"Genre": "RPG"}`, `{"genre": null}`, `{"Genre": "RPG"}`},
+	}
+	for _, tt := range tests {
+		got, err := patch.Merge([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !sameJSON(t, got, []byte(tt.want)) {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := patch.Merge([]byte(`{"a": "b"}`), []byte(`{"a": `)); !errors.Is(err, patch.ErrInvalid) {
+		t.Errorf("malformed patch: error = %v, want %v", err, patch.ErrInvalid)
+	}
+}
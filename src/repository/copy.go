@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CopyRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCopyRepository creates a new CopyRepository instance.
+// Takes the MongoDB database shared by all repositories.
+// Returns the CopyRepositorer interface.
+func NewCopyRepository(db *mongo.Database) _interface.CopyRepositorer {
+	return &CopyRepository{
+		collection: db.Collection("copies"),
+	}
+}
+
+// ListCopies retrieves the copies of a game from the collection in the order they were added.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns a slice of Copy models or an error if the operation fails.
+func (r *CopyRepository) ListCopies(ctx context.Context, gameID string) ([]model.Copy, error) {
+	i, err := model.ParseID(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"game_id": i}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	copies := make([]model.Copy, 0)
+	if err := cursor.All(ctx, &copies); err != nil {
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+// PageCopies retrieves one page of the copies of a game from the collection in the order they were added.
+// Takes a context for managing request lifetime and a CopyQuery.
+// Returns a Page of Copy models or an error if the operation fails.
+func (r *CopyRepository) PageCopies(ctx context.Context, query model.CopyQuery) (*model.Page[model.Copy], error) {
+	i, err := model.ParseID(query.GameID)
+	if err != nil {
+		return nil, err
+	}
+	return findPage(ctx, r.collection, bson.M{"game_id": i}, query.Cursor, query.Limit, func(c model.Copy) primitive.ObjectID { return c.ID })
+}
+
+// GetCopyById retrieves a copy by its ID from the collection.
+// Takes a context for managing request lifetime and the copy ID as a string.
+// Returns a Copy model or an error if the operation fails.
+func (r *CopyRepository) GetCopyById(ctx context.Context, id string) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": i})
+}
+
+// GetCopyByBarcode retrieves the copy with the given barcode from the collection.
+// Takes a context for managing request lifetime and the barcode.
+// Returns a Copy model or an error if the operation fails.
+func (r *CopyRepository) GetCopyByBarcode(ctx context.Context, barcode string) (*model.Copy, error) {
+	return r.findOne(ctx, bson.M{"barcode": barcode})
+}
+
+// findOne decodes the copy matching filter, or returns a NotFound error if there is none.
+func (r *CopyRepository) findOne(ctx context.Context, filter bson.M) (*model.Copy, error) {
+	var gameCopy model.Copy
+	err := r.collection.FindOne(ctx, filter).Decode(&gameCopy)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("copy not found")
+		}
+		return nil, err
+	}
+	return &gameCopy, nil
+}
+
+// CountCopies counts the copies of a game in the collection and how many of them are available.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns both counts or an error if the operation fails.
+func (r *CopyRepository) CountCopies(ctx context.Context, gameID string) (int, int, error) {
+	i, err := model.ParseID(gameID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, bson.M{"game_id": i})
+	if err != nil {
+		return 0, 0, err
+	}
+	available, err := r.collection.CountDocuments(ctx, bson.M{"game_id": i, "available": true})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(total), int(available), nil
+}
+
+// AddCopy inserts a new copy into the collection.
+// Takes a context for managing request lifetime and a Copy model.
+// Returns the inserted Copy model or an error if the operation fails.
+func (r *CopyRepository) AddCopy(ctx context.Context, gameCopy model.Copy) (*model.Copy, error) {
+	gameCopy.ID = primitive.NewObjectID()
+	gameCopy.Version = 1
+
+	_, err := r.collection.InsertOne(ctx, gameCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gameCopy, nil
+}
+
+// UpdateCopy replaces the barcode, condition, platform and shelf location of a copy in the collection.
+// A non-zero gameCopy.Version must match the stored version.
+// Takes a context for managing request lifetime, the copy ID as a string, and a Copy model.
+// Returns the updated Copy model or an error if the operation fails.
+func (r *CopyRepository) UpdateCopy(ctx context.Context, id string, gameCopy model.Copy) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i}
+	if gameCopy.Version != 0 {
+		filter["version"] = gameCopy.Version
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"barcode":        gameCopy.Barcode,
+			"condition":      gameCopy.Condition,
+			"platform":       gameCopy.Platform,
+			"shelf_location": gameCopy.ShelfLocation,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	var updated model.Copy
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.missOrMismatch(ctx, i, gameCopy.Version)
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SetCopyAvailability marks a copy in the collection as available or on loan.
+// The update only matches while the copy has the other value and, for a non-zero version, that version,
+// so it is a single atomic compare-and-set. Setting the value the copy already has changes nothing.
+// Takes a context for managing request lifetime, the copy ID as a string, the new availability and the expected version.
+// Returns the updated Copy model or an error if the copy is missing or its version does not match.
+func (r *CopyRepository) SetCopyAvailability(ctx context.Context, id string, available bool, version int64) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i, "available": bson.M{"$ne": available}}
+	if version != 0 {
+		filter["version"] = version
+	}
+	update := bson.M{"$set": bson.M{"available": available}, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var gameCopy model.Copy
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&gameCopy)
+	if err == nil {
+		return &gameCopy, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	// Nothing matched: the copy is missing, at another version, or already has the value.
+	current, err := r.findOne(ctx, bson.M{"_id": i})
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && current.Version != version {
+		return nil, apperr.VersionMismatch("copy", current.Version, version)
+	}
+	return current, nil
+}
+
+// DeleteCopy removes a copy from the collection by its ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the copy ID as a string and the expected version.
+// Returns an error if the operation fails or if no document is found.
+func (r *CopyRepository) DeleteCopy(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": i}
+	if version != 0 {
+		filter["version"] = version
+	}
+	info, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if info.DeletedCount == 0 {
+		return r.missOrMismatch(ctx, i, version)
+	}
+	return nil
+}
+
+// missOrMismatch explains why a write filtered by ID and version matched nothing:
+// it returns a NotFound error if the copy is missing and a version mismatch otherwise.
+func (r *CopyRepository) missOrMismatch(ctx context.Context, id primitive.ObjectID, version int64) error {
+	current, err := r.findOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	return apperr.VersionMismatch("copy", current.Version, version)
+}
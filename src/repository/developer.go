@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"game-library-management-system/src/apperr"
 	"game-library-management-system/src/interface"
 	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
 )
 
 type DeveloperRepository struct {
@@ -16,20 +19,12 @@ type DeveloperRepository struct {
 }
 
 // NewDeveloperRepository creates a new DeveloperRepository instance.
-// Connects to the MongoDB database using the provided URI and database name.
-// Returns the DeveloperRepositorer interface or an error if the connection fails.
-func NewDeveloperRepository(URI, dbName string) (_interface.DeveloperRepositorer, error) {
-	clientOptions := options.Client().ApplyURI(URI)
-	client, err := mongo.Connect(context.Background(), clientOptions)
-	if err != nil {
-		return nil, err
-	}
-
-	db := client.Database(dbName)
-
+// Takes the MongoDB database shared by all repositories.
+// Returns the DeveloperRepositorer interface.
+func NewDeveloperRepository(db *mongo.Database) _interface.DeveloperRepositorer {
 	return &DeveloperRepository{
 		collection: db.Collection("developers"),
-	}, nil
+	}
 }
 
 // GetAllDevelopers retrieves all developers from the collection.
@@ -50,17 +45,109 @@ func (r *DeveloperRepository) GetAllDevelopers(ctx context.Context) ([]model.Dev
 	return devs, nil
 }
 
+// ListDevelopers retrieves one page of developers matching the query from the collection.
+// Takes a context for managing request lifetime and a DeveloperQuery.
+// Returns a Page of Developer models or an error if the operation fails.
+func (r *DeveloperRepository) ListDevelopers(ctx context.Context, query model.DeveloperQuery) (*model.Page[model.Developer], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "name")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	filter := developerFilter(query.Filter)
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pageFilter := filter
+	if cursor != nil {
+		after, err := afterCursor(field, desc, cursor)
+		if err != nil {
+			return nil, err
+		}
+		pageFilter = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	opts := options.Find().SetSort(sortOrder(field, desc)).SetLimit(int64(limit + 1))
+	result, err := r.collection.Find(ctx, pageFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	devs := make([]model.Developer, 0, limit+1)
+	if err := result.All(ctx, &devs); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[model.Developer]{Items: devs, Total: total}
+	if len(devs) > limit {
+		page.Items = devs[:limit]
+		last := page.Items[limit-1]
+		page.Next = paging.Encode(paging.Cursor{Sort: query.Sort, Str: last.Name, ID: last.ID.Hex()})
+	}
+
+	return page, nil
+}
+
+// developerFilter translates a DeveloperFilter into a MongoDB query document.
+func developerFilter(f model.DeveloperFilter) bson.M {
+	filter := bson.M{}
+	if f.MainHq != "" {
+		filter["mainhq"] = f.MainHq
+	}
+	if f.NameSubstring != "" {
+		filter["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(f.NameSubstring), Options: "i"}
+	}
+	return filter
+}
+
+// StreamDevelopers calls fn with each developer matching the filter, in the order given by sort like ListDevelopers.
+// The developers are decoded one at a time from a MongoDB cursor. Iteration stops at the first error returned by fn.
+// Takes a context for managing request lifetime, a DeveloperFilter, a sort and the function to call.
+// Returns the error of fn or an error if the operation fails.
+func (r *DeveloperRepository) StreamDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, fn func(model.Developer) error) error {
+	field, desc, err := paging.ParseSort(sort, "name")
+	if err != nil {
+		return err
+	}
+
+	cursor, err := r.collection.Find(ctx, developerFilter(filter), options.Find().SetSort(sortOrder(field, desc)))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var dev model.Developer
+		if err := cursor.Decode(&dev); err != nil {
+			return err
+		}
+		if err := fn(dev); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
 // GetDeveloperById retrieves a developer by their ID from the collection.
 // Takes a context for managing request lifetime and the developer ID as a string.
 // Returns a Developer model or an error if the operation fails.
 func (r *DeveloperRepository) GetDeveloperById(ctx context.Context, id string) (*model.Developer, error) {
 	var dev model.Developer
-	i, err := primitive.ObjectIDFromHex(id)
+	i, err := model.ParseID(id)
 	if err != nil {
 		return nil, err
 	}
 	err = r.collection.FindOne(ctx, bson.M{"_id": i}).Decode(&dev)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("developer not found")
+		}
 		return nil, err
 	}
 
@@ -72,6 +159,7 @@ func (r *DeveloperRepository) GetDeveloperById(ctx context.Context, id string) (
 // Returns the inserted Developer model or an error if the operation fails.
 func (r *DeveloperRepository) AddDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
 	developer.ID = primitive.NewObjectID()
+	developer.Version = 1
 
 	_, err := r.collection.InsertOne(ctx, developer)
 	if err != nil {
@@ -82,38 +170,83 @@ func (r *DeveloperRepository) AddDeveloper(ctx context.Context, developer model.
 }
 
 // UpdateDeveloper updates an existing developer in the collection.
+// A non-zero developer.Version must match the stored version.
 // Takes a context for managing request lifetime, the developer ID as a string, and a Developer model.
 // Returns the updated Developer model or an error if the operation fails.
 func (r *DeveloperRepository) UpdateDeveloper(ctx context.Context, id string, developer model.Developer) (*model.Developer, error) {
-	i, err := primitive.ObjectIDFromHex(id)
+	i, err := model.ParseID(id)
 	if err != nil {
 		return nil, err
 	}
-	developer.ID = i
-	result, err := r.collection.UpdateByID(ctx, i, bson.M{"$set": developer})
+
+	filter := bson.M{"_id": i}
+	if developer.Version != 0 {
+		filter["version"] = developer.Version
+	}
+	update := bson.M{
+		"$set": bson.M{"name": developer.Name, "mainhq": developer.MainHq},
+		"$inc": bson.M{"version": 1},
+	}
+	var updated model.Developer
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.missOrMismatch(ctx, i, developer.Version, "developer id not found")
+		}
 		return nil, err
 	}
-	if result.MatchedCount == 0 {
-		return nil, errors.New("developer id not found")
+	return &updated, nil
+}
+
+// RestoreDeveloper writes a developer as it is, ID and version included, replacing the developer with the same ID.
+// Games embedding the developer are not updated.
+// Takes a context for managing request lifetime and a Developer model.
+// Returns the written Developer model or an error if the operation fails.
+func (r *DeveloperRepository) RestoreDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": developer.ID}, developer, opts)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+
+	return &developer, nil
 }
 
 // DeleteDeveloper removes a developer from the collection by their ID.
-// Takes a context for managing request lifetime and the developer ID as a string.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the developer ID as a string and the expected version.
 // Returns an error if the operation fails or if no document is found.
-func (r *DeveloperRepository) DeleteDeveloper(ctx context.Context, id string) error {
-	i, err := primitive.ObjectIDFromHex(id)
+func (r *DeveloperRepository) DeleteDeveloper(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
 	if err != nil {
 		return err
 	}
-	info, err := r.collection.DeleteOne(ctx, bson.M{"_id": i})
+	filter := bson.M{"_id": i}
+	if version != 0 {
+		filter["version"] = version
+	}
+	info, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
 	}
 	if info.DeletedCount == 0 {
-		return errors.New("no document found")
+		return r.missOrMismatch(ctx, i, version, "developer not found")
 	}
 	return nil
 }
+
+// missOrMismatch explains why a write filtered by ID and version matched nothing:
+// it returns a NotFound error with the given message if the developer is missing,
+// and a version mismatch otherwise.
+func (r *DeveloperRepository) missOrMismatch(ctx context.Context, id primitive.ObjectID, version int64, notFound string) error {
+	var current model.Developer
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&current)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return apperr.NotFound("%s", notFound)
+		}
+		return err
+	}
+	return apperr.VersionMismatch("developer", current.Version, version)
+}
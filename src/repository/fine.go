@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FineRepository struct {
+	collection *mongo.Collection
+}
+
+// NewFineRepository creates a new FineRepository instance.
+// Takes the MongoDB database shared by all repositories.
+// Returns the FineRepositorer interface.
+func NewFineRepository(db *mongo.Database) _interface.FineRepositorer {
+	return &FineRepository{
+		collection: db.Collection("fines"),
+	}
+}
+
+// ListFines retrieves the fines matching the filter from the collection, oldest first.
+// Takes a context for managing request lifetime and a FineFilter.
+// Returns a slice of Fine models or an error if the operation fails.
+func (r *FineRepository) ListFines(ctx context.Context, filter model.FineFilter) ([]model.Fine, error) {
+	query, err := fineFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	fines := make([]model.Fine, 0)
+	if err := cursor.All(ctx, &fines); err != nil {
+		return nil, err
+	}
+
+	return fines, nil
+}
+
+// PageFines retrieves one page of the fines matching the query from the collection, oldest first.
+// Takes a context for managing request lifetime and a FineQuery.
+// Returns a Page of Fine models or an error if the operation fails.
+func (r *FineRepository) PageFines(ctx context.Context, query model.FineQuery) (*model.Page[model.Fine], error) {
+	filter, err := fineFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return findPage(ctx, r.collection, filter, query.Cursor, query.Limit, func(f model.Fine) primitive.ObjectID { return f.ID })
+}
+
+// fineFilter builds the query document selecting the fines that match filter.
+func fineFilter(filter model.FineFilter) (bson.M, error) {
+	query := bson.M{}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		query["member_id"] = id
+	}
+	if filter.LoanID != "" {
+		id, err := model.ParseID(filter.LoanID)
+		if err != nil {
+			return nil, err
+		}
+		query["loan_id"] = id
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	return query, nil
+}
+
+// GetFineById retrieves a fine by its ID from the collection.
+// Takes a context for managing request lifetime and the fine ID as a string.
+// Returns a Fine model or an error if the operation fails.
+func (r *FineRepository) GetFineById(ctx context.Context, id string) (*model.Fine, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, i)
+}
+
+// findOne decodes the fine with the given ID, or returns a NotFound error if there is none.
+func (r *FineRepository) findOne(ctx context.Context, id primitive.ObjectID) (*model.Fine, error) {
+	var fine model.Fine
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&fine)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("fine not found")
+		}
+		return nil, err
+	}
+	return &fine, nil
+}
+
+// AddFine inserts a new fine into the collection.
+// Takes a context for managing request lifetime and a Fine model.
+// Returns the inserted Fine model or an error if the operation fails.
+func (r *FineRepository) AddFine(ctx context.Context, fine model.Fine) (*model.Fine, error) {
+	fine.ID = primitive.NewObjectID()
+	fine.Version = 1
+
+	_, err := r.collection.InsertOne(ctx, fine)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fine, nil
+}
+
+// UpdateFine replaces the days late, amounts, status and times of a fine in the collection.
+// A non-zero fine.Version must match the stored version.
+// Takes a context for managing request lifetime, the fine ID as a string, and a Fine model.
+// Returns the updated Fine model or an error if the operation fails.
+func (r *FineRepository) UpdateFine(ctx context.Context, id string, fine model.Fine) (*model.Fine, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i}
+	if fine.Version != 0 {
+		filter["version"] = fine.Version
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"days_late":   fine.DaysLate,
+			"amount":      fine.Amount,
+			"paid":        fine.Paid,
+			"status":      fine.Status,
+			"assessed_at": fine.AssessedAt,
+			"closed_at":   fine.ClosedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	var updated model.Fine
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			current, err := r.findOne(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			return nil, apperr.VersionMismatch("fine", current.Version, fine.Version)
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
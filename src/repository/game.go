@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"errors"
+	"game-library-management-system/src/apperr"
 	"game-library-management-system/src/interface"
 	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
 )
 
 type GameRepository struct {
@@ -16,20 +19,12 @@ type GameRepository struct {
 }
 
 // NewGameRepository creates a new GameRepository instance.
-// Connects to the MongoDB database using the provided URI and database name.
-// Returns the GameRepositorer interface or an error if the connection fails.
-func NewGameRepository(URI, dbName string) (_interface.GameRepositorer, error) {
-	clientOptions := options.Client().ApplyURI(URI)
-	client, err := mongo.Connect(context.Background(), clientOptions)
-	if err != nil {
-		return nil, err
-	}
-
-	db := client.Database(dbName)
-
+// Takes the MongoDB database shared by all repositories.
+// Returns the GameRepositorer interface.
+func NewGameRepository(db *mongo.Database) _interface.GameRepositorer {
 	return &GameRepository{
 		collection: db.Collection("games"),
-	}, nil
+	}
 }
 
 // GetAllGames retrieves all games from the collection.
@@ -50,17 +45,146 @@ func (r *GameRepository) GetAllGames(ctx context.Context) ([]model.Game, error)
 	return gs, nil
 }
 
+// ListGames retrieves one page of games matching the query from the collection.
+// Filtering, sorting and keyset pagination are all done by MongoDB.
+// Takes a context for managing request lifetime and a GameQuery.
+// Returns a Page of Game models or an error if the operation fails.
+func (r *GameRepository) ListGames(ctx context.Context, query model.GameQuery) (*model.Page[model.Game], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "title", "genre", "year")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	filter, err := gameFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pageFilter := filter
+	if cursor != nil {
+		after, err := afterCursor(field, desc, cursor)
+		if err != nil {
+			return nil, err
+		}
+		pageFilter = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	opts := options.Find().SetSort(sortOrder(field, desc)).SetLimit(int64(limit + 1))
+	result, err := r.collection.Find(ctx, pageFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	games := make([]model.Game, 0, limit+1)
+	if err := result.All(ctx, &games); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[model.Game]{Items: games, Total: total}
+	if len(games) > limit {
+		page.Items = games[:limit]
+		last := page.Items[limit-1]
+		next := paging.Cursor{Sort: query.Sort, ID: last.ID.Hex()}
+		switch field {
+		case "title":
+			next.Str = last.Title
+		case "genre":
+			next.Str = last.Genre
+		case "year":
+			next.Num = last.PublicationYear
+		}
+		page.Next = paging.Encode(next)
+	}
+
+	return page, nil
+}
+
+// gameFilter translates a GameFilter into a MongoDB query document.
+func gameFilter(f model.GameFilter) (bson.M, error) {
+	filter := bson.M{}
+	if f.Genre != "" {
+		filter["genre"] = f.Genre
+	}
+	year := bson.M{}
+	if f.YearFrom != 0 {
+		year["$gte"] = f.YearFrom
+	}
+	if f.YearTo != 0 {
+		year["$lte"] = f.YearTo
+	}
+	if len(year) > 0 {
+		filter["year"] = year
+	}
+	if f.Available != nil {
+		filter["available"] = *f.Available
+	}
+	if f.DeveloperID != "" {
+		id, err := model.ParseID(f.DeveloperID)
+		if err != nil {
+			return nil, err
+		}
+		filter["developer._id"] = id
+	}
+	if f.TitlePrefix != "" {
+		filter["title"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(f.TitlePrefix), Options: "i"}
+	}
+	return filter, nil
+}
+
+// StreamGames calls fn with each game matching the filter, in the order given by sort like ListGames.
+// The games are decoded one at a time from a MongoDB cursor. Iteration stops at the first error returned by fn.
+// Takes a context for managing request lifetime, a GameFilter, a sort and the function to call.
+// Returns the error of fn or an error if the operation fails.
+func (r *GameRepository) StreamGames(ctx context.Context, filter model.GameFilter, sort string, fn func(model.Game) error) error {
+	field, desc, err := paging.ParseSort(sort, "title", "genre", "year")
+	if err != nil {
+		return err
+	}
+	query, err := gameFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(sortOrder(field, desc)))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var game model.Game
+		if err := cursor.Decode(&game); err != nil {
+			return err
+		}
+		if err := fn(game); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
 // GetGameById retrieves a game by its ID from the collection.
 // Takes a context for managing request lifetime and the game ID as a string.
 // Returns a Game model or an error if the operation fails.
 func (r *GameRepository) GetGameById(ctx context.Context, id string) (*model.Game, error) {
 	var game model.Game
-	i, err := primitive.ObjectIDFromHex(id)
+	i, err := model.ParseID(id)
 	if err != nil {
 		return nil, err
 	}
 	err = r.collection.FindOne(ctx, bson.M{"_id": i}).Decode(&game)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("game not found")
+		}
 		return nil, err
 	}
 
@@ -68,6 +192,7 @@ func (r *GameRepository) GetGameById(ctx context.Context, id string) (*model.Gam
 }
 
 // AddGame inserts a new game into the collection.
+// The developer is embedded as it is stored, not as it was sent.
 // Takes a context for managing request lifetime and a Game model.
 // Returns the inserted Game model or an error if the operation fails.
 func (r *GameRepository) AddGame(ctx context.Context, game model.Game) (*model.Game, error) {
@@ -75,11 +200,13 @@ func (r *GameRepository) AddGame(ctx context.Context, game model.Game) (*model.G
 	err := r.collection.Database().Collection("developers").FindOne(ctx, bson.M{"_id": game.Developer.ID}).Decode(&developer)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("developer does not exist")
+			return nil, apperr.Validation("developer does not exist")
 		}
 		return nil, err
 	}
+	game.Developer = developer
 	game.ID = primitive.NewObjectID()
+	game.Version = 1
 	_, err = r.collection.InsertOne(ctx, game)
 	if err != nil {
 		return nil, err
@@ -88,40 +215,196 @@ func (r *GameRepository) AddGame(ctx context.Context, game model.Game) (*model.G
 	return &game, nil
 }
 
+// UpdateGame replaces the title, developer, genre, year and availability of a game in the collection.
+// The developer is embedded as it is stored. A non-zero game.Version must match the stored version.
+// Takes a context for managing request lifetime, the game ID as a string and the new Game model.
+// Returns the updated Game model or an error if the game or developer is missing or the version does not match.
+func (r *GameRepository) UpdateGame(ctx context.Context, id string, game model.Game) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var developer model.Developer
+	err = r.collection.Database().Collection("developers").FindOne(ctx, bson.M{"_id": game.Developer.ID}).Decode(&developer)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.Validation("developer does not exist")
+		}
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i}
+	if game.Version != 0 {
+		filter["version"] = game.Version
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"title":     game.Title,
+			"developer": developer,
+			"genre":     game.Genre,
+			"year":      game.PublicationYear,
+			"available": game.Available,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated model.Game
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == nil {
+		return &updated, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	// Nothing matched: the game is missing or at another version.
+	current, err := r.GetGameById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return nil, apperr.VersionMismatch("game", current.Version, game.Version)
+}
+
+// RestoreGame writes a game as it is, ID, version and copy counts included, replacing the game with the same ID.
+// The developer is embedded as it is stored.
+// Takes a context for managing request lifetime and a Game model.
+// Returns the written Game model or an error if the developer is missing or the operation fails.
+func (r *GameRepository) RestoreGame(ctx context.Context, game model.Game) (*model.Game, error) {
+	var developer model.Developer
+	err := r.collection.Database().Collection("developers").FindOne(ctx, bson.M{"_id": game.Developer.ID}).Decode(&developer)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.Validation("developer does not exist")
+		}
+		return nil, err
+	}
+	game.Developer = developer
+
+	opts := options.Replace().SetUpsert(true)
+	_, err = r.collection.ReplaceOne(ctx, bson.M{"_id": game.ID}, game, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &game, nil
+}
+
 // UpdateAvailability toggles the availability of a game in the collection.
+// The flag is flipped by an update pipeline in a single find-and-modify, so concurrent toggles do not get lost.
 // Takes a context for managing request lifetime and the game ID as a string.
 // Returns the updated Game model or an error if the operation fails.
 func (r *GameRepository) UpdateAvailability(ctx context.Context, id string) (*model.Game, error) {
-	i, err := primitive.ObjectIDFromHex(id)
+	i, err := model.ParseID(id)
 	if err != nil {
 		return nil, err
 	}
+
+	toggle := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"available": bson.M{"$not": "$available"},
+		"version":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$version", 0}}, 1}},
+	}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var game model.Game
-	err = r.collection.FindOne(ctx, bson.M{"_id": i}).Decode(&game)
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": i}, toggle, opts).Decode(&game)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("game not found")
+		}
+		return nil, err
+	}
+	return &game, nil
+}
+
+// SetAvailability sets the availability of a game in the collection to an explicit value.
+// The update only matches while the game has the other value and, for a non-zero version, that version,
+// so it is a single atomic compare-and-set. Setting the value the game already has changes nothing.
+// Takes a context for managing request lifetime, the game ID as a string, the new availability and the expected version.
+// Returns the updated Game model or an error if the game is missing or its version does not match.
+func (r *GameRepository) SetAvailability(ctx context.Context, id string, available bool, version int64) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i, "available": bson.M{"$ne": available}}
+	if version != 0 {
+		filter["version"] = version
+	}
+	update := bson.M{"$set": bson.M{"available": available}, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var game model.Game
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&game)
+	if err == nil {
+		return &game, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, err
 	}
-	available := !game.Available
-	_, err = r.collection.UpdateByID(ctx, i, bson.M{"$set": bson.M{"available": available}})
+
+	// Nothing matched: the game is missing, at another version, or already has the value.
+	current, err := r.GetGameById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return nil, nil
+	if version != 0 && current.Version != version {
+		return nil, apperr.VersionMismatch("game", current.Version, version)
+	}
+	return current, nil
 }
 
-// DeleteGame removes a game from the collection.
-// Takes a context for managing request lifetime and the game ID as a string.
+// SetCopyCounts stores the number of copies of a game and how many of them are available in the collection.
+// The game is available exactly when one of its copies is.
+// Takes a context for managing request lifetime, the game ID as a string and the two counts.
+// Returns the updated Game model or an error if the game is missing.
+func (r *GameRepository) SetCopyCounts(ctx context.Context, id string, copies int, available int) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{"copies": copies, "available_copies": available, "available": available > 0},
+		"$inc": bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var game model.Game
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": i}, update, opts).Decode(&game)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("game not found")
+		}
+		return nil, err
+	}
+	return &game, nil
+}
+
+// DeleteGame removes a game and its copies from the collection.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the game ID as a string and the expected version.
 // Returns an error if the operation fails.
-func (r *GameRepository) DeleteGame(ctx context.Context, id string) error {
-	i, err := primitive.ObjectIDFromHex(id)
+func (r *GameRepository) DeleteGame(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
 	if err != nil {
 		return err
 	}
-	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": i})
+	filter := bson.M{"_id": i}
+	if version != 0 {
+		filter["version"] = version
+	}
+	info, err := r.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
 	}
-	return nil
+	if info.DeletedCount == 0 {
+		current, err := r.GetGameById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return apperr.VersionMismatch("game", current.Version, version)
+	}
+	_, err = r.collection.Database().Collection("copies").DeleteMany(ctx, bson.M{"game_id": i})
+	return err
 }
 
 // FindGamesByDeveloper retrieves all games by a developer from the collection.
@@ -132,7 +415,7 @@ func (r *GameRepository) FindGamesByDeveloper(ctx context.Context, developerName
 	err := r.collection.Database().Collection("developers").FindOne(ctx, bson.M{"name": developerName}).Decode(&developer)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("developer does not exist")
+			return nil, apperr.NotFound("developer does not exist")
 		}
 		return nil, err
 	}
@@ -151,18 +434,77 @@ func (r *GameRepository) FindGamesByDeveloper(ctx context.Context, developerName
 	return games, nil
 }
 
-// DeleteManyGamesByDeveloper removes all games by a developer from the collection.
+// DeleteManyGamesByDeveloper removes all games by a developer and their copies from the collection.
 // Takes a context for managing request lifetime and the developer ID as a string.
 // Returns an error if the operation fails.
 func (r *GameRepository) DeleteManyGamesByDeveloper(ctx context.Context, developerId string) error {
-	id, err := primitive.ObjectIDFromHex(developerId)
+	id, err := model.ParseID(developerId)
 	if err != nil {
 		return err
 	}
 
+	gameIDs, err := r.collection.Distinct(ctx, "_id", bson.M{"developer._id": id})
+	if err != nil {
+		return err
+	}
 	_, err = r.collection.DeleteMany(ctx, bson.M{"developer._id": id})
 	if err != nil {
 		return err
 	}
+	if len(gameIDs) > 0 {
+		_, err = r.collection.Database().Collection("copies").DeleteMany(ctx, bson.M{"game_id": bson.M{"$in": gameIDs}})
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// CountGamesByDeveloper counts the games by a developer in the collection.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns the number of games or an error if the operation fails.
+func (r *GameRepository) CountGamesByDeveloper(ctx context.Context, developerId string) (int64, error) {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return 0, err
+	}
+
+	return r.collection.CountDocuments(ctx, bson.M{"developer._id": id})
+}
+
+// ReassignGames moves all games by a developer to another developer in the collection.
+// Takes a context for managing request lifetime, the current developer ID as a string, and the new Developer model.
+// Returns an error if the operation fails.
+func (r *GameRepository) ReassignGames(ctx context.Context, developerId string, developer model.Developer) error {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"developer": developer}, "$inc": bson.M{"version": 1}}
+	_, err = r.collection.UpdateMany(ctx, bson.M{"developer._id": id}, update)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateGamesDeveloper rewrites the embedded developer of every game that references it
+// and whose copy differs from the given Developer model, bumping the version of each game it changes.
+// Takes a context for managing request lifetime and the current Developer model.
+// Returns the number of games changed or an error if the operation fails.
+func (r *GameRepository) UpdateGamesDeveloper(ctx context.Context, developer model.Developer) (int64, error) {
+	filter := bson.M{
+		"developer._id": developer.ID,
+		"$or": bson.A{
+			bson.M{"developer.name": bson.M{"$ne": developer.Name}},
+			bson.M{"developer.mainhq": bson.M{"$ne": developer.MainHq}},
+			bson.M{"developer.version": bson.M{"$ne": developer.Version}},
+		},
+	}
+	result, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"developer": developer}, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
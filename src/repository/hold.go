@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type HoldRepository struct {
+	collection *mongo.Collection
+}
+
+// NewHoldRepository creates a new HoldRepository instance.
+// Takes the MongoDB database shared by all repositories.
+// Returns the HoldRepositorer interface.
+func NewHoldRepository(db *mongo.Database) _interface.HoldRepositorer {
+	return &HoldRepository{
+		collection: db.Collection("holds"),
+	}
+}
+
+// ListHolds retrieves the holds matching the filter from the collection in the order they were placed.
+// Takes a context for managing request lifetime and a HoldFilter.
+// Returns a slice of Hold models or an error if the operation fails.
+func (r *HoldRepository) ListHolds(ctx context.Context, filter model.HoldFilter) ([]model.Hold, error) {
+	query, err := holdFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	holds := make([]model.Hold, 0)
+	if err := cursor.All(ctx, &holds); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// PageHolds retrieves one page of the holds matching the query from the collection in the order they were placed.
+// Takes a context for managing request lifetime and a HoldQuery.
+// Returns a Page of Hold models or an error if the operation fails.
+func (r *HoldRepository) PageHolds(ctx context.Context, query model.HoldQuery) (*model.Page[model.Hold], error) {
+	filter, err := holdFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return findPage(ctx, r.collection, filter, query.Cursor, query.Limit, func(h model.Hold) primitive.ObjectID { return h.ID })
+}
+
+// holdFilter builds the query document selecting the holds that match filter.
+func holdFilter(filter model.HoldFilter) (bson.M, error) {
+	query := bson.M{}
+	if filter.GameID != "" {
+		id, err := model.ParseID(filter.GameID)
+		if err != nil {
+			return nil, err
+		}
+		query["game_id"] = id
+	}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		query["member_id"] = id
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if !filter.ExpiredBy.IsZero() {
+		query["expires_at"] = bson.M{"$lte": filter.ExpiredBy}
+	}
+	return query, nil
+}
+
+// GetHoldById retrieves a hold by its ID from the collection.
+// Takes a context for managing request lifetime and the hold ID as a string.
+// Returns a Hold model or an error if the operation fails.
+func (r *HoldRepository) GetHoldById(ctx context.Context, id string) (*model.Hold, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, i)
+}
+
+// findOne decodes the hold with the given ID, or returns a NotFound error if there is none.
+func (r *HoldRepository) findOne(ctx context.Context, id primitive.ObjectID) (*model.Hold, error) {
+	var hold model.Hold
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&hold)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("hold not found")
+		}
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// AddHold inserts a new hold into the collection.
+// Takes a context for managing request lifetime and a Hold model.
+// Returns the inserted Hold model or an error if the operation fails.
+func (r *HoldRepository) AddHold(ctx context.Context, hold model.Hold) (*model.Hold, error) {
+	hold.ID = primitive.NewObjectID()
+	hold.Version = 1
+
+	_, err := r.collection.InsertOne(ctx, hold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// UpdateHold replaces the status, reserved copy and times of a hold in the collection.
+// A non-zero hold.Version must match the stored version.
+// Takes a context for managing request lifetime, the hold ID as a string, and a Hold model.
+// Returns the updated Hold model or an error if the operation fails.
+func (r *HoldRepository) UpdateHold(ctx context.Context, id string, hold model.Hold) (*model.Hold, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i}
+	if hold.Version != 0 {
+		filter["version"] = hold.Version
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     hold.Status,
+			"copy_id":    hold.CopyID,
+			"ready_at":   hold.ReadyAt,
+			"expires_at": hold.ExpiresAt,
+			"closed_at":  hold.ClosedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	var updated model.Hold
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			current, err := r.findOne(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			return nil, apperr.VersionMismatch("hold", current.Version, hold.Version)
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
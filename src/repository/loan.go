@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+type LoanRepository struct {
+	collection *mongo.Collection
+}
+
+// NewLoanRepository creates a new LoanRepository instance.
+// Takes the MongoDB database shared by all repositories.
+// Returns the LoanRepositorer interface.
+func NewLoanRepository(db *mongo.Database) _interface.LoanRepositorer {
+	return &LoanRepository{
+		collection: db.Collection("loans"),
+	}
+}
+
+// ListLoans retrieves the loans matching the filter from the collection, oldest first.
+// Takes a context for managing request lifetime and a LoanFilter.
+// Returns a slice of Loan models or an error if the operation fails.
+func (r *LoanRepository) ListLoans(ctx context.Context, filter model.LoanFilter) ([]model.Loan, error) {
+	query, err := loanFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	loans := make([]model.Loan, 0)
+	if err := cursor.All(ctx, &loans); err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// PageLoans retrieves one page of the loans matching the query from the collection, oldest first.
+// Takes a context for managing request lifetime and a LoanQuery.
+// Returns a Page of Loan models or an error if the operation fails.
+func (r *LoanRepository) PageLoans(ctx context.Context, query model.LoanQuery) (*model.Page[model.Loan], error) {
+	filter, err := loanFilter(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return findPage(ctx, r.collection, filter, query.Cursor, query.Limit, func(l model.Loan) primitive.ObjectID { return l.ID })
+}
+
+// loanFilter builds the query document selecting the loans that match filter.
+func loanFilter(filter model.LoanFilter) (bson.M, error) {
+	query := bson.M{}
+	if filter.GameID != "" {
+		id, err := model.ParseID(filter.GameID)
+		if err != nil {
+			return nil, err
+		}
+		query["game_id"] = id
+	}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		query["member_id"] = id
+	}
+	if filter.Active != nil {
+		if *filter.Active {
+			query["returned_at"] = nil
+		} else {
+			query["returned_at"] = bson.M{"$ne": nil}
+		}
+	}
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			query["overdue"] = true
+		} else {
+			// Loans from before overdue tracking have no overdue field.
+			query["overdue"] = bson.M{"$ne": true}
+		}
+	}
+	if !filter.DueBefore.IsZero() {
+		query["due_at"] = bson.M{"$lt": filter.DueBefore}
+	}
+	return query, nil
+}
+
+// GetLoanById retrieves a loan by its ID from the collection.
+// Takes a context for managing request lifetime and the loan ID as a string.
+// Returns a Loan model or an error if the operation fails.
+func (r *LoanRepository) GetLoanById(ctx context.Context, id string) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": i})
+}
+
+// findOne decodes the loan matching filter, or returns a NotFound error if there is none.
+func (r *LoanRepository) findOne(ctx context.Context, filter bson.M) (*model.Loan, error) {
+	var loan model.Loan
+	err := r.collection.FindOne(ctx, filter).Decode(&loan)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("loan not found")
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// AddLoan inserts a new loan into the collection.
+// Takes a context for managing request lifetime and a Loan model.
+// Returns the inserted Loan model or an error if the operation fails.
+func (r *LoanRepository) AddLoan(ctx context.Context, loan model.Loan) (*model.Loan, error) {
+	loan.ID = primitive.NewObjectID()
+
+	_, err := r.collection.InsertOne(ctx, loan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loan, nil
+}
+
+// CloseLoan marks a loan as returned at the given time.
+// Takes a context for managing request lifetime, the loan ID as a string and the return time.
+// Returns the updated Loan model, or a Conflict error if the loan was already returned.
+func (r *LoanRepository) CloseLoan(ctx context.Context, id string, returnedAt time.Time) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var loan model.Loan
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	filter := bson.M{"_id": i, "returned_at": nil}
+	err = r.collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": bson.M{"returned_at": returnedAt}}, opts).Decode(&loan)
+	if err == nil {
+		return &loan, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	if _, err := r.GetLoanById(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, apperr.Conflict("loan is already returned")
+}
+
+// MarkOverdue marks a loan as overdue. Marking a loan that is already overdue changes nothing.
+// Takes a context for managing request lifetime and the loan ID as a string.
+// Returns the updated Loan model or an error if the loan is missing.
+func (r *LoanRepository) MarkOverdue(ctx context.Context, id string) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var loan model.Loan
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": i}, bson.M{"$set": bson.M{"overdue": true}}, opts).Decode(&loan)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("loan not found")
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"regexp"
+)
+
+type MemberRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMemberRepository creates a new MemberRepository instance.
+// Takes the MongoDB database shared by all repositories.
+// Returns the MemberRepositorer interface.
+func NewMemberRepository(db *mongo.Database) _interface.MemberRepositorer {
+	return &MemberRepository{
+		collection: db.Collection("members"),
+	}
+}
+
+// ListMembers retrieves one page of members matching the query from the collection.
+// Takes a context for managing request lifetime and a MemberQuery.
+// Returns a Page of Member models or an error if the operation fails.
+func (r *MemberRepository) ListMembers(ctx context.Context, query model.MemberQuery) (*model.Page[model.Member], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "name")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	filter := bson.M{}
+	if query.Filter.Status != "" {
+		filter["status"] = query.Filter.Status
+	}
+	if query.Filter.NameSubstring != "" {
+		filter["name"] = primitive.Regex{Pattern: regexp.QuoteMeta(query.Filter.NameSubstring), Options: "i"}
+	}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pageFilter := filter
+	if cursor != nil {
+		after, err := afterCursor(field, desc, cursor)
+		if err != nil {
+			return nil, err
+		}
+		pageFilter = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	order := 1
+	if desc {
+		order = -1
+	}
+	sort := bson.D{{Key: "_id", Value: order}}
+	if field != "" {
+		sort = bson.D{{Key: field, Value: order}, {Key: "_id", Value: 1}}
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(int64(limit + 1))
+	result, err := r.collection.Find(ctx, pageFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]model.Member, 0, limit+1)
+	if err := result.All(ctx, &members); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[model.Member]{Items: members, Total: total}
+	if len(members) > limit {
+		page.Items = members[:limit]
+		last := page.Items[limit-1]
+		page.Next = paging.Encode(paging.Cursor{Sort: query.Sort, Str: last.Name, ID: last.ID.Hex()})
+	}
+
+	return page, nil
+}
+
+// GetMemberById retrieves a member by their ID from the collection.
+// Takes a context for managing request lifetime and the member ID as a string.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberById(ctx context.Context, id string) (*model.Member, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.findOne(ctx, bson.M{"_id": i})
+}
+
+// GetMemberByEmail retrieves the member with the given email address from the collection.
+// Takes a context for managing request lifetime and the email address as stored.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberByEmail(ctx context.Context, email string) (*model.Member, error) {
+	return r.findOne(ctx, bson.M{"email": email})
+}
+
+// GetMemberByCardNumber retrieves the member with the given library card number from the collection.
+// Takes a context for managing request lifetime and the card number.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error) {
+	return r.findOne(ctx, bson.M{"card_number": cardNumber})
+}
+
+// findOne retrieves the member matching filter, or a NotFound error if there is none.
+func (r *MemberRepository) findOne(ctx context.Context, filter bson.M) (*model.Member, error) {
+	var member model.Member
+	err := r.collection.FindOne(ctx, filter).Decode(&member)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, apperr.NotFound("member not found")
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// AddMember inserts a new member into the collection.
+// Takes a context for managing request lifetime and a Member model.
+// Returns the inserted Member model or an error if the operation fails.
+func (r *MemberRepository) AddMember(ctx context.Context, member model.Member) (*model.Member, error) {
+	member.ID = primitive.NewObjectID()
+	member.Version = 1
+
+	_, err := r.collection.InsertOne(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// UpdateMember updates an existing member in the collection.
+// A non-zero member.Version must match the stored version.
+// Takes a context for managing request lifetime, the member ID as a string, and a Member model.
+// Returns the updated Member model or an error if the operation fails.
+func (r *MemberRepository) UpdateMember(ctx context.Context, id string, member model.Member) (*model.Member, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"_id": i}
+	if member.Version != 0 {
+		filter["version"] = member.Version
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"name":        member.Name,
+			"email":       member.Email,
+			"card_number": member.CardNumber,
+			"status":      member.Status,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+	var updated model.Member
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.missOrMismatch(ctx, i, member.Version)
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteMember removes a member from the collection by their ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the member ID as a string and the expected version.
+// Returns an error if the operation fails or if no document is found.
+func (r *MemberRepository) DeleteMember(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": i}
+	if version != 0 {
+		filter["version"] = version
+	}
+	info, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if info.DeletedCount == 0 {
+		return r.missOrMismatch(ctx, i, version)
+	}
+	return nil
+}
+
+// missOrMismatch explains why a write filtered by ID and version matched nothing:
+// it returns a NotFound error if the member is missing and a version mismatch otherwise.
+func (r *MemberRepository) missOrMismatch(ctx context.Context, id primitive.ObjectID, version int64) error {
+	current, err := r.findOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	return apperr.VersionMismatch("member", current.Version, version)
+}
@@ -0,0 +1,211 @@
+package memory
+
+import (
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+)
+
+type CopyRepository struct {
+	store *Store
+}
+
+// NewCopyRepository creates a new in-memory CopyRepository instance.
+// Takes the Store shared with the other repositories.
+// Returns the CopyRepositorer interface.
+func NewCopyRepository(store *Store) _interface.CopyRepositorer {
+	return &CopyRepository{
+		store: store,
+	}
+}
+
+// ListCopies retrieves the copies of a game from the store in the order they were added.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns a slice of Copy models or an error if the operation fails.
+func (r *CopyRepository) ListCopies(ctx context.Context, gameID string) ([]model.Copy, error) {
+	i, err := model.ParseID(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	copies := make([]model.Copy, 0)
+	for _, c := range r.store.copies {
+		if c.GameID == i {
+			copies = append(copies, c)
+		}
+	}
+
+	return copies, nil
+}
+
+// PageCopies retrieves one page of the copies of a game from the store in the order they were added.
+// Takes a context for managing request lifetime and a CopyQuery.
+// Returns a Page of Copy models or an error if the operation fails.
+func (r *CopyRepository) PageCopies(ctx context.Context, query model.CopyQuery) (*model.Page[model.Copy], error) {
+	copies, err := r.ListCopies(ctx, query.GameID)
+	if err != nil {
+		return nil, err
+	}
+	return pageByID(copies, query.Cursor, query.Limit, func(c model.Copy) primitive.ObjectID { return c.ID })
+}
+
+// GetCopyById retrieves a copy by its ID from the store.
+// Takes a context for managing request lifetime and the copy ID as a string.
+// Returns a Copy model or an error if the operation fails.
+func (r *CopyRepository) GetCopyById(ctx context.Context, id string) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(c model.Copy) bool { return c.ID == i })
+}
+
+// GetCopyByBarcode retrieves the copy with the given barcode from the store.
+// Takes a context for managing request lifetime and the barcode.
+// Returns a Copy model or an error if the operation fails.
+func (r *CopyRepository) GetCopyByBarcode(ctx context.Context, barcode string) (*model.Copy, error) {
+	return r.find(func(c model.Copy) bool { return c.Barcode == barcode })
+}
+
+// find returns a copy of the first stored copy matching match, or a NotFound error if there is none.
+func (r *CopyRepository) find(match func(model.Copy) bool) (*model.Copy, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := slices.IndexFunc(r.store.copies, match)
+	if idx < 0 {
+		return nil, apperr.NotFound("copy not found")
+	}
+	gameCopy := r.store.copies[idx]
+
+	return &gameCopy, nil
+}
+
+// CountCopies counts the copies of a game in the store and how many of them are available.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns both counts or an error if the operation fails.
+func (r *CopyRepository) CountCopies(ctx context.Context, gameID string) (int, int, error) {
+	i, err := model.ParseID(gameID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var total, available int
+	for _, c := range r.store.copies {
+		if c.GameID != i {
+			continue
+		}
+		total++
+		if c.Available {
+			available++
+		}
+	}
+	return total, available, nil
+}
+
+// AddCopy inserts a new copy into the store.
+// Takes a context for managing request lifetime and a Copy model.
+// Returns the inserted Copy model or an error if the operation fails.
+func (r *CopyRepository) AddCopy(ctx context.Context, gameCopy model.Copy) (*model.Copy, error) {
+	gameCopy.ID = primitive.NewObjectID()
+	gameCopy.Version = 1
+
+	defer r.store.lock(ctx)()
+
+	r.store.copies = append(r.store.copies, gameCopy)
+
+	return &gameCopy, nil
+}
+
+// UpdateCopy replaces the barcode, condition, platform and shelf location of a copy in the store.
+// A non-zero gameCopy.Version must match the stored version.
+// Takes a context for managing request lifetime, the copy ID as a string, and a Copy model.
+// Returns the updated Copy model or an error if the operation fails.
+func (r *CopyRepository) UpdateCopy(ctx context.Context, id string, gameCopy model.Copy) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.copyIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("copy not found")
+	}
+	stored := &r.store.copies[idx]
+	if gameCopy.Version != 0 && stored.Version != gameCopy.Version {
+		return nil, apperr.VersionMismatch("copy", stored.Version, gameCopy.Version)
+	}
+
+	stored.Barcode = gameCopy.Barcode
+	stored.Condition = gameCopy.Condition
+	stored.Platform = gameCopy.Platform
+	stored.ShelfLocation = gameCopy.ShelfLocation
+	stored.Version++
+	updated := *stored
+
+	return &updated, nil
+}
+
+// SetCopyAvailability marks a copy in the store as available or on loan.
+// A non-zero version must match the stored one. Setting the value the copy already has changes nothing.
+// Takes a context for managing request lifetime, the copy ID as a string, the new availability and the expected version.
+// Returns the updated Copy model or an error if the copy is missing or its version does not match.
+func (r *CopyRepository) SetCopyAvailability(ctx context.Context, id string, available bool, version int64) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.copyIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("copy not found")
+	}
+	gameCopy := &r.store.copies[idx]
+	if version != 0 && gameCopy.Version != version {
+		return nil, apperr.VersionMismatch("copy", gameCopy.Version, version)
+	}
+	if gameCopy.Available != available {
+		gameCopy.Available = available
+		gameCopy.Version++
+	}
+	updated := *gameCopy
+
+	return &updated, nil
+}
+
+// DeleteCopy removes a copy from the store by its ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the copy ID as a string and the expected version.
+// Returns an error if the operation fails or if no copy is found.
+func (r *CopyRepository) DeleteCopy(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.copyIndex(i)
+	if idx < 0 {
+		return apperr.NotFound("copy not found")
+	}
+	if stored := r.store.copies[idx]; version != 0 && stored.Version != version {
+		return apperr.VersionMismatch("copy", stored.Version, version)
+	}
+	r.store.copies = append(r.store.copies[:idx], r.store.copies[idx+1:]...)
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"strings"
+)
+
+type DeveloperRepository struct {
+	store *Store
+}
+
+// NewDeveloperRepository creates a new in-memory DeveloperRepository instance.
+// Takes the Store shared with the game repository.
+// Returns the DeveloperRepositorer interface.
+func NewDeveloperRepository(store *Store) _interface.DeveloperRepositorer {
+	return &DeveloperRepository{
+		store: store,
+	}
+}
+
+// GetAllDevelopers retrieves all developers from the store.
+// Takes a context for managing request lifetime.
+// Returns a slice of Developer models or an error if the operation fails.
+func (r *DeveloperRepository) GetAllDevelopers(ctx context.Context) ([]model.Developer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	devs := make([]model.Developer, len(r.store.developers))
+	copy(devs, r.store.developers)
+
+	return devs, nil
+}
+
+// ListDevelopers retrieves one page of developers matching the query from the store.
+// Takes a context for managing request lifetime and a DeveloperQuery.
+// Returns a Page of Developer models or an error if the operation fails.
+func (r *DeveloperRepository) ListDevelopers(ctx context.Context, query model.DeveloperQuery) (*model.Page[model.Developer], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "name")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+	match := developerMatcher(query.Filter)
+
+	r.store.mu.RLock()
+	devs := make([]model.Developer, 0)
+	for _, d := range r.store.developers {
+		if match(d) {
+			devs = append(devs, d)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	compare := func(a, b model.Developer) int {
+		return compareDevelopers(a, b, field, desc)
+	}
+	slices.SortFunc(devs, compare)
+	page := &model.Page[model.Developer]{Total: int64(len(devs))}
+
+	if cursor != nil {
+		id, err := primitive.ObjectIDFromHex(cursor.ID)
+		if err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		last := model.Developer{ID: id, Name: cursor.Str}
+		start, _ := slices.BinarySearchFunc(devs, last, compare)
+		for start < len(devs) && compare(devs[start], last) <= 0 {
+			start++
+		}
+		devs = devs[start:]
+	}
+
+	page.Items = devs
+	if len(devs) > limit {
+		page.Items = devs[:limit]
+		last := page.Items[limit-1]
+		page.Next = paging.Encode(paging.Cursor{Sort: query.Sort, Str: last.Name, ID: last.ID.Hex()})
+	}
+
+	return page, nil
+}
+
+// developerMatcher returns a predicate reporting whether a developer passes the filter.
+func developerMatcher(f model.DeveloperFilter) func(model.Developer) bool {
+	substring := strings.ToLower(f.NameSubstring)
+	return func(d model.Developer) bool {
+		if f.MainHq != "" && d.MainHq != f.MainHq {
+			return false
+		}
+		return substring == "" || strings.Contains(strings.ToLower(d.Name), substring)
+	}
+}
+
+// StreamDevelopers calls fn with each developer matching the filter, in the order given by sort like ListDevelopers.
+// The matching developers are copied before fn is called, so fn may use the store. Iteration stops at the first error returned by fn.
+// Takes a context for managing request lifetime, a DeveloperFilter, a sort and the function to call.
+// Returns the error of fn or an error if the operation fails.
+func (r *DeveloperRepository) StreamDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, fn func(model.Developer) error) error {
+	field, desc, err := paging.ParseSort(sort, "name")
+	if err != nil {
+		return err
+	}
+	match := developerMatcher(filter)
+
+	r.store.mu.RLock()
+	devs := make([]model.Developer, 0)
+	for _, d := range r.store.developers {
+		if match(d) {
+			devs = append(devs, d)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	slices.SortFunc(devs, func(a, b model.Developer) int {
+		return compareDevelopers(a, b, field, desc)
+	})
+	for _, d := range devs {
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareDevelopers orders developers by name when sorting by name and then by ID, matching the Mongo backend.
+func compareDevelopers(a, b model.Developer, field string, desc bool) int {
+	c := 0
+	if field == "name" {
+		c = strings.Compare(a.Name, b.Name)
+	}
+	if desc {
+		c = -c
+	}
+	if c == 0 {
+		c = bytes.Compare(a.ID[:], b.ID[:])
+		if field == "" && desc {
+			c = -c
+		}
+	}
+	return c
+}
+
+// GetDeveloperById retrieves a developer by their ID from the store.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns a Developer model or an error if the operation fails.
+func (r *DeveloperRepository) GetDeveloperById(ctx context.Context, id string) (*model.Developer, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := r.store.developerIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("developer not found")
+	}
+	dev := r.store.developers[idx]
+
+	return &dev, nil
+}
+
+// AddDeveloper inserts a new developer into the store.
+// Takes a context for managing request lifetime and a Developer model.
+// Returns the inserted Developer model or an error if the operation fails.
+func (r *DeveloperRepository) AddDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
+	developer.ID = primitive.NewObjectID()
+	developer.Version = 1
+
+	defer r.store.lock(ctx)()
+
+	r.store.developers = append(r.store.developers, developer)
+
+	return &developer, nil
+}
+
+// UpdateDeveloper updates an existing developer in the store.
+// A non-zero developer.Version must match the stored version.
+// Takes a context for managing request lifetime, the developer ID as a string, and a Developer model.
+// Returns the updated Developer model or an error if the operation fails.
+func (r *DeveloperRepository) UpdateDeveloper(ctx context.Context, id string, developer model.Developer) (*model.Developer, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	developer.ID = i
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.developerIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("developer id not found")
+	}
+	stored := r.store.developers[idx]
+	if developer.Version != 0 && stored.Version != developer.Version {
+		return nil, apperr.VersionMismatch("developer", stored.Version, developer.Version)
+	}
+	developer.Version = stored.Version + 1
+	r.store.developers[idx] = developer
+
+	return &developer, nil
+}
+
+// RestoreDeveloper writes a developer as it is, ID and version included, replacing the developer with the same ID.
+// Games embedding the developer are not updated.
+// Takes a context for managing request lifetime and a Developer model.
+// Returns the written Developer model or an error if the operation fails.
+func (r *DeveloperRepository) RestoreDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
+	defer r.store.lock(ctx)()
+
+	if idx := r.store.developerIndex(developer.ID); idx >= 0 {
+		r.store.developers[idx] = developer
+	} else {
+		r.store.developers = append(r.store.developers, developer)
+	}
+
+	return &developer, nil
+}
+
+// DeleteDeveloper removes a developer from the store by their ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the developer ID as a string and the expected version.
+// Returns an error if the operation fails or if no document is found.
+func (r *DeveloperRepository) DeleteDeveloper(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.developerIndex(i)
+	if idx < 0 {
+		return apperr.NotFound("developer not found")
+	}
+	if stored := r.store.developers[idx]; version != 0 && stored.Version != version {
+		return apperr.VersionMismatch("developer", stored.Version, version)
+	}
+	r.store.developers = append(r.store.developers[:idx], r.store.developers[idx+1:]...)
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FineRepository struct {
+	store *Store
+}
+
+// NewFineRepository creates a new in-memory FineRepository instance.
+// Takes the Store shared with the other repositories.
+// Returns the FineRepositorer interface.
+func NewFineRepository(store *Store) _interface.FineRepositorer {
+	return &FineRepository{
+		store: store,
+	}
+}
+
+// ListFines retrieves the fines matching the filter from the store, oldest first.
+// Takes a context for managing request lifetime and a FineFilter.
+// Returns a slice of Fine models or an error if the operation fails.
+func (r *FineRepository) ListFines(ctx context.Context, filter model.FineFilter) ([]model.Fine, error) {
+	var memberID, loanID primitive.ObjectID
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		memberID = id
+	}
+	if filter.LoanID != "" {
+		id, err := model.ParseID(filter.LoanID)
+		if err != nil {
+			return nil, err
+		}
+		loanID = id
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	fines := make([]model.Fine, 0)
+	for _, f := range r.store.fines {
+		switch {
+		case !memberID.IsZero() && f.MemberID != memberID:
+			continue
+		case !loanID.IsZero() && f.LoanID != loanID:
+			continue
+		case filter.Status != "" && f.Status != filter.Status:
+			continue
+		}
+		fines = append(fines, f)
+	}
+
+	return fines, nil
+}
+
+// PageFines retrieves one page of the fines matching the query from the store, oldest first.
+// Takes a context for managing request lifetime and a FineQuery.
+// Returns a Page of Fine models or an error if the operation fails.
+func (r *FineRepository) PageFines(ctx context.Context, query model.FineQuery) (*model.Page[model.Fine], error) {
+	fines, err := r.ListFines(ctx, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return pageByID(fines, query.Cursor, query.Limit, func(f model.Fine) primitive.ObjectID { return f.ID })
+}
+
+// GetFineById retrieves a fine by its ID from the store.
+// Takes a context for managing request lifetime and the fine ID as a string.
+// Returns a Fine model or an error if the operation fails.
+func (r *FineRepository) GetFineById(ctx context.Context, id string) (*model.Fine, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := r.store.fineIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("fine not found")
+	}
+	fine := r.store.fines[idx]
+
+	return &fine, nil
+}
+
+// AddFine inserts a new fine into the store.
+// Takes a context for managing request lifetime and a Fine model.
+// Returns the inserted Fine model or an error if the operation fails.
+func (r *FineRepository) AddFine(ctx context.Context, fine model.Fine) (*model.Fine, error) {
+	fine.ID = primitive.NewObjectID()
+	fine.Version = 1
+
+	defer r.store.lock(ctx)()
+
+	r.store.fines = append(r.store.fines, fine)
+
+	return &fine, nil
+}
+
+// UpdateFine replaces the days late, amounts, status and times of a fine in the store.
+// A non-zero fine.Version must match the stored version.
+// Takes a context for managing request lifetime, the fine ID as a string, and a Fine model.
+// Returns the updated Fine model or an error if the operation fails.
+func (r *FineRepository) UpdateFine(ctx context.Context, id string, fine model.Fine) (*model.Fine, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.fineIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("fine not found")
+	}
+	stored := &r.store.fines[idx]
+	if fine.Version != 0 && stored.Version != fine.Version {
+		return nil, apperr.VersionMismatch("fine", stored.Version, fine.Version)
+	}
+
+	stored.DaysLate = fine.DaysLate
+	stored.Amount = fine.Amount
+	stored.Paid = fine.Paid
+	stored.Status = fine.Status
+	stored.AssessedAt = fine.AssessedAt
+	stored.ClosedAt = fine.ClosedAt
+	stored.Version++
+	updated := *stored
+
+	return &updated, nil
+}
@@ -0,0 +1,513 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"strings"
+)
+
+type GameRepository struct {
+	store *Store
+}
+
+// NewGameRepository creates a new in-memory GameRepository instance.
+// Takes the Store shared with the developer repository.
+// Returns the GameRepositorer interface.
+func NewGameRepository(store *Store) _interface.GameRepositorer {
+	return &GameRepository{
+		store: store,
+	}
+}
+
+// GetAllGames retrieves all games from the store.
+// Takes a context for managing request lifetime.
+// Returns a slice of Game models or an error if the operation fails.
+func (r *GameRepository) GetAllGames(ctx context.Context) ([]model.Game, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	gs := make([]model.Game, len(r.store.games))
+	copy(gs, r.store.games)
+
+	return gs, nil
+}
+
+// ListGames retrieves one page of games matching the query from the store.
+// Takes a context for managing request lifetime and a GameQuery.
+// Returns a Page of Game models or an error if the operation fails.
+func (r *GameRepository) ListGames(ctx context.Context, query model.GameQuery) (*model.Page[model.Game], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "title", "genre", "year")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	match, err := gameMatcher(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	games := make([]model.Game, 0)
+	for _, g := range r.store.games {
+		if match(g) {
+			games = append(games, g)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	compare := func(a, b model.Game) int {
+		return compareGames(a, b, field, desc)
+	}
+	slices.SortFunc(games, compare)
+	page := &model.Page[model.Game]{Total: int64(len(games))}
+
+	if cursor != nil {
+		id, err := primitive.ObjectIDFromHex(cursor.ID)
+		if err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		last := model.Game{ID: id, Title: cursor.Str, Genre: cursor.Str, PublicationYear: cursor.Num}
+		start, _ := slices.BinarySearchFunc(games, last, compare)
+		for start < len(games) && compare(games[start], last) <= 0 {
+			start++
+		}
+		games = games[start:]
+	}
+
+	page.Items = games
+	if len(games) > limit {
+		page.Items = games[:limit]
+		last := page.Items[limit-1]
+		next := paging.Cursor{Sort: query.Sort, ID: last.ID.Hex()}
+		switch field {
+		case "title":
+			next.Str = last.Title
+		case "genre":
+			next.Str = last.Genre
+		case "year":
+			next.Num = last.PublicationYear
+		}
+		page.Next = paging.Encode(next)
+	}
+
+	return page, nil
+}
+
+// StreamGames calls fn with each game matching the filter, in the order given by sort like ListGames.
+// The matching games are copied before fn is called, so fn may use the store. Iteration stops at the first error returned by fn.
+// Takes a context for managing request lifetime, a GameFilter, a sort and the function to call.
+// Returns the error of fn or an error if the operation fails.
+func (r *GameRepository) StreamGames(ctx context.Context, filter model.GameFilter, sort string, fn func(model.Game) error) error {
+	field, desc, err := paging.ParseSort(sort, "title", "genre", "year")
+	if err != nil {
+		return err
+	}
+	match, err := gameMatcher(filter)
+	if err != nil {
+		return err
+	}
+
+	r.store.mu.RLock()
+	games := make([]model.Game, 0)
+	for _, g := range r.store.games {
+		if match(g) {
+			games = append(games, g)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	slices.SortFunc(games, func(a, b model.Game) int {
+		return compareGames(a, b, field, desc)
+	})
+	for _, g := range games {
+		if err := fn(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gameMatcher returns a predicate reporting whether a game passes the filter.
+func gameMatcher(f model.GameFilter) (func(model.Game) bool, error) {
+	var developerID primitive.ObjectID
+	if f.DeveloperID != "" {
+		id, err := model.ParseID(f.DeveloperID)
+		if err != nil {
+			return nil, err
+		}
+		developerID = id
+	}
+	prefix := strings.ToLower(f.TitlePrefix)
+
+	return func(g model.Game) bool {
+		switch {
+		case f.Genre != "" && g.Genre != f.Genre:
+			return false
+		case f.YearFrom != 0 && g.PublicationYear < f.YearFrom:
+			return false
+		case f.YearTo != 0 && g.PublicationYear > f.YearTo:
+			return false
+		case f.Available != nil && g.Available != *f.Available:
+			return false
+		case !developerID.IsZero() && g.Developer.ID != developerID:
+			return false
+		case prefix != "" && !strings.HasPrefix(strings.ToLower(g.Title), prefix):
+			return false
+		}
+		return true
+	}, nil
+}
+
+// compareGames orders games by the sort field and then by ID, matching the Mongo backend.
+// Without a sort field games are ordered by ID only, which follows creation order.
+func compareGames(a, b model.Game, field string, desc bool) int {
+	var c int
+	switch field {
+	case "title":
+		c = strings.Compare(a.Title, b.Title)
+	case "genre":
+		c = strings.Compare(a.Genre, b.Genre)
+	case "year":
+		c = cmp.Compare(a.PublicationYear, b.PublicationYear)
+	default:
+		c = bytes.Compare(a.ID[:], b.ID[:])
+		if desc {
+			c = -c
+		}
+		return c
+	}
+	if desc {
+		c = -c
+	}
+	if c == 0 {
+		c = bytes.Compare(a.ID[:], b.ID[:])
+	}
+	return c
+}
+
+// GetGameById retrieves a game by its ID from the store.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns a Game model or an error if the operation fails.
+func (r *GameRepository) GetGameById(ctx context.Context, id string) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := r.store.gameIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	game := r.store.games[idx]
+
+	return &game, nil
+}
+
+// AddGame inserts a new game into the store.
+// The developer is embedded as it is stored, not as it was sent.
+// Takes a context for managing request lifetime and a Game model.
+// Returns the inserted Game model or an error if the operation fails.
+func (r *GameRepository) AddGame(ctx context.Context, game model.Game) (*model.Game, error) {
+	defer r.store.lock(ctx)()
+
+	devIdx := r.store.developerIndex(game.Developer.ID)
+	if devIdx < 0 {
+		return nil, apperr.Validation("developer does not exist")
+	}
+	game.Developer = r.store.developers[devIdx]
+	game.ID = primitive.NewObjectID()
+	game.Version = 1
+	r.store.games = append(r.store.games, game)
+
+	return &game, nil
+}
+
+// UpdateGame replaces the title, developer, genre, year and availability of a game in the store.
+// The developer is embedded as it is stored. A non-zero game.Version must match the stored version.
+// Takes a context for managing request lifetime, the game ID as a string and the new Game model.
+// Returns the updated Game model or an error if the game or developer is missing or the version does not match.
+func (r *GameRepository) UpdateGame(ctx context.Context, id string, game model.Game) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	devIdx := r.store.developerIndex(game.Developer.ID)
+	if devIdx < 0 {
+		return nil, apperr.Validation("developer does not exist")
+	}
+	idx := r.store.gameIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	stored := &r.store.games[idx]
+	if game.Version != 0 && stored.Version != game.Version {
+		return nil, apperr.VersionMismatch("game", stored.Version, game.Version)
+	}
+
+	stored.Title = game.Title
+	stored.Developer = r.store.developers[devIdx]
+	stored.Genre = game.Genre
+	stored.PublicationYear = game.PublicationYear
+	stored.Available = game.Available
+	stored.Version++
+	updated := *stored
+
+	return &updated, nil
+}
+
+// RestoreGame writes a game as it is, ID, version and copy counts included, replacing the game with the same ID.
+// The developer is embedded as it is stored.
+// Takes a context for managing request lifetime and a Game model.
+// Returns the written Game model or an error if the developer is missing or the operation fails.
+func (r *GameRepository) RestoreGame(ctx context.Context, game model.Game) (*model.Game, error) {
+	defer r.store.lock(ctx)()
+
+	devIdx := r.store.developerIndex(game.Developer.ID)
+	if devIdx < 0 {
+		return nil, apperr.Validation("developer does not exist")
+	}
+	game.Developer = r.store.developers[devIdx]
+	if idx := r.store.gameIndex(game.ID); idx >= 0 {
+		r.store.games[idx] = game
+	} else {
+		r.store.games = append(r.store.games, game)
+	}
+
+	return &game, nil
+}
+
+// UpdateAvailability toggles the availability of a game in the store.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns the updated Game model or an error if the operation fails.
+func (r *GameRepository) UpdateAvailability(ctx context.Context, id string) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.gameIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	r.store.games[idx].Available = !r.store.games[idx].Available
+	r.store.games[idx].Version++
+	game := r.store.games[idx]
+
+	return &game, nil
+}
+
+// SetAvailability sets the availability of a game in the store to an explicit value.
+// A non-zero version must match the stored one. Setting the value the game already has changes nothing.
+// Takes a context for managing request lifetime, the game ID as a string, the new availability and the expected version.
+// Returns the updated Game model or an error if the game is missing or its version does not match.
+func (r *GameRepository) SetAvailability(ctx context.Context, id string, available bool, version int64) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.gameIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	game := &r.store.games[idx]
+	if version != 0 && game.Version != version {
+		return nil, apperr.VersionMismatch("game", game.Version, version)
+	}
+	if game.Available != available {
+		game.Available = available
+		game.Version++
+	}
+	updated := *game
+
+	return &updated, nil
+}
+
+// SetCopyCounts stores the number of copies of a game and how many of them are available in the store.
+// The game is available exactly when one of its copies is.
+// Takes a context for managing request lifetime, the game ID as a string and the two counts.
+// Returns the updated Game model or an error if the game is missing.
+func (r *GameRepository) SetCopyCounts(ctx context.Context, id string, copies int, available int) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.gameIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	game := &r.store.games[idx]
+	game.Copies = copies
+	game.AvailableCopies = available
+	game.Available = available > 0
+	game.Version++
+	updated := *game
+
+	return &updated, nil
+}
+
+// DeleteGame removes a game and its copies from the store.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the game ID as a string and the expected version.
+// Returns an error if the operation fails.
+func (r *GameRepository) DeleteGame(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.gameIndex(i)
+	if idx < 0 {
+		return apperr.NotFound("game not found")
+	}
+	if stored := r.store.games[idx]; version != 0 && stored.Version != version {
+		return apperr.VersionMismatch("game", stored.Version, version)
+	}
+	r.store.games = append(r.store.games[:idx], r.store.games[idx+1:]...)
+	r.store.deleteCopies(func(gameID primitive.ObjectID) bool { return gameID == i })
+
+	return nil
+}
+
+// FindGamesByDeveloper retrieves all games by a developer from the store.
+// Takes a context for managing request lifetime and the developer name as a string.
+// Returns a slice of Game models or an error if the operation fails.
+func (r *GameRepository) FindGamesByDeveloper(ctx context.Context, developerName string) ([]model.Game, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var developer *model.Developer
+	for i := range r.store.developers {
+		if r.store.developers[i].Name == developerName {
+			developer = &r.store.developers[i]
+			break
+		}
+	}
+	if developer == nil {
+		return nil, apperr.NotFound("developer does not exist")
+	}
+
+	games := make([]model.Game, 0)
+	for _, g := range r.store.games {
+		if g.Developer.ID == developer.ID {
+			games = append(games, g)
+		}
+	}
+
+	return games, nil
+}
+
+// DeleteManyGamesByDeveloper removes all games by a developer and their copies from the store.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns an error if the operation fails.
+func (r *GameRepository) DeleteManyGamesByDeveloper(ctx context.Context, developerId string) error {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return err
+	}
+
+	defer r.store.lock(ctx)()
+
+	deleted := make(map[primitive.ObjectID]bool)
+	games := r.store.games[:0]
+	for _, g := range r.store.games {
+		if g.Developer.ID != id {
+			games = append(games, g)
+		} else {
+			deleted[g.ID] = true
+		}
+	}
+	r.store.games = games
+	r.store.deleteCopies(func(gameID primitive.ObjectID) bool { return deleted[gameID] })
+
+	return nil
+}
+
+// CountGamesByDeveloper counts the games by a developer in the store.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns the number of games or an error if the operation fails.
+func (r *GameRepository) CountGamesByDeveloper(ctx context.Context, developerId string) (int64, error) {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, g := range r.store.games {
+		if g.Developer.ID == id {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ReassignGames moves all games by a developer to another developer in the store.
+// Takes a context for managing request lifetime, the current developer ID as a string, and the new Developer model.
+// Returns an error if the operation fails.
+func (r *GameRepository) ReassignGames(ctx context.Context, developerId string, developer model.Developer) error {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return err
+	}
+
+	defer r.store.lock(ctx)()
+
+	for i := range r.store.games {
+		if r.store.games[i].Developer.ID == id {
+			r.store.games[i].Developer = developer
+			r.store.games[i].Version++
+		}
+	}
+
+	return nil
+}
+
+// UpdateGamesDeveloper rewrites the embedded developer of every game that references it
+// and whose copy differs from the given Developer model, bumping the version of each game it changes.
+// Takes a context for managing request lifetime and the current Developer model.
+// Returns the number of games changed or an error if the operation fails.
+func (r *GameRepository) UpdateGamesDeveloper(ctx context.Context, developer model.Developer) (int64, error) {
+	defer r.store.lock(ctx)()
+
+	var changed int64
+	for i := range r.store.games {
+		if r.store.games[i].Developer.ID == developer.ID && r.store.games[i].Developer != developer {
+			r.store.games[i].Developer = developer
+			r.store.games[i].Version++
+			changed++
+		}
+	}
+
+	return changed, nil
+}
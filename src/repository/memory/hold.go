@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type HoldRepository struct {
+	store *Store
+}
+
+// NewHoldRepository creates a new in-memory HoldRepository instance.
+// Takes the Store shared with the other repositories.
+// Returns the HoldRepositorer interface.
+func NewHoldRepository(store *Store) _interface.HoldRepositorer {
+	return &HoldRepository{
+		store: store,
+	}
+}
+
+// ListHolds retrieves the holds matching the filter from the store in the order they were placed.
+// Takes a context for managing request lifetime and a HoldFilter.
+// Returns a slice of Hold models or an error if the operation fails.
+func (r *HoldRepository) ListHolds(ctx context.Context, filter model.HoldFilter) ([]model.Hold, error) {
+	var gameID, memberID primitive.ObjectID
+	if filter.GameID != "" {
+		id, err := model.ParseID(filter.GameID)
+		if err != nil {
+			return nil, err
+		}
+		gameID = id
+	}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		memberID = id
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	holds := make([]model.Hold, 0)
+	for _, h := range r.store.holds {
+		switch {
+		case !gameID.IsZero() && h.GameID != gameID:
+			continue
+		case !memberID.IsZero() && h.MemberID != memberID:
+			continue
+		case filter.Status != "" && h.Status != filter.Status:
+			continue
+		case !filter.ExpiredBy.IsZero() && (h.ExpiresAt == nil || h.ExpiresAt.After(filter.ExpiredBy)):
+			continue
+		}
+		holds = append(holds, h)
+	}
+
+	return holds, nil
+}
+
+// PageHolds retrieves one page of the holds matching the query from the store in the order they were placed.
+// Takes a context for managing request lifetime and a HoldQuery.
+// Returns a Page of Hold models or an error if the operation fails.
+func (r *HoldRepository) PageHolds(ctx context.Context, query model.HoldQuery) (*model.Page[model.Hold], error) {
+	holds, err := r.ListHolds(ctx, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return pageByID(holds, query.Cursor, query.Limit, func(h model.Hold) primitive.ObjectID { return h.ID })
+}
+
+// GetHoldById retrieves a hold by its ID from the store.
+// Takes a context for managing request lifetime and the hold ID as a string.
+// Returns a Hold model or an error if the operation fails.
+func (r *HoldRepository) GetHoldById(ctx context.Context, id string) (*model.Hold, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := r.store.holdIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("hold not found")
+	}
+	hold := r.store.holds[idx]
+
+	return &hold, nil
+}
+
+// AddHold inserts a new hold into the store.
+// Takes a context for managing request lifetime and a Hold model.
+// Returns the inserted Hold model or an error if the operation fails.
+func (r *HoldRepository) AddHold(ctx context.Context, hold model.Hold) (*model.Hold, error) {
+	hold.ID = primitive.NewObjectID()
+	hold.Version = 1
+
+	defer r.store.lock(ctx)()
+
+	r.store.holds = append(r.store.holds, hold)
+
+	return &hold, nil
+}
+
+// UpdateHold replaces the status, reserved copy and times of a hold in the store.
+// A non-zero hold.Version must match the stored version.
+// Takes a context for managing request lifetime, the hold ID as a string, and a Hold model.
+// Returns the updated Hold model or an error if the operation fails.
+func (r *HoldRepository) UpdateHold(ctx context.Context, id string, hold model.Hold) (*model.Hold, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.holdIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("hold not found")
+	}
+	stored := &r.store.holds[idx]
+	if hold.Version != 0 && stored.Version != hold.Version {
+		return nil, apperr.VersionMismatch("hold", stored.Version, hold.Version)
+	}
+
+	stored.Status = hold.Status
+	stored.CopyID = hold.CopyID
+	stored.ReadyAt = hold.ReadyAt
+	stored.ExpiresAt = hold.ExpiresAt
+	stored.ClosedAt = hold.ClosedAt
+	stored.Version++
+	updated := *stored
+
+	return &updated, nil
+}
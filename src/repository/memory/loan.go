@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type LoanRepository struct {
+	store *Store
+}
+
+// NewLoanRepository creates a new in-memory LoanRepository instance.
+// Takes the Store shared with the other repositories.
+// Returns the LoanRepositorer interface.
+func NewLoanRepository(store *Store) _interface.LoanRepositorer {
+	return &LoanRepository{
+		store: store,
+	}
+}
+
+// ListLoans retrieves the loans matching the filter from the store, oldest first.
+// Takes a context for managing request lifetime and a LoanFilter.
+// Returns a slice of Loan models or an error if the operation fails.
+func (r *LoanRepository) ListLoans(ctx context.Context, filter model.LoanFilter) ([]model.Loan, error) {
+	var gameID, memberID primitive.ObjectID
+	if filter.GameID != "" {
+		id, err := model.ParseID(filter.GameID)
+		if err != nil {
+			return nil, err
+		}
+		gameID = id
+	}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, err
+		}
+		memberID = id
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	loans := make([]model.Loan, 0)
+	for _, l := range r.store.loans {
+		switch {
+		case !gameID.IsZero() && l.GameID != gameID:
+			continue
+		case !memberID.IsZero() && l.MemberID != memberID:
+			continue
+		case filter.Active != nil && *filter.Active != (l.ReturnedAt == nil):
+			continue
+		case filter.Overdue != nil && *filter.Overdue != l.Overdue:
+			continue
+		case !filter.DueBefore.IsZero() && !l.DueAt.Before(filter.DueBefore):
+			continue
+		}
+		loans = append(loans, l)
+	}
+
+	return loans, nil
+}
+
+// PageLoans retrieves one page of the loans matching the query from the store, oldest first.
+// Takes a context for managing request lifetime and a LoanQuery.
+// Returns a Page of Loan models or an error if the operation fails.
+func (r *LoanRepository) PageLoans(ctx context.Context, query model.LoanQuery) (*model.Page[model.Loan], error) {
+	loans, err := r.ListLoans(ctx, query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return pageByID(loans, query.Cursor, query.Limit, func(l model.Loan) primitive.ObjectID { return l.ID })
+}
+
+// GetLoanById retrieves a loan by its ID from the store.
+// Takes a context for managing request lifetime and the loan ID as a string.
+// Returns a Loan model or an error if the operation fails.
+func (r *LoanRepository) GetLoanById(ctx context.Context, id string) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := r.store.loanIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("loan not found")
+	}
+	loan := r.store.loans[idx]
+
+	return &loan, nil
+}
+
+// AddLoan inserts a new loan into the store.
+// Takes a context for managing request lifetime and a Loan model.
+// Returns the inserted Loan model or an error if the operation fails.
+func (r *LoanRepository) AddLoan(ctx context.Context, loan model.Loan) (*model.Loan, error) {
+	loan.ID = primitive.NewObjectID()
+
+	defer r.store.lock(ctx)()
+
+	r.store.loans = append(r.store.loans, loan)
+
+	return &loan, nil
+}
+
+// CloseLoan marks a loan as returned at the given time.
+// Takes a context for managing request lifetime, the loan ID as a string and the return time.
+// Returns the updated Loan model, or a Conflict error if the loan was already returned.
+func (r *LoanRepository) CloseLoan(ctx context.Context, id string, returnedAt time.Time) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.loanIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("loan not found")
+	}
+	if r.store.loans[idx].ReturnedAt != nil {
+		return nil, apperr.Conflict("loan is already returned")
+	}
+	r.store.loans[idx].ReturnedAt = &returnedAt
+	loan := r.store.loans[idx]
+
+	return &loan, nil
+}
+
+// MarkOverdue marks a loan as overdue. Marking a loan that is already overdue changes nothing.
+// Takes a context for managing request lifetime and the loan ID as a string.
+// Returns the updated Loan model or an error if the loan is missing.
+func (r *LoanRepository) MarkOverdue(ctx context.Context, id string) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.loanIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("loan not found")
+	}
+	r.store.loans[idx].Overdue = true
+	loan := r.store.loans[idx]
+
+	return &loan, nil
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"strings"
+)
+
+type MemberRepository struct {
+	store *Store
+}
+
+// NewMemberRepository creates a new in-memory MemberRepository instance.
+// Takes the Store shared with the other repositories.
+// Returns the MemberRepositorer interface.
+func NewMemberRepository(store *Store) _interface.MemberRepositorer {
+	return &MemberRepository{
+		store: store,
+	}
+}
+
+// ListMembers retrieves one page of members matching the query from the store.
+// Takes a context for managing request lifetime and a MemberQuery.
+// Returns a Page of Member models or an error if the operation fails.
+func (r *MemberRepository) ListMembers(ctx context.Context, query model.MemberQuery) (*model.Page[model.Member], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "name")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+	substring := strings.ToLower(query.Filter.NameSubstring)
+
+	r.store.mu.RLock()
+	members := make([]model.Member, 0)
+	for _, m := range r.store.members {
+		if query.Filter.Status != "" && m.Status != query.Filter.Status {
+			continue
+		}
+		if substring != "" && !strings.Contains(strings.ToLower(m.Name), substring) {
+			continue
+		}
+		members = append(members, m)
+	}
+	r.store.mu.RUnlock()
+
+	compare := func(a, b model.Member) int {
+		return compareMembers(a, b, field, desc)
+	}
+	slices.SortFunc(members, compare)
+	page := &model.Page[model.Member]{Total: int64(len(members))}
+
+	if cursor != nil {
+		id, err := primitive.ObjectIDFromHex(cursor.ID)
+		if err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		last := model.Member{ID: id, Name: cursor.Str}
+		start, _ := slices.BinarySearchFunc(members, last, compare)
+		for start < len(members) && compare(members[start], last) <= 0 {
+			start++
+		}
+		members = members[start:]
+	}
+
+	page.Items = members
+	if len(members) > limit {
+		page.Items = members[:limit]
+		last := page.Items[limit-1]
+		page.Next = paging.Encode(paging.Cursor{Sort: query.Sort, Str: last.Name, ID: last.ID.Hex()})
+	}
+
+	return page, nil
+}
+
+// compareMembers orders members by name when sorting by name and then by ID, matching the Mongo backend.
+func compareMembers(a, b model.Member, field string, desc bool) int {
+	c := 0
+	if field == "name" {
+		c = strings.Compare(a.Name, b.Name)
+	}
+	if desc {
+		c = -c
+	}
+	if c == 0 {
+		c = bytes.Compare(a.ID[:], b.ID[:])
+		if field == "" && desc {
+			c = -c
+		}
+	}
+	return c
+}
+
+// GetMemberById retrieves a member by their ID from the store.
+// Takes a context for managing request lifetime and the member ID as a string.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberById(ctx context.Context, id string) (*model.Member, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.find(func(m model.Member) bool { return m.ID == i })
+}
+
+// GetMemberByEmail retrieves the member with the given email address from the store.
+// Takes a context for managing request lifetime and the email address as stored.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberByEmail(ctx context.Context, email string) (*model.Member, error) {
+	return r.find(func(m model.Member) bool { return m.Email == email })
+}
+
+// GetMemberByCardNumber retrieves the member with the given library card number from the store.
+// Takes a context for managing request lifetime and the card number.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error) {
+	return r.find(func(m model.Member) bool { return m.CardNumber == cardNumber })
+}
+
+// find returns a copy of the first member matching match, or a NotFound error if there is none.
+func (r *MemberRepository) find(match func(model.Member) bool) (*model.Member, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	idx := slices.IndexFunc(r.store.members, match)
+	if idx < 0 {
+		return nil, apperr.NotFound("member not found")
+	}
+	member := r.store.members[idx]
+
+	return &member, nil
+}
+
+// AddMember inserts a new member into the store.
+// Takes a context for managing request lifetime and a Member model.
+// Returns the inserted Member model or an error if the operation fails.
+func (r *MemberRepository) AddMember(ctx context.Context, member model.Member) (*model.Member, error) {
+	member.ID = primitive.NewObjectID()
+	member.Version = 1
+
+	defer r.store.lock(ctx)()
+
+	r.store.members = append(r.store.members, member)
+
+	return &member, nil
+}
+
+// UpdateMember updates an existing member in the store.
+// A non-zero member.Version must match the stored version.
+// Takes a context for managing request lifetime, the member ID as a string, and a Member model.
+// Returns the updated Member model or an error if the operation fails.
+func (r *MemberRepository) UpdateMember(ctx context.Context, id string, member model.Member) (*model.Member, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	member.ID = i
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.memberIndex(i)
+	if idx < 0 {
+		return nil, apperr.NotFound("member not found")
+	}
+	stored := r.store.members[idx]
+	if member.Version != 0 && stored.Version != member.Version {
+		return nil, apperr.VersionMismatch("member", stored.Version, member.Version)
+	}
+	member.Version = stored.Version + 1
+	r.store.members[idx] = member
+
+	return &member, nil
+}
+
+// DeleteMember removes a member from the store by their ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the member ID as a string and the expected version.
+// Returns an error if the operation fails or if no member is found.
+func (r *MemberRepository) DeleteMember(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	defer r.store.lock(ctx)()
+
+	idx := r.store.memberIndex(i)
+	if idx < 0 {
+		return apperr.NotFound("member not found")
+	}
+	if stored := r.store.members[idx]; version != 0 && stored.Version != version {
+		return apperr.VersionMismatch("member", stored.Version, version)
+	}
+	r.store.members = append(r.store.members[:idx], r.store.members[idx+1:]...)
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package memory_test
+
+import (
+	"game-library-management-system/src/repository/memory"
+	"game-library-management-system/src/repository/repotest"
+	"testing"
+)
+
+// TestConformance runs the repository conformance suite against a fresh store for each test.
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := memory.NewStore()
+		return repotest.Repositories{
+			Games:      memory.NewGameRepository(store),
+			Developers: memory.NewDeveloperRepository(store),
+			Members:    memory.NewMemberRepository(store),
+			Loans:      memory.NewLoanRepository(store),
+			Copies:     memory.NewCopyRepository(store),
+			Holds:      memory.NewHoldRepository(store),
+			Fines:      memory.NewFineRepository(store),
+			Transactor: memory.NewTransactor(store),
+		}
+	})
+}
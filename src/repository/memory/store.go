@@ -0,0 +1,179 @@
+package memory
+
+import (
+	"bytes"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"sync"
+)
+
+// Store holds the in-memory state shared by the memory repositories.
+// Games and developers live side by side so that the game repository can
+// check developer existence the same way the Mongo one reads the developers collection.
+type Store struct {
+	// txMu is held by a running transaction and by writes made outside of one.
+	txMu sync.Mutex
+	mu   sync.RWMutex
+	data
+}
+
+// data is the content of a Store. It is copied as a whole to snapshot the store.
+type data struct {
+	developers []model.Developer
+	games      []model.Game
+	copies     []model.Copy
+	members    []model.Member
+	loans      []model.Loan
+	holds      []model.Hold
+	fines      []model.Fine
+}
+
+// NewStore creates a new empty Store.
+func NewStore() *Store {
+	return &Store{}
+}
+
+// snapshot returns a copy of the store content that restore can bring back.
+// Elements are copied by value, so later in-place updates do not affect the snapshot.
+// The caller must hold the lock.
+func (s *Store) snapshot() data {
+	return data{
+		developers: slices.Clone(s.developers),
+		games:      slices.Clone(s.games),
+		copies:     slices.Clone(s.copies),
+		members:    slices.Clone(s.members),
+		loans:      slices.Clone(s.loans),
+		holds:      slices.Clone(s.holds),
+		fines:      slices.Clone(s.fines),
+	}
+}
+
+// restore replaces the store content with a snapshot.
+// The caller must hold the lock.
+func (s *Store) restore(snapshot data) {
+	s.data = snapshot
+}
+
+// developerIndex returns the position of the developer with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) developerIndex(id primitive.ObjectID) int {
+	for i, d := range s.developers {
+		if d.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// gameIndex returns the position of the game with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) gameIndex(id primitive.ObjectID) int {
+	for i, g := range s.games {
+		if g.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// copyIndex returns the position of the copy with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) copyIndex(id primitive.ObjectID) int {
+	for i, c := range s.copies {
+		if c.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// deleteCopies removes the copies of the games for which deleted returns true.
+// The caller must hold the lock.
+func (s *Store) deleteCopies(deleted func(gameID primitive.ObjectID) bool) {
+	s.copies = slices.DeleteFunc(s.copies, func(c model.Copy) bool { return deleted(c.GameID) })
+}
+
+// memberIndex returns the position of the member with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) memberIndex(id primitive.ObjectID) int {
+	for i, m := range s.members {
+		if m.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// loanIndex returns the position of the loan with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) loanIndex(id primitive.ObjectID) int {
+	for i, l := range s.loans {
+		if l.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// holdIndex returns the position of the hold with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) holdIndex(id primitive.ObjectID) int {
+	for i, h := range s.holds {
+		if h.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// fineIndex returns the position of the fine with the given ID or -1.
+// The caller must hold the lock.
+func (s *Store) fineIndex(id primitive.ObjectID) int {
+	for i, f := range s.fines {
+		if f.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// pageByID returns the page of items that follows the cursor token in creation order, matching the Mongo backend.
+// items are sorted in place. id returns the ID of an item, which the cursor of the next page points at.
+func pageByID[T any](items []T, token string, limit int, id func(T) primitive.ObjectID) (*model.Page[T], error) {
+	cursor, err := paging.Decode(token, "")
+	if err != nil {
+		return nil, err
+	}
+	limit = paging.Limit(limit)
+
+	slices.SortFunc(items, func(a, b T) int {
+		idA, idB := id(a), id(b)
+		return bytes.Compare(idA[:], idB[:])
+	})
+	page := &model.Page[T]{Total: int64(len(items))}
+
+	if cursor != nil {
+		last, err := primitive.ObjectIDFromHex(cursor.ID)
+		if err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		start, found := slices.BinarySearchFunc(items, last, func(item T, last primitive.ObjectID) int {
+			i := id(item)
+			return bytes.Compare(i[:], last[:])
+		})
+		if found {
+			start++
+		}
+		items = items[start:]
+	}
+
+	page.Items = items
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.Next = paging.Encode(paging.Cursor{ID: id(page.Items[limit-1]).Hex()})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"game-library-management-system/src/interface"
+)
+
+type txKey struct{}
+
+type Transactor struct {
+	store *Store
+}
+
+// NewTransactor creates a new in-memory Transactor for the given Store.
+// Returns the Transactor interface.
+func NewTransactor(store *Store) _interface.Transactor {
+	return &Transactor{
+		store: store,
+	}
+}
+
+// WithinTransaction runs fn and restores the store to its previous state if fn returns an error.
+// Transactions are serialized with each other and with writes made outside a transaction,
+// so rolling one back cannot undo another write.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	t.store.txMu.Lock()
+	defer t.store.txMu.Unlock()
+
+	t.store.mu.RLock()
+	snapshot := t.store.snapshot()
+	t.store.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, t)); err != nil {
+		t.store.mu.Lock()
+		t.store.restore(snapshot)
+		t.store.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// lock locks the store for a write and returns the function that unlocks it.
+// A write outside a transaction first waits for the running transaction to end, as restoring
+// the transaction's snapshot would undo it.
+func (s *Store) lock(ctx context.Context) func() {
+	if ctx.Value(txKey{}) != nil {
+		s.mu.Lock()
+		return s.mu.Unlock
+	}
+	s.txMu.Lock()
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		s.txMu.Unlock()
+	}
+}
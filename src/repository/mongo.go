@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"time"
+)
+
+const (
+	connectAttempts   = 10
+	connectBackoff    = 500 * time.Millisecond
+	connectMaxBackoff = 5 * time.Second
+	pingTimeout       = 2 * time.Second
+)
+
+// Connect creates a MongoDB client for the given URI and verifies it with a ping.
+// The ping is retried with exponential backoff so the app can start before the database is ready.
+// Returns the connected client or the last ping error once all attempts are used up.
+func Connect(ctx context.Context, URI string) (*mongo.Client, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(URI))
+	if err != nil {
+		return nil, err
+	}
+
+	backoff := connectBackoff
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err = client.Ping(pingCtx, readpref.Primary())
+		cancel()
+		if err == nil {
+			return client, nil
+		}
+		if attempt == connectAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			_ = client.Disconnect(context.Background())
+			return nil, ctx.Err()
+		}
+		backoff = min(backoff*2, connectMaxBackoff)
+	}
+
+	_ = client.Disconnect(context.Background())
+	return nil, err
+}
+
+// afterCursor builds the condition selecting the documents that sort after the cursor.
+// Ties on the sort key are broken by _id, which always ascends.
+func afterCursor(field string, desc bool, cursor *paging.Cursor) (bson.M, error) {
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, paging.ErrInvalidCursor
+	}
+	op := "$gt"
+	if desc {
+		op = "$lt"
+	}
+	if field == "" {
+		return bson.M{"_id": bson.M{op: id}}, nil
+	}
+
+	var value any = cursor.Str
+	if field == "year" {
+		value = cursor.Num
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{"$gt": id}},
+	}}, nil
+}
+
+// sortOrder returns the sort document for a listing sorted by field, or by creation order if field is empty.
+// Ties are broken by ascending ID so that keyset pagination is stable.
+func sortOrder(field string, desc bool) bson.D {
+	order := 1
+	if desc {
+		order = -1
+	}
+	if field == "" {
+		return bson.D{{Key: "_id", Value: order}}
+	}
+	return bson.D{{Key: field, Value: order}, {Key: "_id", Value: 1}}
+}
+
+// findPage retrieves the page of documents matching filter that follows the cursor token, in creation order.
+// id returns the ID of a document, which the cursor of the next page points at.
+func findPage[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, token string, limit int, id func(T) primitive.ObjectID) (*model.Page[T], error) {
+	cursor, err := paging.Decode(token, "")
+	if err != nil {
+		return nil, err
+	}
+	limit = paging.Limit(limit)
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	pageFilter := filter
+	if cursor != nil {
+		after, err := afterCursor("", false, cursor)
+		if err != nil {
+			return nil, err
+		}
+		pageFilter = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	opts := options.Find().SetSort(sortOrder("", false)).SetLimit(int64(limit + 1))
+	result, err := collection.Find(ctx, pageFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]T, 0, limit+1)
+	if err := result.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[T]{Items: items, Total: total}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.Next = paging.Encode(paging.Cursor{ID: id(page.Items[limit-1]).Hex()})
+	}
+
+	return page, nil
+}
@@ -0,0 +1,39 @@
+package repository_test
+
+import (
+	"context"
+	"game-library-management-system/src/repository"
+	"game-library-management-system/src/repository/repotest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+	"testing"
+)
+
+// TestConformance runs the repository conformance suite against a fresh database for each test.
+// MongoDB only supports transactions on a replica set, so it needs one at MONGO_TEST_URI and is skipped without it.
+func TestConformance(t *testing.T) {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+	client, err := repository.Connect(context.Background(), uri)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db := client.Database("conformance_" + primitive.NewObjectID().Hex())
+		t.Cleanup(func() { db.Drop(context.Background()) })
+		return repotest.Repositories{
+			Games:      repository.NewGameRepository(db),
+			Developers: repository.NewDeveloperRepository(db),
+			Members:    repository.NewMemberRepository(db),
+			Loans:      repository.NewLoanRepository(db),
+			Copies:     repository.NewCopyRepository(db),
+			Holds:      repository.NewHoldRepository(db),
+			Fines:      repository.NewFineRepository(db),
+			Transactor: repository.NewTransactor(client),
+		}
+	})
+}
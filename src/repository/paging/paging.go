@@ -0,0 +1,72 @@
+// Package paging holds the cursor and sort handling shared by the repository backends.
+package paging
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"game-library-management-system/src/apperr"
+	"slices"
+	"strings"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded or belongs to a different sort order.
+var ErrInvalidCursor = apperr.Validation("invalid cursor")
+
+// Cursor marks the last item of a page in keyset pagination.
+// Str or Num hold the value of the sort field, depending on its type.
+type Cursor struct {
+	Sort string `json:"s,omitempty"`
+	Str  string `json:"v,omitempty"`
+	Num  int    `json:"n,omitempty"`
+	ID   string `json:"id"`
+}
+
+// Encode turns c into an opaque token for the client.
+func Encode(c Cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a token produced by Encode for the given sort.
+// Returns nil for an empty token, meaning the first page.
+func Decode(token, sort string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if c.Sort != sort {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort", ErrInvalidCursor)
+	}
+	return &c, nil
+}
+
+// ParseSort splits a sort parameter such as "-year" into the field name and direction.
+// An empty sort is allowed and means creation order. Fields outside allowed are rejected.
+func ParseSort(sort string, allowed ...string) (field string, desc bool, err error) {
+	field, desc = strings.CutPrefix(sort, "-")
+	if field != "" && !slices.Contains(allowed, field) {
+		return "", false, apperr.Validation("cannot sort by %q, use one of %s", field, strings.Join(allowed, ", "))
+	}
+	return field, desc, nil
+}
+
+// Limit clamps a requested page size to [1, MaxLimit], using DefaultLimit when none was given.
+func Limit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return min(limit, MaxLimit)
+}
@@ -0,0 +1,107 @@
+package paging_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/repository/paging"
+	"strings"
+	"testing"
+)
+
+// TestEncodeDecode checks that a cursor survives the round trip through its token.
+func TestEncodeDecode(t *testing.T) {
+	tests := []paging.Cursor{
+		{Sort: "title", Str: "The Witcher", ID: "64b7f0c2a1b2c3d4e5f60718"},
+		{Sort: "-year", Num: 2015, ID: "64b7f0c2a1b2c3d4e5f60718"},
+		{Sort: "year", Num: 0, ID: "64b7f0c2a1b2c3d4e5f60718"},
+		{Sort: "name", Str: "Ünïcödé / \"quoted\" + more?&=", ID: "64b7f0c2a1b2c3d4e5f60718"},
+		{ID: "64b7f0c2a1b2c3d4e5f60718"},
+	}
+	for _, want := range tests {
+		token := paging.Encode(want)
+		if strings.ContainsAny(token, "+/=") {
+			t.Errorf("token %q of %+v is not URL safe", token, want)
+		}
+		got, err := paging.Decode(token, want.Sort)
+		if err != nil {
+			t.Errorf("Decode(Encode(%+v)): %v", want, err)
+			continue
+		}
+		if *got != want {
+			t.Errorf("Decode(Encode(%+v)) = %+v", want, *got)
+		}
+	}
+
+	if c, err := paging.Decode("", "title"); c != nil || err != nil {
+		t.Errorf("Decode of an empty token = %v, %v, want the first page", c, err)
+	}
+}
+
+// TestDecodeInvalid checks that tokens that were not issued for the sort are rejected as invalid cursors.
+func TestDecodeInvalid(t *testing.T) {
+	token := paging.Encode(paging.Cursor{Sort: "title", Str: "The Witcher", ID: "64b7f0c2a1b2c3d4e5f60718"})
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name  string
+		token string
+		sort  string
+	}{
+		{"garbage", "garbage!", "title"},
+		{"padded", base64.URLEncoding.EncodeToString([]byte(`{"s":"title","id":"x"}`)), "title"},
+		{"truncated", token[:len(token)-3], "title"},
+		{"tampered", token[:5] + "~" + token[6:], "title"},
+		{"not json", raw("title|The Witcher"), "title"},
+		{"wrong types", raw(`{"s":"year","n":"2015","id":"x"}`), "year"},
+		{"without id", raw(`{"s":"title","v":"The Witcher"}`), "title"},
+		{"different sort", token, "-title"},
+		{"sort of the first page", token, ""},
+	}
+	for _, tt := range tests {
+		c, err := paging.Decode(tt.token, tt.sort)
+		if !errors.Is(err, paging.ErrInvalidCursor) || !errors.Is(err, apperr.ErrValidation) {
+			t.Errorf("%s: Decode = %v, %v, want an invalid cursor validation error", tt.name, c, err)
+		}
+	}
+}
+
+// TestParseSort checks the field and direction of sort parameters.
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		sort  string
+		field string
+		desc  bool
+		err   error
+	}{
+		{"", "", false, nil},
+		{"-", "", true, nil},
+		{"title", "title", false, nil},
+		{"-year", "year", true, nil},
+		{"developer", "", false, apperr.ErrValidation},
+		{"--title", "", false, apperr.ErrValidation},
+		{"Title", "", false, apperr.ErrValidation},
+	}
+	for _, tt := range tests {
+		field, desc, err := paging.ParseSort(tt.sort, "title", "year")
+		if !errors.Is(err, tt.err) || field != tt.field || desc != tt.desc {
+			t.Errorf("ParseSort(%q) = %q, %v, %v, want %q, %v, %v", tt.sort, field, desc, err, tt.field, tt.desc, tt.err)
+		}
+	}
+}
+
+// TestLimit checks that page sizes are clamped.
+func TestLimit(t *testing.T) {
+	tests := []struct{ limit, want int }{
+		{-1, paging.DefaultLimit},
+		{0, paging.DefaultLimit},
+		{1, 1},
+		{paging.MaxLimit, paging.MaxLimit},
+		{paging.MaxLimit + 1, paging.MaxLimit},
+	}
+	for _, tt := range tests {
+		if got := paging.Limit(tt.limit); got != tt.want {
+			t.Errorf("Limit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,2004 @@
+// Package repotest implements a conformance suite for the repository interfaces.
+// Every storage backend is expected to pass it so that all of them behave
+// like the MongoDB repositories in package repository.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Repositories bundles the repositories of one backend that share the same storage.
+type Repositories struct {
+	Games      _interface.GameRepositorer
+	Developers _interface.DeveloperRepositorer
+	Members    _interface.MemberRepositorer
+	Loans      _interface.LoanRepositorer
+	Copies     _interface.CopyRepositorer
+	Holds      _interface.HoldRepositorer
+	Fines      _interface.FineRepositorer
+	Transactor _interface.Transactor
+}
+
+// Factory returns fresh, empty repositories sharing the same storage.
+// It is called once per subtest; cleanup should be registered with t.Cleanup.
+type Factory func(t *testing.T) Repositories
+
+// Run runs the whole conformance suite against the repositories returned by newRepositories.
+func Run(t *testing.T, newRepositories Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repos Repositories)
+	}{
+		{"DeveloperIDGeneration", testDeveloperIDGeneration},
+		{"DeveloperNotFound", testDeveloperNotFound},
+		{"UpdateDeveloper", testUpdateDeveloper},
+		{"DeleteDeveloper", testDeleteDeveloper},
+		{"GameIDGeneration", testGameIDGeneration},
+		{"GameNotFound", testGameNotFound},
+		{"AddGameRequiresDeveloper", testAddGameRequiresDeveloper},
+		{"UpdateAvailabilityToggles", testUpdateAvailabilityToggles},
+		{"SetAvailability", testSetAvailability},
+		{"UpdateGame", testUpdateGame},
+		{"DeleteGame", testDeleteGame},
+		{"FindGamesByDeveloper", testFindGamesByDeveloper},
+		{"DeleteManyGamesByDeveloper", testDeleteManyGamesByDeveloper},
+		{"CountGamesByDeveloper", testCountGamesByDeveloper},
+		{"ReassignGames", testReassignGames},
+		{"UpdateGamesDeveloper", testUpdateGamesDeveloper},
+		{"ListGamesFilters", testListGamesFilters},
+		{"ListGamesPagination", testListGamesPagination},
+		{"PaginationDuplicateKeys", testPaginationDuplicateKeys},
+		{"ListDevelopers", testListDevelopers},
+		{"StreamGames", testStreamGames},
+		{"StreamDevelopers", testStreamDevelopers},
+		{"RestoreDeveloper", testRestoreDeveloper},
+		{"RestoreGame", testRestoreGame},
+		{"Members", testMembers},
+		{"UpdateMember", testUpdateMember},
+		{"ListMembers", testListMembers},
+		{"Copies", testCopies},
+		{"UpdateCopy", testUpdateCopy},
+		{"SetCopyCounts", testSetCopyCounts},
+		{"DeleteGameDeletesCopies", testDeleteGameDeletesCopies},
+		{"CloseLoan", testCloseLoan},
+		{"ListLoans", testListLoans},
+		{"PageLoans", testPageLoans},
+		{"MarkOverdue", testMarkOverdue},
+		{"Holds", testHolds},
+		{"ListHolds", testListHolds},
+		{"PageHolds", testPageHolds},
+		{"Fines", testFines},
+		{"ListFines", testListFines},
+		{"PageFines", testPageFines},
+		{"TransactionCommit", testTransactionCommit},
+		{"TransactionRollback", testTransactionRollback},
+		{"RollbackKeepsOtherWrites", testRollbackKeepsOtherWrites},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepositories(t))
+		})
+	}
+}
+
+// expectKind fails the test unless err is of the given apperr kind.
+func expectKind(t *testing.T, what string, err, kind error) {
+	t.Helper()
+	if !errors.Is(err, kind) {
+		t.Errorf("%s: error = %v, want %v", what, err, kind)
+	}
+}
+
+// mustAddDeveloper inserts a developer and fails the test on error.
+func mustAddDeveloper(t *testing.T, devs _interface.DeveloperRepositorer, name, hq string) *model.Developer {
+	t.Helper()
+	dev, err := devs.AddDeveloper(context.Background(), model.Developer{Name: name, MainHq: hq})
+	if err != nil {
+		t.Fatalf("AddDeveloper(%q): %v", name, err)
+	}
+	return dev
+}
+
+// mustAddGame inserts a game by the given developer and fails the test on error.
+func mustAddGame(t *testing.T, games _interface.GameRepositorer, dev *model.Developer, title string) *model.Game {
+	t.Helper()
+	game, err := games.AddGame(context.Background(), model.Game{
+		Title:           title,
+		Developer:       *dev,
+		Genre:           "RPG",
+		PublicationYear: 2015,
+		Available:       true,
+	})
+	if err != nil {
+		t.Fatalf("AddGame(%q): %v", title, err)
+	}
+	return game
+}
+
+func testDeveloperIDGeneration(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	preset := primitive.NewObjectID()
+	a, err := repos.Developers.AddDeveloper(ctx, model.Developer{ID: preset, Name: "CD Projekt Red", MainHq: "Warsaw"})
+	if err != nil {
+		t.Fatalf("AddDeveloper: %v", err)
+	}
+	b := mustAddDeveloper(t, repos.Developers, "FromSoftware", "Tokyo")
+
+	if a.ID.IsZero() || b.ID.IsZero() {
+		t.Fatalf("AddDeveloper returned zero IDs: %v, %v", a.ID, b.ID)
+	}
+	if a.ID == preset {
+		t.Errorf("AddDeveloper kept the caller supplied ID %v", preset)
+	}
+	if a.ID == b.ID {
+		t.Errorf("AddDeveloper returned the same ID twice: %v", a.ID)
+	}
+
+	got, err := repos.Developers.GetDeveloperById(ctx, a.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetDeveloperById: %v", err)
+	}
+	if *got != *a {
+		t.Errorf("GetDeveloperById = %+v, want %+v", *got, *a)
+	}
+
+	all, err := repos.Developers.GetAllDevelopers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDevelopers: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("GetAllDevelopers returned %d developers, want 2", len(all))
+	}
+}
+
+func testDeveloperNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	missing := primitive.NewObjectID().Hex()
+
+	_, err := repos.Developers.GetDeveloperById(ctx, missing)
+	expectKind(t, "GetDeveloperById of a missing developer", err, apperr.ErrNotFound)
+	_, err = repos.Developers.GetDeveloperById(ctx, "not-an-id")
+	expectKind(t, "GetDeveloperById of an invalid ID", err, apperr.ErrInvalidID)
+	_, err = repos.Developers.UpdateDeveloper(ctx, missing, model.Developer{Name: "x"})
+	expectKind(t, "UpdateDeveloper of a missing developer", err, apperr.ErrNotFound)
+	err = repos.Developers.DeleteDeveloper(ctx, missing, 0)
+	expectKind(t, "DeleteDeveloper of a missing developer", err, apperr.ErrNotFound)
+
+	all, err := repos.Developers.GetAllDevelopers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDevelopers: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("GetAllDevelopers on empty storage returned %d developers", len(all))
+	}
+}
+
+func testUpdateDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Bioware", "Edmonton")
+
+	if dev.Version != 1 {
+		t.Fatalf("AddDeveloper returned version %d, want 1", dev.Version)
+	}
+
+	want := model.Developer{ID: dev.ID, Name: "BioWare", MainHq: "Austin", Version: 2}
+	updated, err := repos.Developers.UpdateDeveloper(ctx, dev.ID.Hex(), model.Developer{Name: "BioWare", MainHq: "Austin", Version: 1})
+	if err != nil {
+		t.Fatalf("UpdateDeveloper: %v", err)
+	}
+	if updated == nil || *updated != want {
+		t.Errorf("UpdateDeveloper returned %+v, want %+v", updated, want)
+	}
+
+	_, err = repos.Developers.UpdateDeveloper(ctx, dev.ID.Hex(), model.Developer{Name: "Bioware", MainHq: "Edmonton", Version: 1})
+	expectKind(t, "UpdateDeveloper with a stale version", err, apperr.ErrVersionMismatch)
+
+	got, err := repos.Developers.GetDeveloperById(ctx, dev.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetDeveloperById: %v", err)
+	}
+	if *got != want {
+		t.Errorf("GetDeveloperById after update = %+v, want %+v", *got, want)
+	}
+}
+
+func testDeleteDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	other := mustAddDeveloper(t, repos.Developers, "Remedy", "Espoo")
+
+	err := repos.Developers.DeleteDeveloper(ctx, dev.ID.Hex(), 2)
+	expectKind(t, "DeleteDeveloper with a stale version", err, apperr.ErrVersionMismatch)
+	if err := repos.Developers.DeleteDeveloper(ctx, dev.ID.Hex(), 1); err != nil {
+		t.Fatalf("DeleteDeveloper: %v", err)
+	}
+	if _, err := repos.Developers.GetDeveloperById(ctx, dev.ID.Hex()); err == nil {
+		t.Error("GetDeveloperById of a deleted developer returned no error")
+	}
+	if err := repos.Developers.DeleteDeveloper(ctx, dev.ID.Hex(), 0); err == nil {
+		t.Error("deleting a developer twice returned no error")
+	}
+	if _, err := repos.Developers.GetDeveloperById(ctx, other.ID.Hex()); err != nil {
+		t.Errorf("DeleteDeveloper removed an unrelated developer: %v", err)
+	}
+}
+
+func testGameIDGeneration(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "CD Projekt Red", "Warsaw")
+
+	a := mustAddGame(t, repos.Games, dev, "The Witcher 3")
+	b := mustAddGame(t, repos.Games, dev, "Cyberpunk 2077")
+
+	if a.ID.IsZero() || b.ID.IsZero() {
+		t.Fatalf("AddGame returned zero IDs: %v, %v", a.ID, b.ID)
+	}
+	if a.ID == b.ID {
+		t.Errorf("AddGame returned the same ID twice: %v", a.ID)
+	}
+
+	got, err := repos.Games.GetGameById(ctx, a.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if *got != *a {
+		t.Errorf("GetGameById = %+v, want %+v", *got, *a)
+	}
+
+	all, err := repos.Games.GetAllGames(ctx)
+	if err != nil {
+		t.Fatalf("GetAllGames: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("GetAllGames returned %d games, want 2", len(all))
+	}
+}
+
+func testGameNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	missing := primitive.NewObjectID().Hex()
+
+	_, err := repos.Games.GetGameById(ctx, missing)
+	expectKind(t, "GetGameById of a missing game", err, apperr.ErrNotFound)
+	_, err = repos.Games.GetGameById(ctx, "not-an-id")
+	expectKind(t, "GetGameById of an invalid ID", err, apperr.ErrInvalidID)
+	_, err = repos.Games.UpdateAvailability(ctx, missing)
+	expectKind(t, "UpdateAvailability of a missing game", err, apperr.ErrNotFound)
+	err = repos.Games.DeleteGame(ctx, missing, 0)
+	expectKind(t, "DeleteGame of a missing game", err, apperr.ErrNotFound)
+	_, err = repos.Games.FindGamesByDeveloper(ctx, "Nobody")
+	expectKind(t, "FindGamesByDeveloper of a missing developer", err, apperr.ErrNotFound)
+
+	all, err := repos.Games.GetAllGames(ctx)
+	if err != nil {
+		t.Fatalf("GetAllGames: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("GetAllGames on empty storage returned %d games", len(all))
+	}
+}
+
+func testAddGameRequiresDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	_, err := repos.Games.AddGame(ctx, model.Game{
+		Title:     "Orphan",
+		Developer: model.Developer{ID: primitive.NewObjectID(), Name: "Ghost"},
+	})
+	expectKind(t, "AddGame with a missing developer", err, apperr.ErrValidation)
+
+	all, err := repos.Games.GetAllGames(ctx)
+	if err != nil {
+		t.Fatalf("GetAllGames: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("AddGame with a missing developer stored %d games", len(all))
+	}
+}
+
+func testUpdateAvailabilityToggles(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	game := mustAddGame(t, repos.Games, dev, "Zelda")
+
+	for i, want := range []bool{false, true, false} {
+		updated, err := repos.Games.UpdateAvailability(ctx, game.ID.Hex())
+		if err != nil {
+			t.Fatalf("UpdateAvailability #%d: %v", i+1, err)
+		}
+		if updated == nil || updated.ID != game.ID || updated.Available != want {
+			t.Errorf("UpdateAvailability #%d returned %+v, want Available = %v", i+1, updated, want)
+		}
+		got, err := repos.Games.GetGameById(ctx, game.ID.Hex())
+		if err != nil {
+			t.Fatalf("GetGameById: %v", err)
+		}
+		if got.Available != want {
+			t.Errorf("after %d toggles Available = %v, want %v", i+1, got.Available, want)
+		}
+	}
+}
+
+func testSetAvailability(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	game := mustAddGame(t, repos.Games, dev, "Pikmin")
+	if game.Version != 1 {
+		t.Fatalf("AddGame returned version %d, want 1", game.Version)
+	}
+
+	steps := []struct {
+		available   bool
+		version     int64
+		wantVersion int64
+	}{
+		{available: false, version: 1, wantVersion: 2},
+		{available: false, version: 0, wantVersion: 2},
+		{available: false, version: 2, wantVersion: 2},
+		{available: true, version: 0, wantVersion: 3},
+	}
+	for i, step := range steps {
+		updated, err := repos.Games.SetAvailability(ctx, game.ID.Hex(), step.available, step.version)
+		if err != nil {
+			t.Fatalf("SetAvailability #%d: %v", i+1, err)
+		}
+		if updated.Available != step.available || updated.Version != step.wantVersion {
+			t.Errorf("SetAvailability #%d returned Available = %v, Version = %d, want %v, %d",
+				i+1, updated.Available, updated.Version, step.available, step.wantVersion)
+		}
+	}
+
+	_, err := repos.Games.SetAvailability(ctx, game.ID.Hex(), false, 2)
+	expectKind(t, "SetAvailability with a stale version", err, apperr.ErrConflict)
+	_, err = repos.Games.SetAvailability(ctx, game.ID.Hex(), true, 2)
+	expectKind(t, "SetAvailability to the current value with a stale version", err, apperr.ErrConflict)
+	_, err = repos.Games.SetAvailability(ctx, primitive.NewObjectID().Hex(), true, 0)
+	expectKind(t, "SetAvailability of a missing game", err, apperr.ErrNotFound)
+
+	got, err := repos.Games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if !got.Available || got.Version != 3 {
+		t.Errorf("after rejected writes Available = %v, Version = %d, want true, 3", got.Available, got.Version)
+	}
+}
+
+func testUpdateGame(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	nintendo := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	retro := mustAddDeveloper(t, repos.Developers, "Retro Studios", "Austin")
+	game := mustAddGame(t, repos.Games, nintendo, "Metroid Prime")
+
+	replacement := model.Game{
+		Title:           "Metroid Prime Remastered",
+		Developer:       model.Developer{ID: retro.ID},
+		Genre:           "Action",
+		PublicationYear: 2023,
+		Available:       false,
+		Version:         1,
+	}
+	updated, err := repos.Games.UpdateGame(ctx, game.ID.Hex(), replacement)
+	if err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	want := replacement
+	want.ID = game.ID
+	want.Developer = *retro
+	want.Version = 2
+	if *updated != want {
+		t.Errorf("UpdateGame returned %+v, want %+v", *updated, want)
+	}
+	got, err := repos.Games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if *got != want {
+		t.Errorf("after UpdateGame GetGameById = %+v, want %+v", *got, want)
+	}
+
+	_, err = repos.Games.UpdateGame(ctx, game.ID.Hex(), replacement)
+	expectKind(t, "UpdateGame with a stale version", err, apperr.ErrConflict)
+	replacement.Version = 0
+	replacement.Developer.ID = primitive.NewObjectID()
+	_, err = repos.Games.UpdateGame(ctx, game.ID.Hex(), replacement)
+	expectKind(t, "UpdateGame with a missing developer", err, apperr.ErrValidation)
+	replacement.Developer.ID = retro.ID
+	_, err = repos.Games.UpdateGame(ctx, primitive.NewObjectID().Hex(), replacement)
+	expectKind(t, "UpdateGame of a missing game", err, apperr.ErrNotFound)
+
+	got, err = repos.Games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if *got != want {
+		t.Errorf("rejected UpdateGame calls changed the game to %+v", *got)
+	}
+}
+
+func testDeleteGame(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	game := mustAddGame(t, repos.Games, dev, "Metroid")
+	other := mustAddGame(t, repos.Games, dev, "Kirby")
+
+	err := repos.Games.DeleteGame(ctx, game.ID.Hex(), 2)
+	expectKind(t, "DeleteGame with a stale version", err, apperr.ErrVersionMismatch)
+	if err := repos.Games.DeleteGame(ctx, game.ID.Hex(), 1); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	if _, err := repos.Games.GetGameById(ctx, game.ID.Hex()); err == nil {
+		t.Error("GetGameById of a deleted game returned no error")
+	}
+	if _, err := repos.Games.GetGameById(ctx, other.ID.Hex()); err != nil {
+		t.Errorf("DeleteGame removed an unrelated game: %v", err)
+	}
+	expectKind(t, "DeleteGame of an invalid ID", repos.Games.DeleteGame(ctx, "not-an-id", 0), apperr.ErrInvalidID)
+}
+
+func testFindGamesByDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	cdpr := mustAddDeveloper(t, repos.Developers, "CD Projekt Red", "Warsaw")
+	valve := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	idle := mustAddDeveloper(t, repos.Developers, "Idle Studio", "Nowhere")
+	mustAddGame(t, repos.Games, cdpr, "The Witcher 3")
+	mustAddGame(t, repos.Games, cdpr, "Cyberpunk 2077")
+	mustAddGame(t, repos.Games, valve, "Half-Life")
+
+	got, err := repos.Games.FindGamesByDeveloper(ctx, cdpr.Name)
+	if err != nil {
+		t.Fatalf("FindGamesByDeveloper: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("FindGamesByDeveloper returned %d games, want 2", len(got))
+	}
+	for _, g := range got {
+		if g.Developer.ID != cdpr.ID {
+			t.Errorf("FindGamesByDeveloper returned %q by developer %v", g.Title, g.Developer.ID)
+		}
+	}
+
+	got, err = repos.Games.FindGamesByDeveloper(ctx, idle.Name)
+	if err != nil {
+		t.Fatalf("FindGamesByDeveloper for a developer without games: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("FindGamesByDeveloper for a developer without games returned %d games", len(got))
+	}
+}
+
+func testDeleteManyGamesByDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	cdpr := mustAddDeveloper(t, repos.Developers, "CD Projekt Red", "Warsaw")
+	valve := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	mustAddGame(t, repos.Games, cdpr, "The Witcher 3")
+	mustAddGame(t, repos.Games, cdpr, "Cyberpunk 2077")
+	kept := mustAddGame(t, repos.Games, valve, "Half-Life")
+
+	if err := repos.Games.DeleteManyGamesByDeveloper(ctx, cdpr.ID.Hex()); err != nil {
+		t.Fatalf("DeleteManyGamesByDeveloper: %v", err)
+	}
+
+	all, err := repos.Games.GetAllGames(ctx)
+	if err != nil {
+		t.Fatalf("GetAllGames: %v", err)
+	}
+	if len(all) != 1 || all[0].ID != kept.ID {
+		t.Errorf("GetAllGames after cascade = %+v, want only %q", all, kept.Title)
+	}
+	if _, err := repos.Developers.GetDeveloperById(ctx, cdpr.ID.Hex()); err != nil {
+		t.Errorf("DeleteManyGamesByDeveloper removed the developer: %v", err)
+	}
+	expectKind(t, "DeleteManyGamesByDeveloper of an invalid ID", repos.Games.DeleteManyGamesByDeveloper(ctx, "not-an-id"), apperr.ErrInvalidID)
+}
+
+func testCountGamesByDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	cdpr := mustAddDeveloper(t, repos.Developers, "CD Projekt Red", "Warsaw")
+	idle := mustAddDeveloper(t, repos.Developers, "Idle Studio", "Nowhere")
+	mustAddGame(t, repos.Games, cdpr, "The Witcher 3")
+	mustAddGame(t, repos.Games, cdpr, "Cyberpunk 2077")
+
+	for _, tc := range []struct {
+		dev  *model.Developer
+		want int64
+	}{{cdpr, 2}, {idle, 0}} {
+		got, err := repos.Games.CountGamesByDeveloper(ctx, tc.dev.ID.Hex())
+		if err != nil {
+			t.Fatalf("CountGamesByDeveloper(%q): %v", tc.dev.Name, err)
+		}
+		if got != tc.want {
+			t.Errorf("CountGamesByDeveloper(%q) = %d, want %d", tc.dev.Name, got, tc.want)
+		}
+	}
+}
+
+func testReassignGames(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	from := mustAddDeveloper(t, repos.Developers, "Bungie", "Bellevue")
+	to := mustAddDeveloper(t, repos.Developers, "343 Industries", "Redmond")
+	game := mustAddGame(t, repos.Games, from, "Halo")
+
+	if err := repos.Games.ReassignGames(ctx, from.ID.Hex(), *to); err != nil {
+		t.Fatalf("ReassignGames: %v", err)
+	}
+
+	got, err := repos.Games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if got.Developer != *to {
+		t.Errorf("game developer after reassign = %+v, want %+v", got.Developer, *to)
+	}
+	if n, _ := repos.Games.CountGamesByDeveloper(ctx, from.ID.Hex()); n != 0 {
+		t.Errorf("old developer still has %d games after reassign", n)
+	}
+}
+
+func testUpdateGamesDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Bioware", "Edmonton")
+	other := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	game := mustAddGame(t, repos.Games, dev, "Mass Effect")
+	untouched := mustAddGame(t, repos.Games, other, "Half-Life")
+
+	stored, err := repos.Developers.UpdateDeveloper(ctx, dev.ID.Hex(), model.Developer{Name: "BioWare", MainHq: "Austin"})
+	if err != nil {
+		t.Fatalf("UpdateDeveloper: %v", err)
+	}
+	renamed := *stored
+	if _, err := repos.Games.UpdateGamesDeveloper(ctx, renamed); err != nil {
+		t.Fatalf("UpdateGamesDeveloper: %v", err)
+	}
+	changed, err := repos.Games.UpdateGamesDeveloper(ctx, renamed)
+	if err != nil {
+		t.Fatalf("UpdateGamesDeveloper: %v", err)
+	}
+	if changed != 0 {
+		t.Errorf("second UpdateGamesDeveloper changed %d games, want 0", changed)
+	}
+
+	got, err := repos.Games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if got.Developer != renamed {
+		t.Errorf("game developer = %+v, want %+v", got.Developer, renamed)
+	}
+	if got.Version != game.Version+1 {
+		t.Errorf("game version after its developer changed = %d, want %d", got.Version, game.Version+1)
+	}
+	got, err = repos.Games.GetGameById(ctx, untouched.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if got.Developer != *other {
+		t.Errorf("unrelated game developer = %+v, want %+v", got.Developer, *other)
+	}
+	if got.Version != untouched.Version {
+		t.Errorf("unrelated game version = %d, want %d", got.Version, untouched.Version)
+	}
+}
+
+// addCatalogue inserts a small catalogue used by the listing tests.
+func addCatalogue(t *testing.T, repos Repositories) (cdpr, valve *model.Developer) {
+	t.Helper()
+	ctx := context.Background()
+	cdpr = mustAddDeveloper(t, repos.Developers, "CD Projekt Red", "Warsaw")
+	valve = mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	for _, g := range []model.Game{
+		{Title: "The Witcher", Developer: *cdpr, Genre: "RPG", PublicationYear: 2007, Available: true},
+		{Title: "The Witcher 2", Developer: *cdpr, Genre: "RPG", PublicationYear: 2011, Available: false},
+		{Title: "The Witcher 3", Developer: *cdpr, Genre: "RPG", PublicationYear: 2015, Available: true},
+		{Title: "Cyberpunk 2077", Developer: *cdpr, Genre: "RPG", PublicationYear: 2020, Available: true},
+		{Title: "Half-Life", Developer: *valve, Genre: "Shooter", PublicationYear: 1998, Available: true},
+		{Title: "Half-Life 2", Developer: *valve, Genre: "Shooter", PublicationYear: 2004, Available: false},
+		{Title: "Portal", Developer: *valve, Genre: "Puzzle", PublicationYear: 2007, Available: true},
+	} {
+		if _, err := repos.Games.AddGame(ctx, g); err != nil {
+			t.Fatalf("AddGame(%q): %v", g.Title, err)
+		}
+	}
+	return cdpr, valve
+}
+
+// titles returns the titles of games in order.
+func titles(games []model.Game) []string {
+	out := make([]string, len(games))
+	for i, g := range games {
+		out[i] = g.Title
+	}
+	return out
+}
+
+func testListGamesFilters(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	_, valve := addCatalogue(t, repos)
+	available := true
+
+	tests := []struct {
+		name   string
+		filter model.GameFilter
+		want   []string
+	}{
+		{"genre", model.GameFilter{Genre: "Shooter"}, []string{"Half-Life", "Half-Life 2"}},
+		{"year range", model.GameFilter{YearFrom: 2007, YearTo: 2011}, []string{"Portal", "The Witcher", "The Witcher 2"}},
+		{"available", model.GameFilter{Available: &available, Genre: "RPG"}, []string{"Cyberpunk 2077", "The Witcher", "The Witcher 3"}},
+		{"developer", model.GameFilter{DeveloperID: valve.ID.Hex()}, []string{"Half-Life", "Half-Life 2", "Portal"}},
+		{"title prefix", model.GameFilter{TitlePrefix: "the witcher"}, []string{"The Witcher", "The Witcher 2", "The Witcher 3"}},
+		{"title prefix is literal", model.GameFilter{TitlePrefix: "Half_"}, []string{}},
+	}
+	for _, tt := range tests {
+		page, err := repos.Games.ListGames(ctx, model.GameQuery{Filter: tt.filter, Sort: "title"})
+		if err != nil {
+			t.Fatalf("%s: ListGames: %v", tt.name, err)
+		}
+		if got := titles(page.Items); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: ListGames = %q, want %q", tt.name, got, tt.want)
+		}
+		if page.Total != int64(len(tt.want)) {
+			t.Errorf("%s: Total = %d, want %d", tt.name, page.Total, len(tt.want))
+		}
+		if page.Next != "" {
+			t.Errorf("%s: single page has Next = %q", tt.name, page.Next)
+		}
+	}
+
+	_, err := repos.Games.ListGames(ctx, model.GameQuery{Sort: "developer"})
+	expectKind(t, "ListGames with an unknown sort field", err, apperr.ErrValidation)
+	_, err = repos.Games.ListGames(ctx, model.GameQuery{Cursor: "garbage"})
+	expectKind(t, "ListGames with an invalid cursor", err, apperr.ErrValidation)
+}
+
+func testListGamesPagination(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	addCatalogue(t, repos)
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"", []string{"The Witcher", "The Witcher 2", "The Witcher 3", "Cyberpunk 2077", "Half-Life", "Half-Life 2", "Portal"}},
+		{"-", []string{"Portal", "Half-Life 2", "Half-Life", "Cyberpunk 2077", "The Witcher 3", "The Witcher 2", "The Witcher"}},
+		{"title", []string{"Cyberpunk 2077", "Half-Life", "Half-Life 2", "Portal", "The Witcher", "The Witcher 2", "The Witcher 3"}},
+		{"-year", []string{"Cyberpunk 2077", "The Witcher 3", "The Witcher 2", "The Witcher", "Portal", "Half-Life 2", "Half-Life"}},
+		{"genre", []string{"Portal", "The Witcher", "The Witcher 2", "The Witcher 3", "Cyberpunk 2077", "Half-Life", "Half-Life 2"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		query := model.GameQuery{Sort: tt.sort, Limit: 2}
+		for pages := 0; ; pages++ {
+			if pages > len(tt.want) {
+				t.Fatalf("sort %q: pagination does not terminate", tt.sort)
+			}
+			page, err := repos.Games.ListGames(ctx, query)
+			if err != nil {
+				t.Fatalf("sort %q: ListGames: %v", tt.sort, err)
+			}
+			if page.Total != int64(len(tt.want)) {
+				t.Errorf("sort %q: Total = %d, want %d", tt.sort, page.Total, len(tt.want))
+			}
+			got = append(got, titles(page.Items)...)
+			if page.Next == "" {
+				break
+			}
+			query.Cursor = page.Next
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sort %q: pages = %q, want %q", tt.sort, got, tt.want)
+		}
+	}
+
+	first, err := repos.Games.ListGames(ctx, model.GameQuery{Sort: "title", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListGames: %v", err)
+	}
+	_, err = repos.Games.ListGames(ctx, model.GameQuery{Sort: "year", Cursor: first.Next})
+	expectKind(t, "ListGames with a cursor issued for a different sort", err, apperr.ErrValidation)
+}
+
+// testPaginationDuplicateKeys pages through games that share their sort values with every page size,
+// so page boundaries fall inside runs of equal keys. Equal keys are ordered by creation in both directions.
+func testPaginationDuplicateKeys(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "CD Projekt Red", "Warsaw")
+	var created []model.Game
+	for i, title := range []string{"Gwent", "Witcher", "Gwent", "Thronebreaker", "Witcher", "Gwent", "Thronebreaker"} {
+		g, err := repos.Games.AddGame(ctx, model.Game{Title: title, Developer: *dev, Genre: "RPG", PublicationYear: 2015 + i%2})
+		if err != nil {
+			t.Fatalf("AddGame(%q): %v", title, err)
+		}
+		created = append(created, *g)
+	}
+
+	for _, sort := range []string{"title", "-title", "genre", "-genre", "year", "-year"} {
+		field, desc := strings.CutPrefix(sort, "-")
+		key := func(g model.Game) string {
+			switch field {
+			case "title":
+				return g.Title
+			case "year":
+				return fmt.Sprint(g.PublicationYear)
+			}
+			return g.Genre
+		}
+		want := slices.Clone(created)
+		slices.SortStableFunc(want, func(a, b model.Game) int {
+			if desc {
+				return strings.Compare(key(b), key(a))
+			}
+			return strings.Compare(key(a), key(b))
+		})
+
+		for limit := 1; limit <= len(created); limit++ {
+			var got []primitive.ObjectID
+			query := model.GameQuery{Sort: sort, Limit: limit}
+			for pages := 0; ; pages++ {
+				if pages > len(created) {
+					t.Fatalf("sort %q by %d: pagination does not terminate", sort, limit)
+				}
+				page, err := repos.Games.ListGames(ctx, query)
+				if err != nil {
+					t.Fatalf("sort %q by %d: ListGames: %v", sort, limit, err)
+				}
+				for _, g := range page.Items {
+					got = append(got, g.ID)
+				}
+				if page.Next == "" {
+					break
+				}
+				query.Cursor = page.Next
+			}
+			wantIDs := make([]primitive.ObjectID, len(want))
+			for i, g := range want {
+				wantIDs[i] = g.ID
+			}
+			if !slices.Equal(got, wantIDs) {
+				t.Errorf("sort %q by %d: pages = %v, want %v", sort, limit, got, wantIDs)
+			}
+		}
+	}
+}
+
+func testListDevelopers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	for _, d := range []struct{ name, hq string }{
+		{"Ubisoft Montreal", "Montreal"},
+		{"Eidos Montreal", "Montreal"},
+		{"Ubisoft Quebec", "Quebec"},
+		{"Valve", "Bellevue"},
+		{"Bungie", "Bellevue"},
+	} {
+		mustAddDeveloper(t, repos.Developers, d.name, d.hq)
+	}
+
+	names := func(devs []model.Developer) []string {
+		out := make([]string, len(devs))
+		for i, d := range devs {
+			out[i] = d.Name
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		query model.DeveloperQuery
+		want  []string
+	}{
+		{"hq", model.DeveloperQuery{Filter: model.DeveloperFilter{MainHq: "Montreal"}, Sort: "name"}, []string{"Eidos Montreal", "Ubisoft Montreal"}},
+		{"name substring", model.DeveloperQuery{Filter: model.DeveloperFilter{NameSubstring: "ubisoft"}, Sort: "-name"}, []string{"Ubisoft Quebec", "Ubisoft Montreal"}},
+		{"creation order", model.DeveloperQuery{}, []string{"Ubisoft Montreal", "Eidos Montreal", "Ubisoft Quebec", "Valve", "Bungie"}},
+	}
+	for _, tt := range tests {
+		page, err := repos.Developers.ListDevelopers(ctx, tt.query)
+		if err != nil {
+			t.Fatalf("%s: ListDevelopers: %v", tt.name, err)
+		}
+		if got := names(page.Items); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: ListDevelopers = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	var got []string
+	query := model.DeveloperQuery{Sort: "name", Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("developer pagination does not terminate")
+		}
+		page, err := repos.Developers.ListDevelopers(ctx, query)
+		if err != nil {
+			t.Fatalf("ListDevelopers: %v", err)
+		}
+		if page.Total != 5 {
+			t.Errorf("Total = %d, want 5", page.Total)
+		}
+		got = append(got, names(page.Items)...)
+		if page.Next == "" {
+			break
+		}
+		query.Cursor = page.Next
+	}
+	want := []string{"Bungie", "Eidos Montreal", "Ubisoft Montreal", "Ubisoft Quebec", "Valve"}
+	if !slices.Equal(got, want) {
+		t.Errorf("pages = %q, want %q", got, want)
+	}
+}
+
+// errStopStream is returned by the stream tests to stop iterating.
+var errStopStream = errors.New("stop")
+
+func testStreamGames(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	_, valve := addCatalogue(t, repos)
+
+	tests := []struct {
+		name   string
+		filter model.GameFilter
+		sort   string
+		want   []string
+	}{
+		{"all", model.GameFilter{}, "", []string{"The Witcher", "The Witcher 2", "The Witcher 3", "Cyberpunk 2077", "Half-Life", "Half-Life 2", "Portal"}},
+		{"genre by year", model.GameFilter{Genre: "RPG"}, "-year", []string{"Cyberpunk 2077", "The Witcher 3", "The Witcher 2", "The Witcher"}},
+		{"developer by title", model.GameFilter{DeveloperID: valve.ID.Hex()}, "title", []string{"Half-Life", "Half-Life 2", "Portal"}},
+		{"nothing", model.GameFilter{Genre: "Racing"}, "", nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		err := repos.Games.StreamGames(ctx, tt.filter, tt.sort, func(g model.Game) error {
+			got = append(got, g.Title)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: StreamGames: %v", tt.name, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: StreamGames = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	calls := 0
+	err := repos.Games.StreamGames(ctx, model.GameFilter{}, "", func(model.Game) error {
+		calls++
+		return errStopStream
+	})
+	if !errors.Is(err, errStopStream) || calls != 1 {
+		t.Errorf("StreamGames stopping at the first game = %v after %d calls, want %v after 1", err, calls, errStopStream)
+	}
+	err = repos.Games.StreamGames(ctx, model.GameFilter{}, "developer", func(model.Game) error { return nil })
+	expectKind(t, "StreamGames with an unknown sort field", err, apperr.ErrValidation)
+}
+
+func testStreamDevelopers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	for _, d := range []struct{ name, hq string }{
+		{"Ubisoft Montreal", "Montreal"},
+		{"Eidos Montreal", "Montreal"},
+		{"Valve", "Bellevue"},
+	} {
+		mustAddDeveloper(t, repos.Developers, d.name, d.hq)
+	}
+
+	tests := []struct {
+		name   string
+		filter model.DeveloperFilter
+		sort   string
+		want   []string
+	}{
+		{"all", model.DeveloperFilter{}, "", []string{"Ubisoft Montreal", "Eidos Montreal", "Valve"}},
+		{"hq by name", model.DeveloperFilter{MainHq: "Montreal"}, "name", []string{"Eidos Montreal", "Ubisoft Montreal"}},
+		{"name substring", model.DeveloperFilter{NameSubstring: "VAL"}, "-name", []string{"Valve"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		err := repos.Developers.StreamDevelopers(ctx, tt.filter, tt.sort, func(d model.Developer) error {
+			got = append(got, d.Name)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s: StreamDevelopers: %v", tt.name, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: StreamDevelopers = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	err := repos.Developers.StreamDevelopers(ctx, model.DeveloperFilter{}, "", func(model.Developer) error { return errStopStream })
+	if !errors.Is(err, errStopStream) {
+		t.Errorf("StreamDevelopers = %v, want the error of fn", err)
+	}
+}
+
+func testRestoreDeveloper(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	restored := model.Developer{ID: primitive.NewObjectID(), Name: "Nintendo", MainHq: "Kyoto", Version: 7}
+	if _, err := repos.Developers.RestoreDeveloper(ctx, restored); err != nil {
+		t.Fatalf("RestoreDeveloper of a new developer: %v", err)
+	}
+	got, err := repos.Developers.GetDeveloperById(ctx, restored.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetDeveloperById: %v", err)
+	}
+	if *got != restored {
+		t.Errorf("restored developer = %+v, want %+v with its ID and version kept", *got, restored)
+	}
+
+	game := mustAddGame(t, repos.Games, &restored, "Metroid")
+	replacement := model.Developer{ID: restored.ID, Name: "Nintendo EPD", MainHq: "Kyoto", Version: 3}
+	if _, err := repos.Developers.RestoreDeveloper(ctx, replacement); err != nil {
+		t.Fatalf("RestoreDeveloper of a stored developer: %v", err)
+	}
+	got, err = repos.Developers.GetDeveloperById(ctx, restored.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetDeveloperById: %v", err)
+	}
+	if *got != replacement {
+		t.Errorf("replaced developer = %+v, want %+v", *got, replacement)
+	}
+	if _, err := repos.Games.GetGameById(ctx, game.ID.Hex()); err != nil {
+		t.Errorf("replacing a developer removed its game: %v", err)
+	}
+}
+
+func testRestoreGame(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	nintendo := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	restored := model.Game{
+		ID:              primitive.NewObjectID(),
+		Title:           "Metroid",
+		Developer:       model.Developer{ID: nintendo.ID, Name: "stale"},
+		Genre:           "Action",
+		PublicationYear: 1986,
+		Available:       false,
+		Version:         4,
+	}
+	if _, err := repos.Games.RestoreGame(ctx, restored); err != nil {
+		t.Fatalf("RestoreGame of a new game: %v", err)
+	}
+	got, err := repos.Games.GetGameById(ctx, restored.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	want := restored
+	want.Developer = *nintendo
+	if *got != want {
+		t.Errorf("restored game = %+v, want %+v with the stored developer", *got, want)
+	}
+
+	gameCopy := mustAddCopy(t, repos.Copies, got, "B-1")
+	replacement := want
+	replacement.Title = "Metroid (NES)"
+	replacement.Copies, replacement.AvailableCopies, replacement.Available = 1, 1, true
+	replacement.Version = 9
+	if _, err := repos.Games.RestoreGame(ctx, replacement); err != nil {
+		t.Fatalf("RestoreGame of a stored game: %v", err)
+	}
+	got, err = repos.Games.GetGameById(ctx, restored.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if *got != replacement {
+		t.Errorf("replaced game = %+v, want %+v", *got, replacement)
+	}
+	if _, err := repos.Copies.GetCopyById(ctx, gameCopy.ID.Hex()); err != nil {
+		t.Errorf("replacing a game removed its copy: %v", err)
+	}
+
+	orphan := model.Game{ID: primitive.NewObjectID(), Title: "Orphan", Developer: model.Developer{ID: primitive.NewObjectID()}, Version: 1}
+	_, err = repos.Games.RestoreGame(ctx, orphan)
+	expectKind(t, "RestoreGame with a missing developer", err, apperr.ErrValidation)
+}
+
+// mustAddMember inserts an active member and fails the test on error.
+func mustAddMember(t *testing.T, members _interface.MemberRepositorer, name, email, cardNumber string) *model.Member {
+	t.Helper()
+	member, err := members.AddMember(context.Background(), model.Member{
+		Name:       name,
+		Email:      email,
+		CardNumber: cardNumber,
+		Status:     model.MemberActive,
+	})
+	if err != nil {
+		t.Fatalf("AddMember(%q): %v", name, err)
+	}
+	return member
+}
+
+func testMembers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	preset := primitive.NewObjectID()
+	member, err := repos.Members.AddMember(ctx, model.Member{ID: preset, Name: "Ada", Email: "ada@example.com", CardNumber: "C-1", Status: model.MemberActive})
+	if err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	if member.ID.IsZero() || member.ID == preset {
+		t.Errorf("AddMember returned ID %v, want a new one", member.ID)
+	}
+	if member.Version != 1 {
+		t.Errorf("AddMember returned version %d, want 1", member.Version)
+	}
+
+	lookups := []struct {
+		name string
+		get  func() (*model.Member, error)
+	}{
+		{"GetMemberById", func() (*model.Member, error) { return repos.Members.GetMemberById(ctx, member.ID.Hex()) }},
+		{"GetMemberByEmail", func() (*model.Member, error) { return repos.Members.GetMemberByEmail(ctx, "ada@example.com") }},
+		{"GetMemberByCardNumber", func() (*model.Member, error) { return repos.Members.GetMemberByCardNumber(ctx, "C-1") }},
+	}
+	for _, lookup := range lookups {
+		got, err := lookup.get()
+		if err != nil {
+			t.Errorf("%s: %v", lookup.name, err)
+			continue
+		}
+		if *got != *member {
+			t.Errorf("%s = %+v, want %+v", lookup.name, *got, *member)
+		}
+	}
+
+	missing := primitive.NewObjectID().Hex()
+	_, err = repos.Members.GetMemberById(ctx, missing)
+	expectKind(t, "GetMemberById of a missing member", err, apperr.ErrNotFound)
+	_, err = repos.Members.GetMemberById(ctx, "not-an-id")
+	expectKind(t, "GetMemberById of an invalid ID", err, apperr.ErrInvalidID)
+	_, err = repos.Members.GetMemberByEmail(ctx, "alan@example.com")
+	expectKind(t, "GetMemberByEmail of a missing member", err, apperr.ErrNotFound)
+	_, err = repos.Members.GetMemberByCardNumber(ctx, "C-2")
+	expectKind(t, "GetMemberByCardNumber of a missing member", err, apperr.ErrNotFound)
+	_, err = repos.Members.UpdateMember(ctx, missing, model.Member{Name: "x"})
+	expectKind(t, "UpdateMember of a missing member", err, apperr.ErrNotFound)
+	err = repos.Members.DeleteMember(ctx, missing, 0)
+	expectKind(t, "DeleteMember of a missing member", err, apperr.ErrNotFound)
+}
+
+func testUpdateMember(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	member := mustAddMember(t, repos.Members, "Ada", "ada@example.com", "C-1")
+
+	change := model.Member{Name: "Ada Lovelace", Email: "ada@lovelace.org", CardNumber: "C-9", Status: model.MemberSuspended, Version: 1}
+	updated, err := repos.Members.UpdateMember(ctx, member.ID.Hex(), change)
+	if err != nil {
+		t.Fatalf("UpdateMember: %v", err)
+	}
+	want := change
+	want.ID, want.Version = member.ID, 2
+	if *updated != want {
+		t.Errorf("UpdateMember = %+v, want %+v", *updated, want)
+	}
+	if _, err := repos.Members.GetMemberByEmail(ctx, "ada@example.com"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("GetMemberByEmail of the old email: error = %v, want %v", err, apperr.ErrNotFound)
+	}
+
+	_, err = repos.Members.UpdateMember(ctx, member.ID.Hex(), change)
+	expectKind(t, "UpdateMember with a stale version", err, apperr.ErrVersionMismatch)
+	err = repos.Members.DeleteMember(ctx, member.ID.Hex(), 1)
+	expectKind(t, "DeleteMember with a stale version", err, apperr.ErrVersionMismatch)
+
+	if err := repos.Members.DeleteMember(ctx, member.ID.Hex(), 2); err != nil {
+		t.Fatalf("DeleteMember: %v", err)
+	}
+	_, err = repos.Members.GetMemberById(ctx, member.ID.Hex())
+	expectKind(t, "GetMemberById of a deleted member", err, apperr.ErrNotFound)
+}
+
+func testListMembers(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	for i, name := range []string{"Grace", "Ada", "Alan", "Barbara", "Edsger"} {
+		member := mustAddMember(t, repos.Members, name, strings.ToLower(name)+"@example.com", fmt.Sprintf("C-%d", i))
+		if name == "Alan" || name == "Edsger" {
+			member.Status = model.MemberExpired
+			if _, err := repos.Members.UpdateMember(ctx, member.ID.Hex(), *member); err != nil {
+				t.Fatalf("UpdateMember(%q): %v", name, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query model.MemberQuery
+		want  []string
+	}{
+		{"creation order", model.MemberQuery{}, []string{"Grace", "Ada", "Alan", "Barbara", "Edsger"}},
+		{"by name", model.MemberQuery{Sort: "name"}, []string{"Ada", "Alan", "Barbara", "Edsger", "Grace"}},
+		{"status", model.MemberQuery{Filter: model.MemberFilter{Status: model.MemberExpired}, Sort: "-name"}, []string{"Edsger", "Alan"}},
+		{"name substring", model.MemberQuery{Filter: model.MemberFilter{NameSubstring: "A"}, Sort: "name"}, []string{"Ada", "Alan", "Barbara", "Grace"}},
+	}
+	for _, tt := range tests {
+		page, err := repos.Members.ListMembers(ctx, tt.query)
+		if err != nil {
+			t.Errorf("%s: ListMembers: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, member := range page.Items {
+			got = append(got, member.Name)
+		}
+		if !slices.Equal(got, tt.want) || page.Total != int64(len(tt.want)) {
+			t.Errorf("%s: ListMembers = %q (total %d), want %q", tt.name, got, page.Total, tt.want)
+		}
+	}
+
+	var got []string
+	query := model.MemberQuery{Sort: "name", Limit: 2}
+	for {
+		page, err := repos.Members.ListMembers(ctx, query)
+		if err != nil {
+			t.Fatalf("ListMembers: %v", err)
+		}
+		for _, member := range page.Items {
+			got = append(got, member.Name)
+		}
+		if page.Next == "" {
+			break
+		}
+		query.Cursor = page.Next
+	}
+	if want := []string{"Ada", "Alan", "Barbara", "Edsger", "Grace"}; !slices.Equal(got, want) {
+		t.Errorf("pages = %q, want %q", got, want)
+	}
+}
+
+// mustAddCopy inserts an available copy of a game and fails the test on error.
+func mustAddCopy(t *testing.T, copies _interface.CopyRepositorer, game *model.Game, barcode string) *model.Copy {
+	t.Helper()
+	gameCopy, err := copies.AddCopy(context.Background(), model.Copy{
+		GameID:    game.ID,
+		Barcode:   barcode,
+		Condition: model.CopyGood,
+		Platform:  "PC",
+		Available: true,
+	})
+	if err != nil {
+		t.Fatalf("AddCopy(%q): %v", barcode, err)
+	}
+	return gameCopy
+}
+
+// copyIDs returns the IDs of copies in order.
+func copyIDs(copies []model.Copy) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, len(copies))
+	for i, c := range copies {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+// expectCounts fails the test unless the game has the given number of copies and available copies.
+func expectCounts(t *testing.T, copies _interface.CopyRepositorer, gameID primitive.ObjectID, total, available int) {
+	t.Helper()
+	gotTotal, gotAvailable, err := copies.CountCopies(context.Background(), gameID.Hex())
+	if err != nil {
+		t.Fatalf("CountCopies: %v", err)
+	}
+	if gotTotal != total || gotAvailable != available {
+		t.Errorf("CountCopies = %d, %d, want %d, %d", gotTotal, gotAvailable, total, available)
+	}
+}
+
+func testCopies(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	game := mustAddGame(t, repos.Games, dev, "Metroid")
+	other := mustAddGame(t, repos.Games, dev, "Kirby")
+
+	preset := primitive.NewObjectID()
+	first, err := repos.Copies.AddCopy(ctx, model.Copy{ID: preset, GameID: game.ID, Barcode: "B-1", Condition: model.CopyNew, Platform: "Switch", ShelfLocation: "A3", Available: true})
+	if err != nil {
+		t.Fatalf("AddCopy: %v", err)
+	}
+	if first.ID.IsZero() || first.ID == preset {
+		t.Errorf("AddCopy returned ID %v, want a new one", first.ID)
+	}
+	if first.Version != 1 {
+		t.Errorf("AddCopy returned version %d, want 1", first.Version)
+	}
+	second := mustAddCopy(t, repos.Copies, game, "B-2")
+	mustAddCopy(t, repos.Copies, other, "B-3")
+
+	lookups := []struct {
+		name string
+		get  func() (*model.Copy, error)
+	}{
+		{"GetCopyById", func() (*model.Copy, error) { return repos.Copies.GetCopyById(ctx, first.ID.Hex()) }},
+		{"GetCopyByBarcode", func() (*model.Copy, error) { return repos.Copies.GetCopyByBarcode(ctx, "B-1") }},
+	}
+	for _, lookup := range lookups {
+		got, err := lookup.get()
+		if err != nil {
+			t.Errorf("%s: %v", lookup.name, err)
+			continue
+		}
+		if *got != *first {
+			t.Errorf("%s = %+v, want %+v", lookup.name, *got, *first)
+		}
+	}
+
+	listed, err := repos.Copies.ListCopies(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("ListCopies: %v", err)
+	}
+	if got, want := copyIDs(listed), []primitive.ObjectID{first.ID, second.ID}; !slices.Equal(got, want) {
+		t.Errorf("ListCopies = %v, want %v", got, want)
+	}
+	page, err := repos.Copies.PageCopies(ctx, model.CopyQuery{GameID: game.ID.Hex(), Limit: 1})
+	if err != nil {
+		t.Fatalf("PageCopies: %v", err)
+	}
+	if got, want := copyIDs(page.Items), []primitive.ObjectID{first.ID}; !slices.Equal(got, want) || page.Total != 2 || page.Next == "" {
+		t.Errorf("PageCopies = %v (total %d, next %q), want %v of 2 and a next page", got, page.Total, page.Next, want)
+	}
+	page, err = repos.Copies.PageCopies(ctx, model.CopyQuery{GameID: game.ID.Hex(), Cursor: page.Next, Limit: 1})
+	if err != nil {
+		t.Fatalf("PageCopies of the next page: %v", err)
+	}
+	if got, want := copyIDs(page.Items), []primitive.ObjectID{second.ID}; !slices.Equal(got, want) || page.Next != "" {
+		t.Errorf("PageCopies of the next page = %v (next %q), want %v and no next page", got, page.Next, want)
+	}
+	expectCounts(t, repos.Copies, game.ID, 2, 2)
+
+	lent, err := repos.Copies.SetCopyAvailability(ctx, first.ID.Hex(), false, 1)
+	if err != nil {
+		t.Fatalf("SetCopyAvailability: %v", err)
+	}
+	if lent.Available || lent.Version != 2 {
+		t.Errorf("SetCopyAvailability = %+v, want unavailable at version 2", *lent)
+	}
+	expectCounts(t, repos.Copies, game.ID, 2, 1)
+
+	same, err := repos.Copies.SetCopyAvailability(ctx, first.ID.Hex(), false, 0)
+	if err != nil {
+		t.Fatalf("SetCopyAvailability to the current value: %v", err)
+	}
+	if same.Version != 2 {
+		t.Errorf("SetCopyAvailability to the current value bumped the version to %d", same.Version)
+	}
+	_, err = repos.Copies.SetCopyAvailability(ctx, first.ID.Hex(), true, 1)
+	expectKind(t, "SetCopyAvailability with a stale version", err, apperr.ErrVersionMismatch)
+
+	missing := primitive.NewObjectID().Hex()
+	_, err = repos.Copies.GetCopyById(ctx, missing)
+	expectKind(t, "GetCopyById of a missing copy", err, apperr.ErrNotFound)
+	_, err = repos.Copies.GetCopyById(ctx, "not-an-id")
+	expectKind(t, "GetCopyById of an invalid ID", err, apperr.ErrInvalidID)
+	_, err = repos.Copies.GetCopyByBarcode(ctx, "B-9")
+	expectKind(t, "GetCopyByBarcode of a missing copy", err, apperr.ErrNotFound)
+	_, err = repos.Copies.SetCopyAvailability(ctx, missing, true, 0)
+	expectKind(t, "SetCopyAvailability of a missing copy", err, apperr.ErrNotFound)
+	_, err = repos.Copies.ListCopies(ctx, "not-an-id")
+	expectKind(t, "ListCopies of an invalid game ID", err, apperr.ErrInvalidID)
+}
+
+func testUpdateCopy(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	game := mustAddGame(t, repos.Games, dev, "Metroid")
+	gameCopy := mustAddCopy(t, repos.Copies, game, "B-1")
+
+	change := model.Copy{Barcode: "B-7", Condition: model.CopyPoor, Platform: "Wii", ShelfLocation: "C1", Version: 1}
+	updated, err := repos.Copies.UpdateCopy(ctx, gameCopy.ID.Hex(), change)
+	if err != nil {
+		t.Fatalf("UpdateCopy: %v", err)
+	}
+	want := change
+	want.ID, want.GameID, want.Available, want.Version = gameCopy.ID, game.ID, true, 2
+	if *updated != want {
+		t.Errorf("UpdateCopy = %+v, want %+v", *updated, want)
+	}
+	_, err = repos.Copies.GetCopyByBarcode(ctx, "B-1")
+	expectKind(t, "GetCopyByBarcode of the old barcode", err, apperr.ErrNotFound)
+
+	_, err = repos.Copies.UpdateCopy(ctx, gameCopy.ID.Hex(), change)
+	expectKind(t, "UpdateCopy with a stale version", err, apperr.ErrVersionMismatch)
+	err = repos.Copies.DeleteCopy(ctx, gameCopy.ID.Hex(), 1)
+	expectKind(t, "DeleteCopy with a stale version", err, apperr.ErrVersionMismatch)
+
+	if err := repos.Copies.DeleteCopy(ctx, gameCopy.ID.Hex(), 2); err != nil {
+		t.Fatalf("DeleteCopy: %v", err)
+	}
+	_, err = repos.Copies.GetCopyById(ctx, gameCopy.ID.Hex())
+	expectKind(t, "GetCopyById of a deleted copy", err, apperr.ErrNotFound)
+	expectCounts(t, repos.Copies, game.ID, 0, 0)
+
+	missing := primitive.NewObjectID().Hex()
+	_, err = repos.Copies.UpdateCopy(ctx, missing, change)
+	expectKind(t, "UpdateCopy of a missing copy", err, apperr.ErrNotFound)
+	expectKind(t, "DeleteCopy of a missing copy", repos.Copies.DeleteCopy(ctx, missing, 0), apperr.ErrNotFound)
+}
+
+func testSetCopyCounts(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	game := mustAddGame(t, repos.Games, dev, "Metroid")
+
+	out, err := repos.Games.SetCopyCounts(ctx, game.ID.Hex(), 3, 0)
+	if err != nil {
+		t.Fatalf("SetCopyCounts: %v", err)
+	}
+	if out.Copies != 3 || out.AvailableCopies != 0 || out.Available || out.Version != game.Version+1 {
+		t.Errorf("SetCopyCounts(3, 0) = %+v, want 3 copies, none available, at version %d", *out, game.Version+1)
+	}
+	in, err := repos.Games.SetCopyCounts(ctx, game.ID.Hex(), 3, 2)
+	if err != nil {
+		t.Fatalf("SetCopyCounts: %v", err)
+	}
+	if in.Copies != 3 || in.AvailableCopies != 2 || !in.Available {
+		t.Errorf("SetCopyCounts(3, 2) = %+v, want 3 copies, 2 available", *in)
+	}
+
+	change := *in
+	change.Title, change.Copies, change.AvailableCopies = "Metroid Prime", 0, 0
+	updated, err := repos.Games.UpdateGame(ctx, game.ID.Hex(), change)
+	if err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	if updated.Copies != 3 || updated.AvailableCopies != 2 {
+		t.Errorf("UpdateGame changed the copy counts to %d, %d", updated.Copies, updated.AvailableCopies)
+	}
+
+	_, err = repos.Games.SetCopyCounts(ctx, primitive.NewObjectID().Hex(), 1, 1)
+	expectKind(t, "SetCopyCounts of a missing game", err, apperr.ErrNotFound)
+}
+
+func testDeleteGameDeletesCopies(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	nintendo := mustAddDeveloper(t, repos.Developers, "Nintendo", "Kyoto")
+	valve := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	metroid := mustAddGame(t, repos.Games, nintendo, "Metroid")
+	kirby := mustAddGame(t, repos.Games, nintendo, "Kirby")
+	portal := mustAddGame(t, repos.Games, valve, "Portal")
+	metroidCopy := mustAddCopy(t, repos.Copies, metroid, "B-1")
+	kirbyCopy := mustAddCopy(t, repos.Copies, kirby, "B-2")
+	portalCopy := mustAddCopy(t, repos.Copies, portal, "B-3")
+
+	if err := repos.Games.DeleteGame(ctx, metroid.ID.Hex(), 0); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	_, err := repos.Copies.GetCopyById(ctx, metroidCopy.ID.Hex())
+	expectKind(t, "GetCopyById of a copy of a deleted game", err, apperr.ErrNotFound)
+
+	if err := repos.Games.DeleteManyGamesByDeveloper(ctx, nintendo.ID.Hex()); err != nil {
+		t.Fatalf("DeleteManyGamesByDeveloper: %v", err)
+	}
+	_, err = repos.Copies.GetCopyById(ctx, kirbyCopy.ID.Hex())
+	expectKind(t, "GetCopyById of a copy of a cascaded game", err, apperr.ErrNotFound)
+
+	if _, err := repos.Copies.GetCopyById(ctx, portalCopy.ID.Hex()); err != nil {
+		t.Errorf("deleting games removed a copy of an unrelated game: %v", err)
+	}
+}
+
+// loanTime is a point in time that every backend stores without loss.
+var loanTime = time.Date(2024, time.March, 1, 10, 30, 0, 0, time.UTC)
+
+// mustAddLoan inserts a loan of a game to a member and fails the test on error.
+func mustAddLoan(t *testing.T, loans _interface.LoanRepositorer, gameID, memberID primitive.ObjectID) *model.Loan {
+	t.Helper()
+	loan, err := loans.AddLoan(context.Background(), model.Loan{
+		GameID:       gameID,
+		MemberID:     memberID,
+		CheckedOutAt: loanTime,
+		DueAt:        loanTime.Add(14 * 24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("AddLoan: %v", err)
+	}
+	return loan
+}
+
+// sameLoan reports whether two loans have the same fields, comparing times by instant.
+func sameLoan(a, b model.Loan) bool {
+	if a.ID != b.ID || a.GameID != b.GameID || a.MemberID != b.MemberID || a.Overdue != b.Overdue ||
+		!a.CheckedOutAt.Equal(b.CheckedOutAt) || !a.DueAt.Equal(b.DueAt) {
+		return false
+	}
+	if (a.CopyID == nil) != (b.CopyID == nil) || a.CopyID != nil && *a.CopyID != *b.CopyID {
+		return false
+	}
+	if a.ReturnedAt == nil || b.ReturnedAt == nil {
+		return a.ReturnedAt == nil && b.ReturnedAt == nil
+	}
+	return a.ReturnedAt.Equal(*b.ReturnedAt)
+}
+
+func testCloseLoan(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	gameID, memberID := primitive.NewObjectID(), primitive.NewObjectID()
+	loan := mustAddLoan(t, repos.Loans, gameID, memberID)
+
+	got, err := repos.Loans.GetLoanById(ctx, loan.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetLoanById: %v", err)
+	}
+	if !sameLoan(*got, *loan) {
+		t.Errorf("GetLoanById = %+v, want %+v", *got, *loan)
+	}
+
+	copyID := primitive.NewObjectID()
+	copyLoan, err := repos.Loans.AddLoan(ctx, model.Loan{GameID: gameID, CopyID: &copyID, MemberID: memberID, CheckedOutAt: loanTime, DueAt: loanTime})
+	if err != nil {
+		t.Fatalf("AddLoan of a copy: %v", err)
+	}
+	got, err = repos.Loans.GetLoanById(ctx, copyLoan.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetLoanById of a copy loan: %v", err)
+	}
+	if !sameLoan(*got, *copyLoan) {
+		t.Errorf("GetLoanById of a copy loan = %+v, want %+v", *got, *copyLoan)
+	}
+
+	returnedAt := loanTime.Add(48 * time.Hour)
+	closed, err := repos.Loans.CloseLoan(ctx, loan.ID.Hex(), returnedAt)
+	if err != nil {
+		t.Fatalf("CloseLoan: %v", err)
+	}
+	if closed.ReturnedAt == nil || !closed.ReturnedAt.Equal(returnedAt) {
+		t.Errorf("CloseLoan set ReturnedAt = %v, want %v", closed.ReturnedAt, returnedAt)
+	}
+
+	_, err = repos.Loans.CloseLoan(ctx, loan.ID.Hex(), returnedAt)
+	expectKind(t, "CloseLoan of a returned loan", err, apperr.ErrConflict)
+	active := true
+	open, err := repos.Loans.ListLoans(ctx, model.LoanFilter{GameID: gameID.Hex(), Active: &active})
+	if err != nil {
+		t.Fatalf("ListLoans: %v", err)
+	}
+	if len(open) != 1 || open[0].ID != copyLoan.ID {
+		t.Errorf("active loans after CloseLoan = %+v, want only %v", open, copyLoan.ID)
+	}
+	_, err = repos.Loans.CloseLoan(ctx, primitive.NewObjectID().Hex(), returnedAt)
+	expectKind(t, "CloseLoan of a missing loan", err, apperr.ErrNotFound)
+	_, err = repos.Loans.GetLoanById(ctx, "not-an-id")
+	expectKind(t, "GetLoanById of an invalid ID", err, apperr.ErrInvalidID)
+}
+
+func testListLoans(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	witcher, portal := primitive.NewObjectID(), primitive.NewObjectID()
+	ada, alan := primitive.NewObjectID(), primitive.NewObjectID()
+
+	returned := mustAddLoan(t, repos.Loans, witcher, ada)
+	if _, err := repos.Loans.CloseLoan(ctx, returned.ID.Hex(), loanTime.Add(time.Hour)); err != nil {
+		t.Fatalf("CloseLoan: %v", err)
+	}
+	current := mustAddLoan(t, repos.Loans, witcher, alan)
+	other := mustAddLoan(t, repos.Loans, portal, ada)
+	if _, err := repos.Loans.MarkOverdue(ctx, other.ID.Hex()); err != nil {
+		t.Fatalf("MarkOverdue: %v", err)
+	}
+
+	active, inactive := true, false
+	dueAt := current.DueAt
+	tests := []struct {
+		name   string
+		filter model.LoanFilter
+		want   []*model.Loan
+	}{
+		{"all", model.LoanFilter{}, []*model.Loan{returned, current, other}},
+		{"game", model.LoanFilter{GameID: witcher.Hex()}, []*model.Loan{returned, current}},
+		{"member", model.LoanFilter{MemberID: ada.Hex()}, []*model.Loan{returned, other}},
+		{"active", model.LoanFilter{Active: &active}, []*model.Loan{current, other}},
+		{"returned", model.LoanFilter{Active: &inactive}, []*model.Loan{returned}},
+		{"combined", model.LoanFilter{GameID: witcher.Hex(), Active: &active}, []*model.Loan{current}},
+		{"overdue", model.LoanFilter{Overdue: &active}, []*model.Loan{other}},
+		{"not overdue", model.LoanFilter{Overdue: &inactive}, []*model.Loan{returned, current}},
+		{"due before", model.LoanFilter{DueBefore: dueAt.Add(time.Millisecond), Active: &active}, []*model.Loan{current, other}},
+		{"not yet due", model.LoanFilter{DueBefore: dueAt}, nil},
+	}
+	for _, tt := range tests {
+		loans, err := repos.Loans.ListLoans(ctx, tt.filter)
+		if err != nil {
+			t.Errorf("%s: ListLoans: %v", tt.name, err)
+			continue
+		}
+		got := make([]primitive.ObjectID, len(loans))
+		for i, loan := range loans {
+			got[i] = loan.ID
+		}
+		want := make([]primitive.ObjectID, len(tt.want))
+		for i, loan := range tt.want {
+			want[i] = loan.ID
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: ListLoans = %v, want %v", tt.name, got, want)
+		}
+	}
+
+	_, err := repos.Loans.ListLoans(ctx, model.LoanFilter{MemberID: "not-an-id"})
+	expectKind(t, "ListLoans with an invalid member ID", err, apperr.ErrInvalidID)
+}
+
+func testPageLoans(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	member := primitive.NewObjectID()
+	var want []primitive.ObjectID
+	for range 5 {
+		want = append(want, mustAddLoan(t, repos.Loans, primitive.NewObjectID(), member).ID)
+	}
+	mustAddLoan(t, repos.Loans, primitive.NewObjectID(), primitive.NewObjectID())
+
+	var got []primitive.ObjectID
+	query := model.LoanQuery{Filter: model.LoanFilter{MemberID: member.Hex()}, Limit: 2}
+	for {
+		page, err := repos.Loans.PageLoans(ctx, query)
+		if err != nil {
+			t.Fatalf("PageLoans: %v", err)
+		}
+		if page.Total != 5 {
+			t.Errorf("PageLoans total = %d, want 5", page.Total)
+		}
+		for _, loan := range page.Items {
+			got = append(got, loan.ID)
+		}
+		if page.Next == "" {
+			break
+		}
+		query.Cursor = page.Next
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+
+	_, err := repos.Loans.PageLoans(ctx, model.LoanQuery{Cursor: "not-a-cursor"})
+	expectKind(t, "PageLoans with an invalid cursor", err, apperr.ErrValidation)
+}
+
+func testMarkOverdue(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	loan := mustAddLoan(t, repos.Loans, primitive.NewObjectID(), primitive.NewObjectID())
+	if loan.Overdue {
+		t.Errorf("AddLoan returned an overdue loan")
+	}
+
+	want := *loan
+	want.Overdue = true
+	for _, attempt := range []string{"MarkOverdue", "MarkOverdue of an overdue loan"} {
+		marked, err := repos.Loans.MarkOverdue(ctx, loan.ID.Hex())
+		if err != nil {
+			t.Fatalf("%s: %v", attempt, err)
+		}
+		if !sameLoan(*marked, want) {
+			t.Errorf("%s = %+v, want %+v", attempt, *marked, want)
+		}
+	}
+	got, err := repos.Loans.GetLoanById(ctx, loan.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetLoanById: %v", err)
+	}
+	if !sameLoan(*got, want) {
+		t.Errorf("GetLoanById after MarkOverdue = %+v, want %+v", *got, want)
+	}
+
+	_, err = repos.Loans.MarkOverdue(ctx, primitive.NewObjectID().Hex())
+	expectKind(t, "MarkOverdue of a missing loan", err, apperr.ErrNotFound)
+	_, err = repos.Loans.MarkOverdue(ctx, "not-an-id")
+	expectKind(t, "MarkOverdue of an invalid ID", err, apperr.ErrInvalidID)
+}
+
+// sameTime reports whether two optional times are both nil or the same instant.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+// sameHold reports whether two holds have the same fields, comparing times by instant.
+func sameHold(a, b model.Hold) bool {
+	if a.ID != b.ID || a.GameID != b.GameID || a.MemberID != b.MemberID || a.Status != b.Status ||
+		a.Version != b.Version || !a.PlacedAt.Equal(b.PlacedAt) {
+		return false
+	}
+	if (a.CopyID == nil) != (b.CopyID == nil) || a.CopyID != nil && *a.CopyID != *b.CopyID {
+		return false
+	}
+	return sameTime(a.ReadyAt, b.ReadyAt) && sameTime(a.ExpiresAt, b.ExpiresAt) && sameTime(a.ClosedAt, b.ClosedAt)
+}
+
+// mustAddHold inserts a waiting hold of a member on a game and fails the test on error.
+func mustAddHold(t *testing.T, holds _interface.HoldRepositorer, gameID, memberID primitive.ObjectID) *model.Hold {
+	t.Helper()
+	hold, err := holds.AddHold(context.Background(), model.Hold{
+		GameID:   gameID,
+		MemberID: memberID,
+		Status:   model.HoldWaiting,
+		PlacedAt: loanTime,
+	})
+	if err != nil {
+		t.Fatalf("AddHold: %v", err)
+	}
+	return hold
+}
+
+func testHolds(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	hold := mustAddHold(t, repos.Holds, primitive.NewObjectID(), primitive.NewObjectID())
+	if hold.ID.IsZero() || hold.Version != 1 {
+		t.Errorf("AddHold returned ID %v at version %d, want a new ID at version 1", hold.ID, hold.Version)
+	}
+
+	got, err := repos.Holds.GetHoldById(ctx, hold.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetHoldById: %v", err)
+	}
+	if !sameHold(*got, *hold) {
+		t.Errorf("GetHoldById = %+v, want %+v", *got, *hold)
+	}
+
+	copyID := primitive.NewObjectID()
+	readyAt, expiresAt := loanTime.Add(time.Hour), loanTime.Add(73*time.Hour)
+	change := *hold
+	change.Status, change.CopyID, change.ReadyAt, change.ExpiresAt = model.HoldReady, &copyID, &readyAt, &expiresAt
+	updated, err := repos.Holds.UpdateHold(ctx, hold.ID.Hex(), change)
+	if err != nil {
+		t.Fatalf("UpdateHold: %v", err)
+	}
+	want := change
+	want.Version = 2
+	if !sameHold(*updated, want) {
+		t.Errorf("UpdateHold = %+v, want %+v", *updated, want)
+	}
+	got, err = repos.Holds.GetHoldById(ctx, hold.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetHoldById: %v", err)
+	}
+	if !sameHold(*got, want) {
+		t.Errorf("GetHoldById after UpdateHold = %+v, want %+v", *got, want)
+	}
+
+	_, err = repos.Holds.UpdateHold(ctx, hold.ID.Hex(), change)
+	expectKind(t, "UpdateHold with a stale version", err, apperr.ErrVersionMismatch)
+	_, err = repos.Holds.UpdateHold(ctx, primitive.NewObjectID().Hex(), change)
+	expectKind(t, "UpdateHold of a missing hold", err, apperr.ErrNotFound)
+	_, err = repos.Holds.GetHoldById(ctx, primitive.NewObjectID().Hex())
+	expectKind(t, "GetHoldById of a missing hold", err, apperr.ErrNotFound)
+	_, err = repos.Holds.GetHoldById(ctx, "not-an-id")
+	expectKind(t, "GetHoldById of an invalid ID", err, apperr.ErrInvalidID)
+}
+
+func testListHolds(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	witcher, portal := primitive.NewObjectID(), primitive.NewObjectID()
+	ada, alan := primitive.NewObjectID(), primitive.NewObjectID()
+
+	first := mustAddHold(t, repos.Holds, witcher, ada)
+	second := mustAddHold(t, repos.Holds, witcher, alan)
+	other := mustAddHold(t, repos.Holds, portal, ada)
+
+	readyAt, expiresAt := loanTime, loanTime.Add(72*time.Hour)
+	ready := *first
+	ready.Status, ready.ReadyAt, ready.ExpiresAt = model.HoldReady, &readyAt, &expiresAt
+	if _, err := repos.Holds.UpdateHold(ctx, first.ID.Hex(), ready); err != nil {
+		t.Fatalf("UpdateHold: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter model.HoldFilter
+		want   []*model.Hold
+	}{
+		{"all", model.HoldFilter{}, []*model.Hold{first, second, other}},
+		{"game", model.HoldFilter{GameID: witcher.Hex()}, []*model.Hold{first, second}},
+		{"member", model.HoldFilter{MemberID: ada.Hex()}, []*model.Hold{first, other}},
+		{"status", model.HoldFilter{Status: model.HoldWaiting}, []*model.Hold{second, other}},
+		{"combined", model.HoldFilter{GameID: witcher.Hex(), MemberID: ada.Hex(), Status: model.HoldReady}, []*model.Hold{first}},
+		{"expired", model.HoldFilter{ExpiredBy: expiresAt}, []*model.Hold{first}},
+		{"not yet expired", model.HoldFilter{ExpiredBy: expiresAt.Add(-time.Millisecond)}, nil},
+	}
+	for _, tt := range tests {
+		holds, err := repos.Holds.ListHolds(ctx, tt.filter)
+		if err != nil {
+			t.Errorf("%s: ListHolds: %v", tt.name, err)
+			continue
+		}
+		got := make([]primitive.ObjectID, len(holds))
+		for i, hold := range holds {
+			got[i] = hold.ID
+		}
+		want := make([]primitive.ObjectID, len(tt.want))
+		for i, hold := range tt.want {
+			want[i] = hold.ID
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: ListHolds = %v, want %v", tt.name, got, want)
+		}
+	}
+
+	_, err := repos.Holds.ListHolds(ctx, model.HoldFilter{GameID: "not-an-id"})
+	expectKind(t, "ListHolds with an invalid game ID", err, apperr.ErrInvalidID)
+}
+
+func testPageHolds(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	member := primitive.NewObjectID()
+	var want []primitive.ObjectID
+	for range 5 {
+		want = append(want, mustAddHold(t, repos.Holds, primitive.NewObjectID(), member).ID)
+	}
+	mustAddHold(t, repos.Holds, primitive.NewObjectID(), primitive.NewObjectID())
+
+	var got []primitive.ObjectID
+	query := model.HoldQuery{Filter: model.HoldFilter{MemberID: member.Hex()}, Limit: 2}
+	for {
+		page, err := repos.Holds.PageHolds(ctx, query)
+		if err != nil {
+			t.Fatalf("PageHolds: %v", err)
+		}
+		if page.Total != 5 {
+			t.Errorf("PageHolds total = %d, want 5", page.Total)
+		}
+		for _, hold := range page.Items {
+			got = append(got, hold.ID)
+		}
+		if page.Next == "" {
+			break
+		}
+		query.Cursor = page.Next
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+
+	_, err := repos.Holds.PageHolds(ctx, model.HoldQuery{Cursor: "not-a-cursor"})
+	expectKind(t, "PageHolds with an invalid cursor", err, apperr.ErrValidation)
+}
+
+// sameFine reports whether two fines have the same fields, comparing times by instant.
+func sameFine(a, b model.Fine) bool {
+	return a.ID == b.ID && a.LoanID == b.LoanID && a.MemberID == b.MemberID && a.GameID == b.GameID &&
+		a.DaysLate == b.DaysLate && a.Amount == b.Amount && a.Paid == b.Paid && a.Status == b.Status &&
+		a.Version == b.Version && a.AssessedAt.Equal(b.AssessedAt) && sameTime(a.ClosedAt, b.ClosedAt)
+}
+
+// mustAddFine inserts an open fine of a member for a loan and fails the test on error.
+func mustAddFine(t *testing.T, fines _interface.FineRepositorer, loanID, memberID primitive.ObjectID) *model.Fine {
+	t.Helper()
+	fine, err := fines.AddFine(context.Background(), model.Fine{
+		LoanID:     loanID,
+		MemberID:   memberID,
+		GameID:     primitive.NewObjectID(),
+		DaysLate:   2,
+		Amount:     50,
+		Status:     model.FineOpen,
+		AssessedAt: loanTime,
+	})
+	if err != nil {
+		t.Fatalf("AddFine: %v", err)
+	}
+	return fine
+}
+
+func testFines(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	fine := mustAddFine(t, repos.Fines, primitive.NewObjectID(), primitive.NewObjectID())
+	if fine.ID.IsZero() || fine.Version != 1 {
+		t.Errorf("AddFine returned ID %v at version %d, want a new ID at version 1", fine.ID, fine.Version)
+	}
+
+	got, err := repos.Fines.GetFineById(ctx, fine.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetFineById: %v", err)
+	}
+	if !sameFine(*got, *fine) {
+		t.Errorf("GetFineById = %+v, want %+v", *got, *fine)
+	}
+
+	closedAt := loanTime.Add(96 * time.Hour)
+	change := *fine
+	change.DaysLate, change.Amount, change.Paid = 4, 100, 100
+	change.Status, change.AssessedAt, change.ClosedAt = model.FinePaid, closedAt, &closedAt
+	updated, err := repos.Fines.UpdateFine(ctx, fine.ID.Hex(), change)
+	if err != nil {
+		t.Fatalf("UpdateFine: %v", err)
+	}
+	want := change
+	want.Version = 2
+	if !sameFine(*updated, want) {
+		t.Errorf("UpdateFine = %+v, want %+v", *updated, want)
+	}
+	got, err = repos.Fines.GetFineById(ctx, fine.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetFineById: %v", err)
+	}
+	if !sameFine(*got, want) {
+		t.Errorf("GetFineById after UpdateFine = %+v, want %+v", *got, want)
+	}
+
+	_, err = repos.Fines.UpdateFine(ctx, fine.ID.Hex(), change)
+	expectKind(t, "UpdateFine with a stale version", err, apperr.ErrVersionMismatch)
+	_, err = repos.Fines.UpdateFine(ctx, primitive.NewObjectID().Hex(), change)
+	expectKind(t, "UpdateFine of a missing fine", err, apperr.ErrNotFound)
+	_, err = repos.Fines.GetFineById(ctx, primitive.NewObjectID().Hex())
+	expectKind(t, "GetFineById of a missing fine", err, apperr.ErrNotFound)
+	_, err = repos.Fines.GetFineById(ctx, "not-an-id")
+	expectKind(t, "GetFineById of an invalid ID", err, apperr.ErrInvalidID)
+}
+
+func testListFines(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	ada, alan := primitive.NewObjectID(), primitive.NewObjectID()
+	loan := primitive.NewObjectID()
+
+	first := mustAddFine(t, repos.Fines, loan, ada)
+	second := mustAddFine(t, repos.Fines, primitive.NewObjectID(), alan)
+	other := mustAddFine(t, repos.Fines, primitive.NewObjectID(), ada)
+
+	waived := *other
+	waived.Status = model.FineWaived
+	if _, err := repos.Fines.UpdateFine(ctx, other.ID.Hex(), waived); err != nil {
+		t.Fatalf("UpdateFine: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter model.FineFilter
+		want   []*model.Fine
+	}{
+		{"all", model.FineFilter{}, []*model.Fine{first, second, other}},
+		{"member", model.FineFilter{MemberID: ada.Hex()}, []*model.Fine{first, other}},
+		{"loan", model.FineFilter{LoanID: loan.Hex()}, []*model.Fine{first}},
+		{"status", model.FineFilter{Status: model.FineOpen}, []*model.Fine{first, second}},
+		{"combined", model.FineFilter{MemberID: ada.Hex(), Status: model.FineWaived}, []*model.Fine{other}},
+	}
+	for _, tt := range tests {
+		fines, err := repos.Fines.ListFines(ctx, tt.filter)
+		if err != nil {
+			t.Errorf("%s: ListFines: %v", tt.name, err)
+			continue
+		}
+		got := make([]primitive.ObjectID, len(fines))
+		for i, fine := range fines {
+			got[i] = fine.ID
+		}
+		want := make([]primitive.ObjectID, len(tt.want))
+		for i, fine := range tt.want {
+			want[i] = fine.ID
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: ListFines = %v, want %v", tt.name, got, want)
+		}
+	}
+
+	_, err := repos.Fines.ListFines(ctx, model.FineFilter{MemberID: "not-an-id"})
+	expectKind(t, "ListFines with an invalid member ID", err, apperr.ErrInvalidID)
+}
+
+func testPageFines(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	member := primitive.NewObjectID()
+	var want []primitive.ObjectID
+	for range 5 {
+		want = append(want, mustAddFine(t, repos.Fines, primitive.NewObjectID(), member).ID)
+	}
+	mustAddFine(t, repos.Fines, primitive.NewObjectID(), primitive.NewObjectID())
+
+	var got []primitive.ObjectID
+	query := model.FineQuery{Filter: model.FineFilter{MemberID: member.Hex()}, Limit: 2}
+	for {
+		page, err := repos.Fines.PageFines(ctx, query)
+		if err != nil {
+			t.Fatalf("PageFines: %v", err)
+		}
+		if page.Total != 5 {
+			t.Errorf("PageFines total = %d, want 5", page.Total)
+		}
+		for _, fine := range page.Items {
+			got = append(got, fine.ID)
+		}
+		if page.Next == "" {
+			break
+		}
+		query.Cursor = page.Next
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("pages = %v, want %v", got, want)
+	}
+
+	_, err := repos.Fines.PageFines(ctx, model.FineQuery{Cursor: "not-a-cursor"})
+	expectKind(t, "PageFines with an invalid cursor", err, apperr.ErrValidation)
+}
+
+func testTransactionCommit(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	mustAddGame(t, repos.Games, dev, "Portal")
+
+	err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := repos.Games.DeleteManyGamesByDeveloper(ctx, dev.ID.Hex()); err != nil {
+			return err
+		}
+		return repos.Developers.DeleteDeveloper(ctx, dev.ID.Hex(), 0)
+	})
+	if err != nil {
+		t.Fatalf("WithinTransaction: %v", err)
+	}
+
+	if _, err := repos.Developers.GetDeveloperById(ctx, dev.ID.Hex()); err == nil {
+		t.Error("developer still exists after a committed delete")
+	}
+	all, err := repos.Games.GetAllGames(ctx)
+	if err != nil {
+		t.Fatalf("GetAllGames: %v", err)
+	}
+	if len(all) != 0 {
+		t.Errorf("%d games left after a committed cascade", len(all))
+	}
+}
+
+func testTransactionRollback(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	game := mustAddGame(t, repos.Games, dev, "Portal")
+	failure := errors.New("failure in the middle of the cascade")
+
+	err := repos.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := repos.Games.DeleteManyGamesByDeveloper(ctx, dev.ID.Hex()); err != nil {
+			return err
+		}
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithinTransaction = %v, want %v", err, failure)
+	}
+
+	if _, err := repos.Games.GetGameById(ctx, game.ID.Hex()); err != nil {
+		t.Errorf("game was deleted by a rolled back transaction: %v", err)
+	}
+	if _, err := repos.Developers.GetDeveloperById(ctx, dev.ID.Hex()); err != nil {
+		t.Errorf("developer is gone after a rolled back transaction: %v", err)
+	}
+}
+
+func testRollbackKeepsOtherWrites(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	dev := mustAddDeveloper(t, repos.Developers, "Valve", "Bellevue")
+	failure := errors.New("failure in the middle of the transaction")
+
+	var (
+		start   sync.Once
+		done    = make(chan struct{})
+		outside *model.Developer
+		addErr  error
+	)
+	err := repos.Transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+		if err := repos.Developers.DeleteDeveloper(txCtx, dev.ID.Hex(), 0); err != nil {
+			return err
+		}
+		start.Do(func() {
+			go func() {
+				defer close(done)
+				outside, addErr = repos.Developers.AddDeveloper(ctx, model.Developer{Name: "Bioware", MainHq: "Edmonton"})
+			}()
+		})
+		// Give the write outside the transaction time to run, or to wait for the transaction to end.
+		time.Sleep(50 * time.Millisecond)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithinTransaction = %v, want %v", err, failure)
+	}
+	<-done
+	if addErr != nil {
+		t.Fatalf("AddDeveloper outside the transaction: %v", addErr)
+	}
+
+	if _, err := repos.Developers.GetDeveloperById(ctx, outside.ID.Hex()); err != nil {
+		t.Errorf("developer added outside a transaction is gone after it rolled back: %v", err)
+	}
+	if _, err := repos.Developers.GetDeveloperById(ctx, dev.ID.Hex()); err != nil {
+		t.Errorf("developer is gone after a rolled back transaction: %v", err)
+	}
+}
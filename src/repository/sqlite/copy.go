@@ -0,0 +1,259 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errBarcodeTaken is returned when a write gives a copy the barcode of another one.
+var errBarcodeTaken = apperr.Conflict("barcode belongs to another copy")
+
+type CopyRepository struct {
+	db *sql.DB
+}
+
+// NewCopyRepository creates a new SQLite CopyRepository instance.
+// Takes a database handle returned by Open.
+// Returns the CopyRepositorer interface.
+func NewCopyRepository(db *sql.DB) _interface.CopyRepositorer {
+	return &CopyRepository{
+		db: db,
+	}
+}
+
+// copyColumns lists the copy columns in the order scanCopy reads them.
+const copyColumns = `id, game_id, barcode, condition, platform, shelf_location, available, version`
+
+// scanCopy reads a copy from a row holding copyColumns.
+func scanCopy(row interface{ Scan(...any) error }) (model.Copy, error) {
+	var gameCopy model.Copy
+	var id, gameID string
+	if err := row.Scan(&id, &gameID, &gameCopy.Barcode, &gameCopy.Condition, &gameCopy.Platform,
+		&gameCopy.ShelfLocation, &gameCopy.Available, &gameCopy.Version); err != nil {
+		return gameCopy, err
+	}
+	oid, err := model.ParseID(id)
+	if err != nil {
+		return gameCopy, err
+	}
+	gameOID, err := model.ParseID(gameID)
+	if err != nil {
+		return gameCopy, err
+	}
+	gameCopy.ID = oid
+	gameCopy.GameID = gameOID
+	return gameCopy, nil
+}
+
+// ListCopies retrieves the copies of a game from the table in the order they were added.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns a slice of Copy models or an error if the operation fails.
+func (r *CopyRepository) ListCopies(ctx context.Context, gameID string) ([]model.Copy, error) {
+	i, err := model.ParseID(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+copyColumns+` FROM copies WHERE game_id = ? ORDER BY id`, i.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	copies := make([]model.Copy, 0)
+	for rows.Next() {
+		gameCopy, err := scanCopy(rows)
+		if err != nil {
+			return nil, err
+		}
+		copies = append(copies, gameCopy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+// PageCopies retrieves one page of the copies of a game from the table in the order they were added.
+// Takes a context for managing request lifetime and a CopyQuery.
+// Returns a Page of Copy models or an error if the operation fails.
+func (r *CopyRepository) PageCopies(ctx context.Context, query model.CopyQuery) (*model.Page[model.Copy], error) {
+	i, err := model.ParseID(query.GameID)
+	if err != nil {
+		return nil, err
+	}
+	return queryPage(ctx, r.db, "copies", copyColumns, []string{"game_id = ?"}, []any{i.Hex()}, query.Cursor, query.Limit, scanCopy,
+		func(c model.Copy) primitive.ObjectID { return c.ID })
+}
+
+// GetCopyById retrieves a copy by its ID from the table.
+// Takes a context for managing request lifetime and the copy ID as a string.
+// Returns a Copy model or an error if the operation fails.
+func (r *CopyRepository) GetCopyById(ctx context.Context, id string) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryOne(ctx, `SELECT `+copyColumns+` FROM copies WHERE id = ?`, i.Hex())
+}
+
+// GetCopyByBarcode retrieves the copy with the given barcode from the table.
+// Takes a context for managing request lifetime and the barcode.
+// Returns a Copy model or an error if the operation fails.
+func (r *CopyRepository) GetCopyByBarcode(ctx context.Context, barcode string) (*model.Copy, error) {
+	return r.queryOne(ctx, `SELECT `+copyColumns+` FROM copies WHERE barcode = ?`, barcode)
+}
+
+// queryOne runs a query returning copyColumns and reads its first row,
+// or returns a NotFound error if there is none.
+func (r *CopyRepository) queryOne(ctx context.Context, query string, args ...any) (*model.Copy, error) {
+	gameCopy, err := scanCopy(conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("copy not found")
+		}
+		return nil, err
+	}
+	return &gameCopy, nil
+}
+
+// CountCopies counts the copies of a game in the table and how many of them are available.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns both counts or an error if the operation fails.
+func (r *CopyRepository) CountCopies(ctx context.Context, gameID string) (int, int, error) {
+	i, err := model.ParseID(gameID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var total, available int
+	err = conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(SUM(available), 0) FROM copies WHERE game_id = ?`, i.Hex()).Scan(&total, &available)
+	if err != nil {
+		return 0, 0, err
+	}
+	return total, available, nil
+}
+
+// AddCopy inserts a new copy into the table.
+// Takes a context for managing request lifetime and a Copy model.
+// Returns the inserted Copy model, or a Conflict error if its barcode is taken.
+func (r *CopyRepository) AddCopy(ctx context.Context, gameCopy model.Copy) (*model.Copy, error) {
+	gameCopy.ID = primitive.NewObjectID()
+	gameCopy.Version = 1
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO copies (`+copyColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		gameCopy.ID.Hex(), gameCopy.GameID.Hex(), gameCopy.Barcode, gameCopy.Condition, gameCopy.Platform,
+		gameCopy.ShelfLocation, gameCopy.Available, gameCopy.Version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, errBarcodeTaken
+		}
+		return nil, err
+	}
+
+	return &gameCopy, nil
+}
+
+// UpdateCopy replaces the barcode, condition, platform and shelf location of a copy in the table.
+// A non-zero gameCopy.Version must match the stored version.
+// Takes a context for managing request lifetime, the copy ID as a string, and a Copy model.
+// Returns the updated Copy model or an error if the operation fails.
+func (r *CopyRepository) UpdateCopy(ctx context.Context, id string, gameCopy model.Copy) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE copies SET barcode = ?, condition = ?, platform = ?, shelf_location = ?,
+		version = version + 1 WHERE id = ? AND (? = 0 OR version = ?) RETURNING `+copyColumns,
+		gameCopy.Barcode, gameCopy.Condition, gameCopy.Platform, gameCopy.ShelfLocation, i.Hex(), gameCopy.Version, gameCopy.Version)
+	updated, err := scanCopy(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.missOrMismatch(ctx, i.Hex(), gameCopy.Version)
+		}
+		if isUniqueViolation(err) {
+			return nil, errBarcodeTaken
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SetCopyAvailability marks a copy in the table as available or on loan.
+// The update only matches while the copy has the other value and, for a non-zero version, that version,
+// so it is a single atomic compare-and-set. Setting the value the copy already has changes nothing.
+// Takes a context for managing request lifetime, the copy ID as a string, the new availability and the expected version.
+// Returns the updated Copy model or an error if the copy is missing or its version does not match.
+func (r *CopyRepository) SetCopyAvailability(ctx context.Context, id string, available bool, version int64) (*model.Copy, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE copies SET available = ?, version = version + 1 WHERE id = ? AND available <> ? AND (? = 0 OR version = ?)`,
+		available, i.Hex(), available, version, version)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	gameCopy, err := r.GetCopyById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing matched: the copy is at another version or already has the value.
+	if affected == 0 && version != 0 && gameCopy.Version != version {
+		return nil, apperr.VersionMismatch("copy", gameCopy.Version, version)
+	}
+	return gameCopy, nil
+}
+
+// DeleteCopy removes a copy from the table by its ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the copy ID as a string and the expected version.
+// Returns an error if the operation fails or if no row is found.
+func (r *CopyRepository) DeleteCopy(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM copies WHERE id = ? AND (? = 0 OR version = ?)`,
+		i.Hex(), version, version)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return r.missOrMismatch(ctx, i.Hex(), version)
+	}
+	return nil
+}
+
+// missOrMismatch explains why a write filtered by ID and version matched no row:
+// it returns a NotFound error if the copy is missing and a version mismatch otherwise.
+func (r *CopyRepository) missOrMismatch(ctx context.Context, id string, version int64) error {
+	var current int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT version FROM copies WHERE id = ?`, id).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.NotFound("copy not found")
+		}
+		return err
+	}
+	return apperr.VersionMismatch("copy", current, version)
+}
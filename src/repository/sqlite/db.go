@@ -0,0 +1,201 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"time"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS developers (
+	id      TEXT PRIMARY KEY,
+	name    TEXT NOT NULL,
+	mainhq  TEXT NOT NULL,
+	version INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS games (
+	id               TEXT PRIMARY KEY,
+	title            TEXT NOT NULL,
+	developer_id     TEXT NOT NULL REFERENCES developers(id),
+	genre            TEXT NOT NULL,
+	year             INTEGER NOT NULL,
+	available        INTEGER NOT NULL,
+	version          INTEGER NOT NULL DEFAULT 0,
+	copies           INTEGER NOT NULL DEFAULT 0,
+	available_copies INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS games_developer_id ON games(developer_id);
+
+-- Games show their developer, so a change to the developer is a new version of each of its games.
+CREATE TRIGGER IF NOT EXISTS games_developer_updated AFTER UPDATE ON developers
+WHEN NEW.name IS NOT OLD.name OR NEW.mainhq IS NOT OLD.mainhq OR NEW.version IS NOT OLD.version
+BEGIN
+	UPDATE games SET version = version + 1 WHERE developer_id = NEW.id;
+END;
+
+-- Copies go with their game, so deleting a game deletes its copies.
+CREATE TABLE IF NOT EXISTS copies (
+	id             TEXT PRIMARY KEY,
+	game_id        TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+	barcode        TEXT NOT NULL UNIQUE,
+	condition      TEXT NOT NULL,
+	platform       TEXT NOT NULL,
+	shelf_location TEXT NOT NULL,
+	available      INTEGER NOT NULL,
+	version        INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS copies_game_id ON copies(game_id);
+
+CREATE TABLE IF NOT EXISTS members (
+	id          TEXT PRIMARY KEY,
+	name        TEXT NOT NULL,
+	email       TEXT NOT NULL DEFAULT '',
+	card_number TEXT NOT NULL DEFAULT '',
+	status      TEXT NOT NULL DEFAULT 'active',
+	version     INTEGER NOT NULL DEFAULT 0
+);
+
+-- Loans outlive the games and members they reference, so they carry no foreign keys.
+-- Times are Unix milliseconds; returned_at is NULL while the game is out.
+CREATE TABLE IF NOT EXISTS loans (
+	id             TEXT PRIMARY KEY,
+	game_id        TEXT NOT NULL,
+	copy_id        TEXT NOT NULL DEFAULT '',
+	member_id      TEXT NOT NULL,
+	checked_out_at INTEGER NOT NULL,
+	due_at         INTEGER NOT NULL,
+	returned_at    INTEGER,
+	overdue        INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS loans_member_id ON loans(member_id);
+
+-- Like loans, holds carry no foreign keys. Times are Unix milliseconds;
+-- copy_id is empty unless a copy is reserved for a ready hold.
+CREATE TABLE IF NOT EXISTS holds (
+	id         TEXT PRIMARY KEY,
+	game_id    TEXT NOT NULL,
+	member_id  TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	placed_at  INTEGER NOT NULL,
+	copy_id    TEXT NOT NULL DEFAULT '',
+	ready_at   INTEGER,
+	expires_at INTEGER,
+	closed_at  INTEGER,
+	version    INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS holds_game_id ON holds(game_id);
+
+-- A loan has at most one fine. Amounts are in cents, times Unix milliseconds.
+CREATE TABLE IF NOT EXISTS fines (
+	id          TEXT PRIMARY KEY,
+	loan_id     TEXT NOT NULL UNIQUE,
+	member_id   TEXT NOT NULL,
+	game_id     TEXT NOT NULL,
+	days_late   INTEGER NOT NULL,
+	amount      INTEGER NOT NULL,
+	paid        INTEGER NOT NULL,
+	status      TEXT NOT NULL,
+	assessed_at INTEGER NOT NULL,
+	closed_at   INTEGER,
+	version     INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS fines_member_id ON fines(member_id);
+`
+
+// indexes is run after addedColumns, so it can index columns that older database files only get from there.
+// Members registered before emails and card numbers existed have empty ones, which need not be unique.
+const indexes = `
+CREATE UNIQUE INDEX IF NOT EXISTS members_email ON members(email) WHERE email <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS members_card_number ON members(card_number) WHERE card_number <> '';
+
+-- A game without copies, or a copy, is lent to one member at a time.
+DROP INDEX IF EXISTS loans_active_game_id;
+CREATE UNIQUE INDEX IF NOT EXISTS loans_active_copy ON loans(game_id, copy_id) WHERE returned_at IS NULL;
+`
+
+// addedColumns lists the columns added to tables after they were first created.
+// Open adds the ones an older database file is missing.
+var addedColumns = []struct {
+	table, name, definition string
+}{
+	{"games", "version", "INTEGER NOT NULL DEFAULT 0"},
+	{"developers", "version", "INTEGER NOT NULL DEFAULT 0"},
+	{"members", "email", "TEXT NOT NULL DEFAULT ''"},
+	{"members", "card_number", "TEXT NOT NULL DEFAULT ''"},
+	{"members", "status", "TEXT NOT NULL DEFAULT 'active'"},
+	{"members", "version", "INTEGER NOT NULL DEFAULT 0"},
+	{"games", "copies", "INTEGER NOT NULL DEFAULT 0"},
+	{"games", "available_copies", "INTEGER NOT NULL DEFAULT 0"},
+	{"loans", "copy_id", "TEXT NOT NULL DEFAULT ''"},
+	{"loans", "overdue", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// Open opens the SQLite database file at path and creates the schema if it does not exist yet.
+// Foreign keys are enforced, so a game can only reference an existing developer.
+// Returns the database handle or an error if the file cannot be opened or migrated.
+func Open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite serializes writers anyway; a single connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(context.Background(), schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := addColumns(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.ExecContext(context.Background(), indexes); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// addColumns adds every column of addedColumns that its table does not have yet.
+func addColumns(ctx context.Context, db *sql.DB) error {
+	for _, c := range addedColumns {
+		var count int
+		err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, c.table, c.name).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, "ALTER TABLE "+c.table+" ADD COLUMN "+c.name+" "+c.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// millis converts a time to the Unix milliseconds stored in time columns.
+func millis(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+// fromMillis converts Unix milliseconds read from a time column to a UTC time.
+func fromMillis(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
+
+// isUniqueViolation reports whether err was caused by a write breaking a unique index.
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
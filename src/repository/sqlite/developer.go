@@ -0,0 +1,289 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DeveloperRepository struct {
+	db *sql.DB
+}
+
+// NewDeveloperRepository creates a new SQLite DeveloperRepository instance.
+// Takes a database handle returned by Open.
+// Returns the DeveloperRepositorer interface.
+func NewDeveloperRepository(db *sql.DB) _interface.DeveloperRepositorer {
+	return &DeveloperRepository{
+		db: db,
+	}
+}
+
+// developerColumns lists the developer columns in the order scanDeveloper reads them.
+const developerColumns = `id, name, mainhq, version`
+
+// scanDeveloper reads a developer from a row holding developerColumns.
+func scanDeveloper(row interface{ Scan(...any) error }) (model.Developer, error) {
+	var dev model.Developer
+	var id string
+	if err := row.Scan(&id, &dev.Name, &dev.MainHq, &dev.Version); err != nil {
+		return dev, err
+	}
+	oid, err := model.ParseID(id)
+	if err != nil {
+		return dev, err
+	}
+	dev.ID = oid
+	return dev, nil
+}
+
+// GetAllDevelopers retrieves all developers from the table.
+// Takes a context for managing request lifetime.
+// Returns a slice of Developer models or an error if the operation fails.
+func (r *DeveloperRepository) GetAllDevelopers(ctx context.Context) ([]model.Developer, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+developerColumns+` FROM developers ORDER BY rowid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devs := make([]model.Developer, 0)
+	for rows.Next() {
+		dev, err := scanDeveloper(rows)
+		if err != nil {
+			return nil, err
+		}
+		devs = append(devs, dev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return devs, nil
+}
+
+// ListDevelopers retrieves one page of developers matching the query from the table.
+// Takes a context for managing request lifetime and a DeveloperQuery.
+// Returns a Page of Developer models or an error if the operation fails.
+func (r *DeveloperRepository) ListDevelopers(ctx context.Context, query model.DeveloperQuery) (*model.Page[model.Developer], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "name")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	var where []string
+	var args []any
+	if query.Filter.MainHq != "" {
+		where = append(where, "mainhq = ?")
+		args = append(args, query.Filter.MainHq)
+	}
+	if query.Filter.NameSubstring != "" {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(query.Filter.NameSubstring)+"%")
+	}
+
+	var total int64
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM developers`+whereClause(where), args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	op, order := ">", "ASC"
+	if desc {
+		op, order = "<", "DESC"
+	}
+	if cursor != nil {
+		if _, err := primitive.ObjectIDFromHex(cursor.ID); err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		if field == "" {
+			where = append(where, "id "+op+" ?")
+			args = append(args, cursor.ID)
+		} else {
+			where = append(where, "(name "+op+" ? OR (name = ? AND id > ?))")
+			args = append(args, cursor.Str, cursor.Str, cursor.ID)
+		}
+	}
+
+	orderBy := " ORDER BY id " + order
+	if field != "" {
+		orderBy = " ORDER BY name " + order + ", id ASC"
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+developerColumns+` FROM developers`+whereClause(where)+orderBy+` LIMIT ?`, append(args, limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devs := make([]model.Developer, 0, limit+1)
+	for rows.Next() {
+		dev, err := scanDeveloper(rows)
+		if err != nil {
+			return nil, err
+		}
+		devs = append(devs, dev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[model.Developer]{Items: devs, Total: total}
+	if len(devs) > limit {
+		page.Items = devs[:limit]
+		last := page.Items[limit-1]
+		page.Next = paging.Encode(paging.Cursor{Sort: query.Sort, Str: last.Name, ID: last.ID.Hex()})
+	}
+
+	return page, nil
+}
+
+// StreamDevelopers calls fn with each developer matching the filter, in the order given by sort like ListDevelopers.
+// The developers are read a page at a time, so the connection is not held while fn runs. Iteration stops at the first error returned by fn.
+// Takes a context for managing request lifetime, a DeveloperFilter, a sort and the function to call.
+// Returns the error of fn or an error if the operation fails.
+func (r *DeveloperRepository) StreamDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, fn func(model.Developer) error) error {
+	query := model.DeveloperQuery{Filter: filter, Sort: sort, Limit: paging.MaxLimit}
+	for {
+		page, err := r.ListDevelopers(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, d := range page.Items {
+			if err := fn(d); err != nil {
+				return err
+			}
+		}
+		if page.Next == "" {
+			return nil
+		}
+		query.Cursor = page.Next
+	}
+}
+
+// GetDeveloperById retrieves a developer by their ID from the table.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns a Developer model or an error if the operation fails.
+func (r *DeveloperRepository) GetDeveloperById(ctx context.Context, id string) (*model.Developer, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+developerColumns+` FROM developers WHERE id = ?`, i.Hex())
+	dev, err := scanDeveloper(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("developer not found")
+		}
+		return nil, err
+	}
+
+	return &dev, nil
+}
+
+// AddDeveloper inserts a new developer into the table.
+// Takes a context for managing request lifetime and a Developer model.
+// Returns the inserted Developer model or an error if the operation fails.
+func (r *DeveloperRepository) AddDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
+	developer.ID = primitive.NewObjectID()
+	developer.Version = 1
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO developers (id, name, mainhq, version) VALUES (?, ?, ?, ?)`,
+		developer.ID.Hex(), developer.Name, developer.MainHq, developer.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &developer, nil
+}
+
+// UpdateDeveloper updates an existing developer in the table.
+// Games reference developers by key, so they see the change without being rewritten.
+// A non-zero developer.Version must match the stored version.
+// Takes a context for managing request lifetime, the developer ID as a string, and a Developer model.
+// Returns the updated Developer model or an error if the operation fails.
+func (r *DeveloperRepository) UpdateDeveloper(ctx context.Context, id string, developer model.Developer) (*model.Developer, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE developers SET name = ?, mainhq = ?, version = version + 1
+		WHERE id = ? AND (? = 0 OR version = ?) RETURNING `+developerColumns,
+		developer.Name, developer.MainHq, i.Hex(), developer.Version, developer.Version)
+	updated, err := scanDeveloper(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.missOrMismatch(ctx, i.Hex(), developer.Version, "developer id not found")
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// RestoreDeveloper writes a developer as it is, ID and version included, replacing the developer with the same ID.
+// The row is updated in place, so the games referencing it stay.
+// Takes a context for managing request lifetime and a Developer model.
+// Returns the written Developer model or an error if the operation fails.
+func (r *DeveloperRepository) RestoreDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO developers (id, name, mainhq, version) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET name = excluded.name, mainhq = excluded.mainhq, version = excluded.version`,
+		developer.ID.Hex(), developer.Name, developer.MainHq, developer.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &developer, nil
+}
+
+// DeleteDeveloper removes a developer from the table by their ID.
+// Fails with a foreign key error while games still reference the developer.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the developer ID as a string and the expected version.
+// Returns an error if the operation fails or if no row is found.
+func (r *DeveloperRepository) DeleteDeveloper(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM developers WHERE id = ? AND (? = 0 OR version = ?)`,
+		i.Hex(), version, version)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return r.missOrMismatch(ctx, i.Hex(), version, "developer not found")
+	}
+	return nil
+}
+
+// missOrMismatch explains why a write filtered by ID and version matched no row:
+// it returns a NotFound error with the given message if the developer is missing,
+// and a version mismatch otherwise.
+func (r *DeveloperRepository) missOrMismatch(ctx context.Context, id string, version int64, notFound string) error {
+	var current int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT version FROM developers WHERE id = ?`, id).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.NotFound("%s", notFound)
+		}
+		return err
+	}
+	return apperr.VersionMismatch("developer", current, version)
+}
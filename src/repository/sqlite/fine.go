@@ -0,0 +1,194 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FineRepository struct {
+	db *sql.DB
+}
+
+// NewFineRepository creates a new SQLite FineRepository instance.
+// Takes a database handle returned by Open.
+// Returns the FineRepositorer interface.
+func NewFineRepository(db *sql.DB) _interface.FineRepositorer {
+	return &FineRepository{
+		db: db,
+	}
+}
+
+// fineColumns lists the fine columns in the order scanFine reads them.
+const fineColumns = `id, loan_id, member_id, game_id, days_late, amount, paid, status, assessed_at, closed_at, version`
+
+// scanFine reads a fine from a row holding fineColumns.
+func scanFine(row interface{ Scan(...any) error }) (model.Fine, error) {
+	var fine model.Fine
+	var id, loanID, memberID, gameID string
+	var assessedAt int64
+	var closedAt sql.NullInt64
+	if err := row.Scan(&id, &loanID, &memberID, &gameID, &fine.DaysLate, &fine.Amount, &fine.Paid, &fine.Status,
+		&assessedAt, &closedAt, &fine.Version); err != nil {
+		return fine, err
+	}
+
+	var err error
+	if fine.ID, err = model.ParseID(id); err != nil {
+		return fine, err
+	}
+	if fine.LoanID, err = primitive.ObjectIDFromHex(loanID); err != nil {
+		return fine, err
+	}
+	if fine.MemberID, err = primitive.ObjectIDFromHex(memberID); err != nil {
+		return fine, err
+	}
+	if fine.GameID, err = primitive.ObjectIDFromHex(gameID); err != nil {
+		return fine, err
+	}
+	fine.AssessedAt = fromMillis(assessedAt)
+	fine.ClosedAt = nullTime(closedAt)
+	return fine, nil
+}
+
+// ListFines retrieves the fines matching the filter from the table, oldest first.
+// Takes a context for managing request lifetime and a FineFilter.
+// Returns a slice of Fine models or an error if the operation fails.
+func (r *FineRepository) ListFines(ctx context.Context, filter model.FineFilter) ([]model.Fine, error) {
+	where, args, err := fineWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+fineColumns+` FROM fines`+whereClause(where)+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fines := make([]model.Fine, 0)
+	for rows.Next() {
+		fine, err := scanFine(rows)
+		if err != nil {
+			return nil, err
+		}
+		fines = append(fines, fine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fines, nil
+}
+
+// PageFines retrieves one page of the fines matching the query from the table, oldest first.
+// Takes a context for managing request lifetime and a FineQuery.
+// Returns a Page of Fine models or an error if the operation fails.
+func (r *FineRepository) PageFines(ctx context.Context, query model.FineQuery) (*model.Page[model.Fine], error) {
+	where, args, err := fineWhere(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return queryPage(ctx, r.db, "fines", fineColumns, where, args, query.Cursor, query.Limit, scanFine,
+		func(f model.Fine) primitive.ObjectID { return f.ID })
+}
+
+// fineWhere builds the conditions and arguments selecting the fines that match filter.
+func fineWhere(filter model.FineFilter) ([]string, []any, error) {
+	var where []string
+	var args []any
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "member_id = ?")
+		args = append(args, id.Hex())
+	}
+	if filter.LoanID != "" {
+		id, err := model.ParseID(filter.LoanID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "loan_id = ?")
+		args = append(args, id.Hex())
+	}
+	if filter.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, filter.Status)
+	}
+	return where, args, nil
+}
+
+// GetFineById retrieves a fine by its ID from the table.
+// Takes a context for managing request lifetime and the fine ID as a string.
+// Returns a Fine model or an error if the operation fails.
+func (r *FineRepository) GetFineById(ctx context.Context, id string) (*model.Fine, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	fine, err := scanFine(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+fineColumns+` FROM fines WHERE id = ?`, i.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("fine not found")
+		}
+		return nil, err
+	}
+	return &fine, nil
+}
+
+// AddFine inserts a new fine into the table.
+// Takes a context for managing request lifetime and a Fine model.
+// Returns the inserted Fine model, or a Conflict error if the loan already has a fine.
+func (r *FineRepository) AddFine(ctx context.Context, fine model.Fine) (*model.Fine, error) {
+	fine.ID = primitive.NewObjectID()
+	fine.Version = 1
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO fines (`+fineColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		fine.ID.Hex(), fine.LoanID.Hex(), fine.MemberID.Hex(), fine.GameID.Hex(), fine.DaysLate, fine.Amount, fine.Paid,
+		fine.Status, millis(fine.AssessedAt), nullMillis(fine.ClosedAt), fine.Version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, apperr.Conflict("loan already has a fine")
+		}
+		return nil, err
+	}
+
+	return &fine, nil
+}
+
+// UpdateFine replaces the days late, amounts, status and times of a fine in the table.
+// A non-zero fine.Version must match the stored version.
+// Takes a context for managing request lifetime, the fine ID as a string, and a Fine model.
+// Returns the updated Fine model or an error if the operation fails.
+func (r *FineRepository) UpdateFine(ctx context.Context, id string, fine model.Fine) (*model.Fine, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE fines SET days_late = ?, amount = ?, paid = ?, status = ?, assessed_at = ?,
+		closed_at = ?, version = version + 1 WHERE id = ? AND (? = 0 OR version = ?) RETURNING `+fineColumns,
+		fine.DaysLate, fine.Amount, fine.Paid, fine.Status, millis(fine.AssessedAt), nullMillis(fine.ClosedAt),
+		i.Hex(), fine.Version, fine.Version)
+	updated, err := scanFine(row)
+	if err == nil {
+		return &updated, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	current, err := r.GetFineById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return nil, apperr.VersionMismatch("fine", current.Version, fine.Version)
+}
@@ -0,0 +1,584 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"strings"
+)
+
+// gameColumns maps the sortable game fields to their columns.
+var gameColumns = map[string]string{
+	"title": "g.title",
+	"genre": "g.genre",
+	"year":  "g.year",
+}
+
+// selectGames joins every game with the developer it references.
+const selectGames = `SELECT g.id, g.title, g.genre, g.year, g.available, g.copies, g.available_copies, g.version,
+	d.id, d.name, d.mainhq, d.version
+FROM games g JOIN developers d ON d.id = g.developer_id`
+
+type GameRepository struct {
+	db *sql.DB
+}
+
+// NewGameRepository creates a new SQLite GameRepository instance.
+// Takes a database handle returned by Open.
+// Returns the GameRepositorer interface.
+func NewGameRepository(db *sql.DB) _interface.GameRepositorer {
+	return &GameRepository{
+		db: db,
+	}
+}
+
+// scanGame reads a game and its developer from a row produced by selectGames.
+func scanGame(row interface{ Scan(...any) error }) (model.Game, error) {
+	var game model.Game
+	var id, devID string
+	err := row.Scan(&id, &game.Title, &game.Genre, &game.PublicationYear, &game.Available, &game.Copies, &game.AvailableCopies,
+		&game.Version, &devID, &game.Developer.Name, &game.Developer.MainHq, &game.Developer.Version)
+	if err != nil {
+		return game, err
+	}
+	if game.ID, err = model.ParseID(id); err != nil {
+		return game, err
+	}
+	if game.Developer.ID, err = primitive.ObjectIDFromHex(devID); err != nil {
+		return game, err
+	}
+	return game, nil
+}
+
+// queryGames runs a query built on selectGames and collects the resulting games.
+func (r *GameRepository) queryGames(ctx context.Context, query string, args ...any) ([]model.Game, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := make([]model.Game, 0)
+	for rows.Next() {
+		game, err := scanGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return games, nil
+}
+
+// GetAllGames retrieves all games from the table.
+// Takes a context for managing request lifetime.
+// Returns a slice of Game models or an error if the operation fails.
+func (r *GameRepository) GetAllGames(ctx context.Context) ([]model.Game, error) {
+	return r.queryGames(ctx, selectGames+` ORDER BY g.rowid`)
+}
+
+// ListGames retrieves one page of games matching the query from the table.
+// Takes a context for managing request lifetime and a GameQuery.
+// Returns a Page of Game models or an error if the operation fails.
+func (r *GameRepository) ListGames(ctx context.Context, query model.GameQuery) (*model.Page[model.Game], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "title", "genre", "year")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	where, args, err := gameWhere(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM games g`+whereClause(where), args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	column := gameColumns[field]
+	op, order := ">", "ASC"
+	if desc {
+		op, order = "<", "DESC"
+	}
+	if cursor != nil {
+		if _, err := primitive.ObjectIDFromHex(cursor.ID); err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		var value any = cursor.Str
+		if field == "year" {
+			value = cursor.Num
+		}
+		if column == "" {
+			where = append(where, "g.id "+op+" ?")
+			args = append(args, cursor.ID)
+		} else {
+			where = append(where, "("+column+" "+op+" ? OR ("+column+" = ? AND g.id > ?))")
+			args = append(args, value, value, cursor.ID)
+		}
+	}
+
+	orderBy := " ORDER BY g.id " + order
+	if column != "" {
+		orderBy = " ORDER BY " + column + " " + order + ", g.id ASC"
+	}
+	games, err := r.queryGames(ctx, selectGames+whereClause(where)+orderBy+" LIMIT ?", append(args, limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[model.Game]{Items: games, Total: total}
+	if len(games) > limit {
+		page.Items = games[:limit]
+		last := page.Items[limit-1]
+		next := paging.Cursor{Sort: query.Sort, ID: last.ID.Hex()}
+		switch field {
+		case "title":
+			next.Str = last.Title
+		case "genre":
+			next.Str = last.Genre
+		case "year":
+			next.Num = last.PublicationYear
+		}
+		page.Next = paging.Encode(next)
+	}
+
+	return page, nil
+}
+
+// StreamGames calls fn with each game matching the filter, in the order given by sort like ListGames.
+// The games are read a page at a time, so the connection is not held while fn runs. Iteration stops at the first error returned by fn.
+// Takes a context for managing request lifetime, a GameFilter, a sort and the function to call.
+// Returns the error of fn or an error if the operation fails.
+func (r *GameRepository) StreamGames(ctx context.Context, filter model.GameFilter, sort string, fn func(model.Game) error) error {
+	query := model.GameQuery{Filter: filter, Sort: sort, Limit: paging.MaxLimit}
+	for {
+		page, err := r.ListGames(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, g := range page.Items {
+			if err := fn(g); err != nil {
+				return err
+			}
+		}
+		if page.Next == "" {
+			return nil
+		}
+		query.Cursor = page.Next
+	}
+}
+
+// gameWhere translates a GameFilter into SQL conditions on the games table aliased as g.
+func gameWhere(f model.GameFilter) ([]string, []any, error) {
+	var where []string
+	var args []any
+	if f.Genre != "" {
+		where = append(where, "g.genre = ?")
+		args = append(args, f.Genre)
+	}
+	if f.YearFrom != 0 {
+		where = append(where, "g.year >= ?")
+		args = append(args, f.YearFrom)
+	}
+	if f.YearTo != 0 {
+		where = append(where, "g.year <= ?")
+		args = append(args, f.YearTo)
+	}
+	if f.Available != nil {
+		where = append(where, "g.available = ?")
+		args = append(args, *f.Available)
+	}
+	if f.DeveloperID != "" {
+		id, err := model.ParseID(f.DeveloperID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "g.developer_id = ?")
+		args = append(args, id.Hex())
+	}
+	if f.TitlePrefix != "" {
+		where = append(where, `g.title LIKE ? ESCAPE '\'`)
+		args = append(args, likeEscaper.Replace(f.TitlePrefix)+"%")
+	}
+	return where, args, nil
+}
+
+// likeEscaper escapes the LIKE wildcards so user input only matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// whereClause joins conditions into a WHERE clause, or returns an empty string when there are none.
+func whereClause(where []string) string {
+	if len(where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(where, " AND ")
+}
+
+// queryPage retrieves the page of rows of table matching where that follows the cursor token, in creation order.
+// scan reads a row selected with columns and id returns its ID, which the cursor of the next page points at.
+func queryPage[T any](ctx context.Context, db *sql.DB, table, columns string, where []string, args []any, token string, limit int,
+	scan func(row interface{ Scan(...any) error }) (T, error), id func(T) primitive.ObjectID) (*model.Page[T], error) {
+	cursor, err := paging.Decode(token, "")
+	if err != nil {
+		return nil, err
+	}
+	limit = paging.Limit(limit)
+
+	var total int64
+	err = conn(ctx, db).QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+whereClause(where), args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != nil {
+		if _, err := primitive.ObjectIDFromHex(cursor.ID); err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		where = append(where, "id > ?")
+		args = append(args, cursor.ID)
+	}
+	rows, err := conn(ctx, db).QueryContext(ctx,
+		`SELECT `+columns+` FROM `+table+whereClause(where)+` ORDER BY id LIMIT ?`, append(args, limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]T, 0, limit+1)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[T]{Items: items, Total: total}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.Next = paging.Encode(paging.Cursor{ID: id(page.Items[limit-1]).Hex()})
+	}
+
+	return page, nil
+}
+
+// GetGameById retrieves a game by its ID from the table.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns a Game model or an error if the operation fails.
+func (r *GameRepository) GetGameById(ctx context.Context, id string) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := scanGame(conn(ctx, r.db).QueryRowContext(ctx, selectGames+` WHERE g.id = ?`, i.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("game not found")
+		}
+		return nil, err
+	}
+
+	return &game, nil
+}
+
+// AddGame inserts a new game into the table.
+// Only the developer ID is stored; the returned game carries the developer as it is stored.
+// Takes a context for managing request lifetime and a Game model.
+// Returns the inserted Game model or an error if the operation fails.
+func (r *GameRepository) AddGame(ctx context.Context, game model.Game) (*model.Game, error) {
+	developer, err := scanDeveloper(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+developerColumns+` FROM developers WHERE id = ?`, game.Developer.ID.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.Validation("developer does not exist")
+		}
+		return nil, err
+	}
+	game.Developer = developer
+	game.ID = primitive.NewObjectID()
+	game.Version = 1
+
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO games (id, title, developer_id, genre, year, available, copies, available_copies, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		game.ID.Hex(), game.Title, developer.ID.Hex(), game.Genre, game.PublicationYear, game.Available,
+		game.Copies, game.AvailableCopies, game.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &game, nil
+}
+
+// UpdateGame replaces the title, developer, genre, year and availability of a game in the table.
+// A non-zero game.Version must match the stored version.
+// Takes a context for managing request lifetime, the game ID as a string and the new Game model.
+// Returns the updated Game model or an error if the game or developer is missing or the version does not match.
+func (r *GameRepository) UpdateGame(ctx context.Context, id string, game model.Game) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var devID string
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT id FROM developers WHERE id = ?`, game.Developer.ID.Hex()).Scan(&devID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.Validation("developer does not exist")
+		}
+		return nil, err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE games SET title = ?, developer_id = ?, genre = ?, year = ?, available = ?, version = version + 1
+		WHERE id = ? AND (? = 0 OR version = ?)`,
+		game.Title, devID, game.Genre, game.PublicationYear, game.Available, i.Hex(), game.Version, game.Version)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := r.GetGameById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing matched although the game exists, so it is at another version.
+	if affected == 0 {
+		return nil, apperr.VersionMismatch("game", updated.Version, game.Version)
+	}
+	return updated, nil
+}
+
+// RestoreGame writes a game as it is, ID, version and copy counts included, replacing the game with the same ID.
+// The row is updated in place, so the copies of the game stay.
+// Takes a context for managing request lifetime and a Game model.
+// Returns the written Game model or an error if the developer is missing or the operation fails.
+func (r *GameRepository) RestoreGame(ctx context.Context, game model.Game) (*model.Game, error) {
+	developer, err := scanDeveloper(conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT `+developerColumns+` FROM developers WHERE id = ?`, game.Developer.ID.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.Validation("developer does not exist")
+		}
+		return nil, err
+	}
+	game.Developer = developer
+
+	_, err = conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO games (id, title, developer_id, genre, year, available, copies, available_copies, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET title = excluded.title, developer_id = excluded.developer_id, genre = excluded.genre,
+			year = excluded.year, available = excluded.available, copies = excluded.copies,
+			available_copies = excluded.available_copies, version = excluded.version`,
+		game.ID.Hex(), game.Title, developer.ID.Hex(), game.Genre, game.PublicationYear, game.Available,
+		game.Copies, game.AvailableCopies, game.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &game, nil
+}
+
+// UpdateAvailability toggles the availability of a game in the table.
+// Takes a context for managing request lifetime and the game ID as a string.
+// Returns the updated Game model or an error if the operation fails.
+func (r *GameRepository) UpdateAvailability(ctx context.Context, id string) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE games SET available = NOT available, version = version + 1 WHERE id = ?`, i.Hex())
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	return r.GetGameById(ctx, id)
+}
+
+// SetAvailability sets the availability of a game in the table to an explicit value.
+// The update only matches while the game has the other value and, for a non-zero version, that version,
+// so it is a single atomic compare-and-set. Setting the value the game already has changes nothing.
+// Takes a context for managing request lifetime, the game ID as a string, the new availability and the expected version.
+// Returns the updated Game model or an error if the game is missing or its version does not match.
+func (r *GameRepository) SetAvailability(ctx context.Context, id string, available bool, version int64) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE games SET available = ?, version = version + 1 WHERE id = ? AND available <> ? AND (? = 0 OR version = ?)`,
+		available, i.Hex(), available, version, version)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := r.GetGameById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing matched: the game is at another version or already has the value.
+	if affected == 0 && version != 0 && game.Version != version {
+		return nil, apperr.VersionMismatch("game", game.Version, version)
+	}
+	return game, nil
+}
+
+// SetCopyCounts stores the number of copies of a game and how many of them are available in the table.
+// The game is available exactly when one of its copies is.
+// Takes a context for managing request lifetime, the game ID as a string and the two counts.
+// Returns the updated Game model or an error if the game is missing.
+func (r *GameRepository) SetCopyCounts(ctx context.Context, id string, copies int, available int) (*model.Game, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	result, err := conn(ctx, r.db).ExecContext(ctx,
+		`UPDATE games SET copies = ?, available_copies = ?, available = ?, version = version + 1 WHERE id = ?`,
+		copies, available, available > 0, i.Hex())
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, apperr.NotFound("game not found")
+	}
+	return r.GetGameById(ctx, id)
+}
+
+// DeleteGame removes a game and, through the foreign key, its copies from the table.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the game ID as a string and the expected version.
+// Returns an error if the operation fails.
+func (r *GameRepository) DeleteGame(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM games WHERE id = ? AND (? = 0 OR version = ?)`,
+		i.Hex(), version, version)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		current, err := r.GetGameById(ctx, id)
+		if err != nil {
+			return err
+		}
+		return apperr.VersionMismatch("game", current.Version, version)
+	}
+	return nil
+}
+
+// FindGamesByDeveloper retrieves all games by a developer from the table.
+// Takes a context for managing request lifetime and the developer name as a string.
+// Returns a slice of Game models or an error if the operation fails.
+func (r *GameRepository) FindGamesByDeveloper(ctx context.Context, developerName string) ([]model.Game, error) {
+	var devID string
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT id FROM developers WHERE name = ? ORDER BY rowid LIMIT 1`, developerName).Scan(&devID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("developer does not exist")
+		}
+		return nil, err
+	}
+
+	return r.queryGames(ctx, selectGames+` WHERE g.developer_id = ? ORDER BY g.rowid`, devID)
+}
+
+// DeleteManyGamesByDeveloper removes all games by a developer and their copies from the table.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns an error if the operation fails.
+func (r *GameRepository) DeleteManyGamesByDeveloper(ctx context.Context, developerId string) error {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, `DELETE FROM games WHERE developer_id = ?`, id.Hex())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountGamesByDeveloper counts the games by a developer in the table.
+// Takes a context for managing request lifetime and the developer ID as a string.
+// Returns the number of games or an error if the operation fails.
+func (r *GameRepository) CountGamesByDeveloper(ctx context.Context, developerId string) (int64, error) {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM games WHERE developer_id = ?`, id.Hex()).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ReassignGames moves all games by a developer to another developer in the table.
+// Takes a context for managing request lifetime, the current developer ID as a string, and the new Developer model.
+// Returns an error if the operation fails.
+func (r *GameRepository) ReassignGames(ctx context.Context, developerId string, developer model.Developer) error {
+	id, err := model.ParseID(developerId)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, `UPDATE games SET developer_id = ?, version = version + 1 WHERE developer_id = ?`,
+		developer.ID.Hex(), id.Hex())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateGamesDeveloper is a no-op for SQLite.
+// Games only store the developer ID and read the developer through a join, so they never diverge,
+// and the games_developer_updated trigger bumps their versions when the developer changes.
+// Returns zero changed games.
+func (r *GameRepository) UpdateGamesDeveloper(ctx context.Context, developer model.Developer) (int64, error) {
+	return 0, nil
+}
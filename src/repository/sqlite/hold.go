@@ -0,0 +1,226 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type HoldRepository struct {
+	db *sql.DB
+}
+
+// NewHoldRepository creates a new SQLite HoldRepository instance.
+// Takes a database handle returned by Open.
+// Returns the HoldRepositorer interface.
+func NewHoldRepository(db *sql.DB) _interface.HoldRepositorer {
+	return &HoldRepository{
+		db: db,
+	}
+}
+
+// holdColumns lists the hold columns in the order scanHold reads them.
+const holdColumns = `id, game_id, member_id, status, placed_at, copy_id, ready_at, expires_at, closed_at, version`
+
+// scanHold reads a hold from a row holding holdColumns.
+func scanHold(row interface{ Scan(...any) error }) (model.Hold, error) {
+	var hold model.Hold
+	var id, gameID, memberID, copyID string
+	var placedAt int64
+	var readyAt, expiresAt, closedAt sql.NullInt64
+	if err := row.Scan(&id, &gameID, &memberID, &hold.Status, &placedAt, &copyID, &readyAt, &expiresAt, &closedAt, &hold.Version); err != nil {
+		return hold, err
+	}
+
+	var err error
+	if hold.ID, err = model.ParseID(id); err != nil {
+		return hold, err
+	}
+	if hold.GameID, err = primitive.ObjectIDFromHex(gameID); err != nil {
+		return hold, err
+	}
+	if hold.MemberID, err = primitive.ObjectIDFromHex(memberID); err != nil {
+		return hold, err
+	}
+	if copyID != "" {
+		oid, err := primitive.ObjectIDFromHex(copyID)
+		if err != nil {
+			return hold, err
+		}
+		hold.CopyID = &oid
+	}
+	hold.PlacedAt = fromMillis(placedAt)
+	hold.ReadyAt = nullTime(readyAt)
+	hold.ExpiresAt = nullTime(expiresAt)
+	hold.ClosedAt = nullTime(closedAt)
+	return hold, nil
+}
+
+// nullTime converts a nullable millisecond column to a time, or nil for NULL.
+func nullTime(ms sql.NullInt64) *time.Time {
+	if !ms.Valid {
+		return nil
+	}
+	t := fromMillis(ms.Int64)
+	return &t
+}
+
+// nullMillis converts an optional time to a nullable millisecond column.
+func nullMillis(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: millis(*t), Valid: true}
+}
+
+// ListHolds retrieves the holds matching the filter from the table in the order they were placed.
+// Takes a context for managing request lifetime and a HoldFilter.
+// Returns a slice of Hold models or an error if the operation fails.
+func (r *HoldRepository) ListHolds(ctx context.Context, filter model.HoldFilter) ([]model.Hold, error) {
+	where, args, err := holdWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+holdColumns+` FROM holds`+whereClause(where)+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds := make([]model.Hold, 0)
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, err
+		}
+		holds = append(holds, hold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// PageHolds retrieves one page of the holds matching the query from the table in the order they were placed.
+// Takes a context for managing request lifetime and a HoldQuery.
+// Returns a Page of Hold models or an error if the operation fails.
+func (r *HoldRepository) PageHolds(ctx context.Context, query model.HoldQuery) (*model.Page[model.Hold], error) {
+	where, args, err := holdWhere(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return queryPage(ctx, r.db, "holds", holdColumns, where, args, query.Cursor, query.Limit, scanHold,
+		func(h model.Hold) primitive.ObjectID { return h.ID })
+}
+
+// holdWhere builds the conditions and arguments selecting the holds that match filter.
+func holdWhere(filter model.HoldFilter) ([]string, []any, error) {
+	var where []string
+	var args []any
+	if filter.GameID != "" {
+		id, err := model.ParseID(filter.GameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "game_id = ?")
+		args = append(args, id.Hex())
+	}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "member_id = ?")
+		args = append(args, id.Hex())
+	}
+	if filter.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if !filter.ExpiredBy.IsZero() {
+		where = append(where, "expires_at <= ?")
+		args = append(args, millis(filter.ExpiredBy))
+	}
+	return where, args, nil
+}
+
+// GetHoldById retrieves a hold by its ID from the table.
+// Takes a context for managing request lifetime and the hold ID as a string.
+// Returns a Hold model or an error if the operation fails.
+func (r *HoldRepository) GetHoldById(ctx context.Context, id string) (*model.Hold, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := scanHold(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+holdColumns+` FROM holds WHERE id = ?`, i.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("hold not found")
+		}
+		return nil, err
+	}
+	return &hold, nil
+}
+
+// AddHold inserts a new hold into the table.
+// Takes a context for managing request lifetime and a Hold model.
+// Returns the inserted Hold model or an error if the operation fails.
+func (r *HoldRepository) AddHold(ctx context.Context, hold model.Hold) (*model.Hold, error) {
+	hold.ID = primitive.NewObjectID()
+	hold.Version = 1
+
+	var copyID string
+	if hold.CopyID != nil {
+		copyID = hold.CopyID.Hex()
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO holds (`+holdColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		hold.ID.Hex(), hold.GameID.Hex(), hold.MemberID.Hex(), hold.Status, millis(hold.PlacedAt), copyID,
+		nullMillis(hold.ReadyAt), nullMillis(hold.ExpiresAt), nullMillis(hold.ClosedAt), hold.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// UpdateHold replaces the status, reserved copy and times of a hold in the table.
+// A non-zero hold.Version must match the stored version.
+// Takes a context for managing request lifetime, the hold ID as a string, and a Hold model.
+// Returns the updated Hold model or an error if the operation fails.
+func (r *HoldRepository) UpdateHold(ctx context.Context, id string, hold model.Hold) (*model.Hold, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var copyID string
+	if hold.CopyID != nil {
+		copyID = hold.CopyID.Hex()
+	}
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE holds SET status = ?, copy_id = ?, ready_at = ?, expires_at = ?, closed_at = ?,
+		version = version + 1 WHERE id = ? AND (? = 0 OR version = ?) RETURNING `+holdColumns,
+		hold.Status, copyID, nullMillis(hold.ReadyAt), nullMillis(hold.ExpiresAt), nullMillis(hold.ClosedAt),
+		i.Hex(), hold.Version, hold.Version)
+	updated, err := scanHold(row)
+	if err == nil {
+		return &updated, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	current, err := r.GetHoldById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return nil, apperr.VersionMismatch("hold", current.Version, hold.Version)
+}
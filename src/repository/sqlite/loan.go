@@ -0,0 +1,233 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+type LoanRepository struct {
+	db *sql.DB
+}
+
+// NewLoanRepository creates a new SQLite LoanRepository instance.
+// Takes a database handle returned by Open.
+// Returns the LoanRepositorer interface.
+func NewLoanRepository(db *sql.DB) _interface.LoanRepositorer {
+	return &LoanRepository{
+		db: db,
+	}
+}
+
+// loanColumns lists the loan columns in the order scanLoan reads them.
+// copy_id is empty for loans of games without copies.
+const loanColumns = `id, game_id, copy_id, member_id, checked_out_at, due_at, returned_at, overdue`
+
+// scanLoan reads a loan from a row holding loanColumns.
+func scanLoan(row interface{ Scan(...any) error }) (model.Loan, error) {
+	var loan model.Loan
+	var id, gameID, copyID, memberID string
+	var checkedOutAt, dueAt int64
+	var returnedAt sql.NullInt64
+	if err := row.Scan(&id, &gameID, &copyID, &memberID, &checkedOutAt, &dueAt, &returnedAt, &loan.Overdue); err != nil {
+		return loan, err
+	}
+
+	var err error
+	if loan.ID, err = model.ParseID(id); err != nil {
+		return loan, err
+	}
+	if loan.GameID, err = primitive.ObjectIDFromHex(gameID); err != nil {
+		return loan, err
+	}
+	if copyID != "" {
+		oid, err := primitive.ObjectIDFromHex(copyID)
+		if err != nil {
+			return loan, err
+		}
+		loan.CopyID = &oid
+	}
+	if loan.MemberID, err = primitive.ObjectIDFromHex(memberID); err != nil {
+		return loan, err
+	}
+	loan.CheckedOutAt = fromMillis(checkedOutAt)
+	loan.DueAt = fromMillis(dueAt)
+	if returnedAt.Valid {
+		t := fromMillis(returnedAt.Int64)
+		loan.ReturnedAt = &t
+	}
+	return loan, nil
+}
+
+// ListLoans retrieves the loans matching the filter from the table, oldest first.
+// Takes a context for managing request lifetime and a LoanFilter.
+// Returns a slice of Loan models or an error if the operation fails.
+func (r *LoanRepository) ListLoans(ctx context.Context, filter model.LoanFilter) ([]model.Loan, error) {
+	where, args, err := loanWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT `+loanColumns+` FROM loans`+whereClause(where)+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	loans := make([]model.Loan, 0)
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, loan)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// PageLoans retrieves one page of the loans matching the query from the table, oldest first.
+// Takes a context for managing request lifetime and a LoanQuery.
+// Returns a Page of Loan models or an error if the operation fails.
+func (r *LoanRepository) PageLoans(ctx context.Context, query model.LoanQuery) (*model.Page[model.Loan], error) {
+	where, args, err := loanWhere(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return queryPage(ctx, r.db, "loans", loanColumns, where, args, query.Cursor, query.Limit, scanLoan,
+		func(l model.Loan) primitive.ObjectID { return l.ID })
+}
+
+// loanWhere builds the conditions and arguments selecting the loans that match filter.
+func loanWhere(filter model.LoanFilter) ([]string, []any, error) {
+	var where []string
+	var args []any
+	if filter.GameID != "" {
+		id, err := model.ParseID(filter.GameID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "game_id = ?")
+		args = append(args, id.Hex())
+	}
+	if filter.MemberID != "" {
+		id, err := model.ParseID(filter.MemberID)
+		if err != nil {
+			return nil, nil, err
+		}
+		where = append(where, "member_id = ?")
+		args = append(args, id.Hex())
+	}
+	if filter.Active != nil {
+		if *filter.Active {
+			where = append(where, "returned_at IS NULL")
+		} else {
+			where = append(where, "returned_at IS NOT NULL")
+		}
+	}
+	if filter.Overdue != nil {
+		where = append(where, "overdue = ?")
+		args = append(args, *filter.Overdue)
+	}
+	if !filter.DueBefore.IsZero() {
+		where = append(where, "due_at < ?")
+		args = append(args, millis(filter.DueBefore))
+	}
+	return where, args, nil
+}
+
+// GetLoanById retrieves a loan by its ID from the table.
+// Takes a context for managing request lifetime and the loan ID as a string.
+// Returns a Loan model or an error if the operation fails.
+func (r *LoanRepository) GetLoanById(ctx context.Context, id string) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	loan, err := scanLoan(conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+loanColumns+` FROM loans WHERE id = ?`, i.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("loan not found")
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
+
+// AddLoan inserts a new loan into the table.
+// Takes a context for managing request lifetime and a Loan model.
+// Returns the inserted Loan model or an error if the operation fails.
+func (r *LoanRepository) AddLoan(ctx context.Context, loan model.Loan) (*model.Loan, error) {
+	loan.ID = primitive.NewObjectID()
+
+	var returnedAt sql.NullInt64
+	if loan.ReturnedAt != nil {
+		returnedAt = sql.NullInt64{Int64: millis(*loan.ReturnedAt), Valid: true}
+	}
+	var copyID string
+	if loan.CopyID != nil {
+		copyID = loan.CopyID.Hex()
+	}
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO loans (`+loanColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		loan.ID.Hex(), loan.GameID.Hex(), copyID, loan.MemberID.Hex(), millis(loan.CheckedOutAt), millis(loan.DueAt), returnedAt, loan.Overdue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loan, nil
+}
+
+// CloseLoan marks a loan as returned at the given time.
+// Takes a context for managing request lifetime, the loan ID as a string and the return time.
+// Returns the updated Loan model, or a Conflict error if the loan was already returned.
+func (r *LoanRepository) CloseLoan(ctx context.Context, id string, returnedAt time.Time) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	loan, err := scanLoan(conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE loans SET returned_at = ? WHERE id = ? AND returned_at IS NULL RETURNING `+loanColumns,
+		millis(returnedAt), i.Hex()))
+	if err == nil {
+		return &loan, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if _, err := r.GetLoanById(ctx, id); err != nil {
+		return nil, err
+	}
+	return nil, apperr.Conflict("loan is already returned")
+}
+
+// MarkOverdue marks a loan as overdue. Marking a loan that is already overdue changes nothing.
+// Takes a context for managing request lifetime and the loan ID as a string.
+// Returns the updated Loan model or an error if the loan is missing.
+func (r *LoanRepository) MarkOverdue(ctx context.Context, id string) (*model.Loan, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	loan, err := scanLoan(conn(ctx, r.db).QueryRowContext(ctx,
+		`UPDATE loans SET overdue = 1 WHERE id = ? RETURNING `+loanColumns, i.Hex()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("loan not found")
+		}
+		return nil, err
+	}
+	return &loan, nil
+}
@@ -0,0 +1,249 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/paging"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// errMemberNotUnique is returned when a write gives a member the email or card number of another one.
+var errMemberNotUnique = apperr.Conflict("email or card number belongs to another member")
+
+type MemberRepository struct {
+	db *sql.DB
+}
+
+// NewMemberRepository creates a new SQLite MemberRepository instance.
+// Takes a database handle returned by Open.
+// Returns the MemberRepositorer interface.
+func NewMemberRepository(db *sql.DB) _interface.MemberRepositorer {
+	return &MemberRepository{
+		db: db,
+	}
+}
+
+// memberColumns lists the member columns in the order scanMember reads them.
+const memberColumns = `id, name, email, card_number, status, version`
+
+// scanMember reads a member from a row holding memberColumns.
+func scanMember(row interface{ Scan(...any) error }) (model.Member, error) {
+	var member model.Member
+	var id string
+	if err := row.Scan(&id, &member.Name, &member.Email, &member.CardNumber, &member.Status, &member.Version); err != nil {
+		return member, err
+	}
+	oid, err := model.ParseID(id)
+	if err != nil {
+		return member, err
+	}
+	member.ID = oid
+	return member, nil
+}
+
+// ListMembers retrieves one page of members matching the query from the table.
+// Takes a context for managing request lifetime and a MemberQuery.
+// Returns a Page of Member models or an error if the operation fails.
+func (r *MemberRepository) ListMembers(ctx context.Context, query model.MemberQuery) (*model.Page[model.Member], error) {
+	field, desc, err := paging.ParseSort(query.Sort, "name")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := paging.Decode(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := paging.Limit(query.Limit)
+
+	var where []string
+	var args []any
+	if query.Filter.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, query.Filter.Status)
+	}
+	if query.Filter.NameSubstring != "" {
+		where = append(where, `name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(query.Filter.NameSubstring)+"%")
+	}
+
+	var total int64
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM members`+whereClause(where), args...).Scan(&total)
+	if err != nil {
+		return nil, err
+	}
+
+	op, order := ">", "ASC"
+	if desc {
+		op, order = "<", "DESC"
+	}
+	if cursor != nil {
+		if _, err := primitive.ObjectIDFromHex(cursor.ID); err != nil {
+			return nil, paging.ErrInvalidCursor
+		}
+		if field == "" {
+			where = append(where, "id "+op+" ?")
+			args = append(args, cursor.ID)
+		} else {
+			where = append(where, "(name "+op+" ? OR (name = ? AND id > ?))")
+			args = append(args, cursor.Str, cursor.Str, cursor.ID)
+		}
+	}
+
+	orderBy := " ORDER BY id " + order
+	if field != "" {
+		orderBy = " ORDER BY name " + order + ", id ASC"
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx,
+		`SELECT `+memberColumns+` FROM members`+whereClause(where)+orderBy+` LIMIT ?`, append(args, limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]model.Member, 0, limit+1)
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &model.Page[model.Member]{Items: members, Total: total}
+	if len(members) > limit {
+		page.Items = members[:limit]
+		last := page.Items[limit-1]
+		page.Next = paging.Encode(paging.Cursor{Sort: query.Sort, Str: last.Name, ID: last.ID.Hex()})
+	}
+
+	return page, nil
+}
+
+// GetMemberById retrieves a member by their ID from the table.
+// Takes a context for managing request lifetime and the member ID as a string.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberById(ctx context.Context, id string) (*model.Member, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.queryOne(ctx, `SELECT `+memberColumns+` FROM members WHERE id = ?`, i.Hex())
+}
+
+// GetMemberByEmail retrieves the member with the given email address from the table.
+// Takes a context for managing request lifetime and the email address as stored.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberByEmail(ctx context.Context, email string) (*model.Member, error) {
+	return r.queryOne(ctx, `SELECT `+memberColumns+` FROM members WHERE email = ?`, email)
+}
+
+// GetMemberByCardNumber retrieves the member with the given library card number from the table.
+// Takes a context for managing request lifetime and the card number.
+// Returns a Member model or an error if the operation fails.
+func (r *MemberRepository) GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error) {
+	return r.queryOne(ctx, `SELECT `+memberColumns+` FROM members WHERE card_number = ?`, cardNumber)
+}
+
+// queryOne runs a query returning memberColumns and reads its first row,
+// or returns a NotFound error if there is none.
+func (r *MemberRepository) queryOne(ctx context.Context, query string, args ...any) (*model.Member, error) {
+	member, err := scanMember(conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperr.NotFound("member not found")
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// AddMember inserts a new member into the table.
+// Takes a context for managing request lifetime and a Member model.
+// Returns the inserted Member model, or a Conflict error if its email or card number is taken.
+func (r *MemberRepository) AddMember(ctx context.Context, member model.Member) (*model.Member, error) {
+	member.ID = primitive.NewObjectID()
+	member.Version = 1
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, `INSERT INTO members (`+memberColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		member.ID.Hex(), member.Name, member.Email, member.CardNumber, member.Status, member.Version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, errMemberNotUnique
+		}
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// UpdateMember updates an existing member in the table.
+// A non-zero member.Version must match the stored version.
+// Takes a context for managing request lifetime, the member ID as a string, and a Member model.
+// Returns the updated Member model or an error if the operation fails.
+func (r *MemberRepository) UpdateMember(ctx context.Context, id string, member model.Member) (*model.Member, error) {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, `UPDATE members SET name = ?, email = ?, card_number = ?, status = ?, version = version + 1
+		WHERE id = ? AND (? = 0 OR version = ?) RETURNING `+memberColumns,
+		member.Name, member.Email, member.CardNumber, member.Status, i.Hex(), member.Version, member.Version)
+	updated, err := scanMember(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.missOrMismatch(ctx, i.Hex(), member.Version)
+		}
+		if isUniqueViolation(err) {
+			return nil, errMemberNotUnique
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteMember removes a member from the table by their ID.
+// A non-zero version must match the stored version.
+// Takes a context for managing request lifetime, the member ID as a string and the expected version.
+// Returns an error if the operation fails or if no row is found.
+func (r *MemberRepository) DeleteMember(ctx context.Context, id string, version int64) error {
+	i, err := model.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM members WHERE id = ? AND (? = 0 OR version = ?)`,
+		i.Hex(), version, version)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return r.missOrMismatch(ctx, i.Hex(), version)
+	}
+	return nil
+}
+
+// missOrMismatch explains why a write filtered by ID and version matched no row:
+// it returns a NotFound error if the member is missing and a version mismatch otherwise.
+func (r *MemberRepository) missOrMismatch(ctx context.Context, id string, version int64) error {
+	var current int64
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT version FROM members WHERE id = ?`, id).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperr.NotFound("member not found")
+		}
+		return err
+	}
+	return apperr.VersionMismatch("member", current, version)
+}
@@ -0,0 +1,29 @@
+package sqlite_test
+
+import (
+	"game-library-management-system/src/repository/repotest"
+	"game-library-management-system/src/repository/sqlite"
+	"path/filepath"
+	"testing"
+)
+
+// TestConformance runs the repository conformance suite against a fresh database file for each test.
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db, err := sqlite.Open(filepath.Join(t.TempDir(), "library.db"))
+		if err != nil {
+			t.Fatalf("Open: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return repotest.Repositories{
+			Games:      sqlite.NewGameRepository(db),
+			Developers: sqlite.NewDeveloperRepository(db),
+			Members:    sqlite.NewMemberRepository(db),
+			Loans:      sqlite.NewLoanRepository(db),
+			Copies:     sqlite.NewCopyRepository(db),
+			Holds:      sqlite.NewHoldRepository(db),
+			Fines:      sqlite.NewFineRepository(db),
+			Transactor: sqlite.NewTransactor(db),
+		}
+	})
+}
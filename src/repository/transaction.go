@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"game-library-management-system/src/interface"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Transactor struct {
+	client *mongo.Client
+}
+
+// NewTransactor creates a new Transactor backed by MongoDB sessions.
+// Transactions require MongoDB to run as a replica set.
+// Returns the Transactor interface.
+func NewTransactor(client *mongo.Client) _interface.Transactor {
+	return &Transactor{
+		client: client,
+	}
+}
+
+// WithinTransaction runs fn inside a multi-document transaction.
+// Repository calls made with the context passed to fn take part in the transaction,
+// which is committed when fn returns nil and aborted otherwise.
+// fn may be retried on transient transaction errors, so it must not have side effects outside the database.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
@@ -0,0 +1,218 @@
+// Package search ranks games against a free-text query.
+// Matching is done per word and tolerates typos, so "witchr" still finds "The Witcher".
+package search
+
+import (
+	"cmp"
+	"game-library-management-system/src/model"
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Field weights: a hit in the title counts more than one in the developer name or genre.
+const (
+	titleWeight     = 3.0
+	developerWeight = 2.0
+	genreWeight     = 1.0
+)
+
+// Match quality of a single query word against a single field word.
+const (
+	exactMatch  = 1.0
+	prefixMatch = 0.8
+	typoMatch   = 0.6
+	typo2Match  = 0.4
+)
+
+const (
+	highlightStart = "<em>"
+	highlightEnd   = "</em>"
+)
+
+// word is a word of a field together with its byte offsets in the original text.
+type word struct {
+	text       string
+	start, end int
+}
+
+// field is one searchable field of a game.
+type field struct {
+	name   string
+	text   string
+	weight float64
+}
+
+// Games ranks games by relevance to query and returns at most limit results with a positive score.
+// Results are ordered by descending score, ties broken by title.
+func Games(games []model.Game, query string, limit int) []model.GameSearchResult {
+	terms := words(query)
+	if len(terms) == 0 {
+		return []model.GameSearchResult{}
+	}
+
+	results := make([]model.GameSearchResult, 0)
+	for _, g := range games {
+		if result, ok := rank(g, terms); ok {
+			results = append(results, result)
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b model.GameSearchResult) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Game.Title, b.Game.Title)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// rank scores a single game. It reports false when no query word matches any field.
+func rank(g model.Game, terms []word) (model.GameSearchResult, bool) {
+	fields := []field{
+		{"Title", g.Title, titleWeight},
+		{"Developer", g.Developer.Name, developerWeight},
+		{"Genre", g.Genre, genreWeight},
+	}
+
+	result := model.GameSearchResult{Game: g, Highlights: map[string]string{}}
+	matched := make([][]word, len(fields))
+	fieldWords := make([][]word, len(fields))
+	for i, f := range fields {
+		fieldWords[i] = words(f.text)
+	}
+
+	for _, term := range terms {
+		best, bestField, bestWord := 0.0, -1, word{}
+		for i, f := range fields {
+			for _, w := range fieldWords[i] {
+				if score := similarity(term.text, w.text) * f.weight; score > best {
+					best, bestField, bestWord = score, i, w
+				}
+			}
+		}
+		if bestField >= 0 {
+			result.Score += best
+			matched[bestField] = append(matched[bestField], bestWord)
+		}
+	}
+	if result.Score == 0 {
+		return result, false
+	}
+
+	for i, f := range fields {
+		if len(matched[i]) > 0 {
+			result.Highlights[f.name] = highlight(f.text, matched[i])
+		}
+	}
+	return result, true
+}
+
+// similarity returns how well a query word matches a field word, from 0 (no match) to exactMatch.
+func similarity(term, w string) float64 {
+	switch {
+	case term == w:
+		return exactMatch
+	case len(term) >= 2 && strings.HasPrefix(w, term):
+		return prefixMatch
+	}
+
+	maxEdits := allowedEdits(term)
+	if maxEdits == 0 {
+		return 0
+	}
+	switch d := levenshtein(term, w, maxEdits); {
+	case d > maxEdits:
+		return 0
+	case d == 1:
+		return typoMatch
+	default:
+		return typo2Match
+	}
+}
+
+// allowedEdits returns how many typos a query word of this length may contain.
+func allowedEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// levenshtein returns the edit distance between a and b, or max+1 once it is known to exceed max.
+func levenshtein(a, b string, max int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > max || -d > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// words splits text into lower-cased words made of letters and digits.
+func words(text string) []word {
+	var out []word
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && start < 0:
+			start = i
+		case !isWordRune && start >= 0:
+			out = append(out, word{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		out = append(out, word{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return out
+}
+
+// highlight wraps the matched words of text in highlight markers.
+// The text is HTML-escaped, so that only the markers are markup when the result is shown as HTML.
+func highlight(text string, matched []word) string {
+	slices.SortFunc(matched, func(a, b word) int { return cmp.Compare(a.start, b.start) })
+	matched = slices.CompactFunc(matched, func(a, b word) bool { return a.start == b.start })
+
+	var sb strings.Builder
+	last := 0
+	for _, w := range matched {
+		sb.WriteString(html.EscapeString(text[last:w.start]))
+		sb.WriteString(highlightStart)
+		sb.WriteString(html.EscapeString(text[w.start:w.end]))
+		sb.WriteString(highlightEnd)
+		last = w.end
+	}
+	sb.WriteString(html.EscapeString(text[last:]))
+	return sb.String()
+}
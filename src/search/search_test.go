@@ -0,0 +1,141 @@
+package search
+
+import (
+	"game-library-management-system/src/model"
+	"slices"
+	"testing"
+)
+
+// TestHighlightsEscapeHTML checks that stored text cannot inject markup into the highlights.
+func TestHighlightsEscapeHTML(t *testing.T) {
+	games := []model.Game{{
+		Title:     `<img src=x onerror=alert(1)> Witcher & Co`,
+		Developer: model.Developer{Name: "CD Projekt <Red>"},
+		Genre:     "RPG",
+	}}
+
+	results := Games(games, "witcher red", 10)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	want := map[string]string{
+		"Title":     `&lt;img src=x onerror=alert(1)&gt; <em>Witcher</em> &amp; Co`,
+		"Developer": `CD Projekt &lt;<em>Red</em>&gt;`,
+	}
+	for field, highlight := range want {
+		if got := results[0].Highlights[field]; got != highlight {
+			t.Errorf("%s highlight = %q, want %q", field, got, highlight)
+		}
+	}
+}
+
+// TestLevenshtein checks edit distances and the cut-off once the distance exceeds the maximum.
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		max  int
+		want int
+	}{
+		{"witcher", "witcher", 2, 0},
+		{"witchr", "witcher", 2, 1},
+		{"wticher", "witcher", 2, 2},
+		{"zelda", "zelad", 2, 2},
+		{"doom", "dome", 1, 2},
+		{"halo", "halo infinite", 2, 3},
+		{"portal", "mortal", 1, 1},
+		{"pokémon", "pokemon", 1, 1},
+		{"", "ico", 3, 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b, tt.max); got != tt.want {
+			t.Errorf("levenshtein(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.max, got, tt.want)
+		}
+	}
+}
+
+// TestSimilarity checks the match quality of a query word: exact, prefix, one or two typos depending on its length, or none.
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		term string
+		word string
+		want float64
+	}{
+		{"exact", "witcher", "witcher", exactMatch},
+		{"prefix", "wit", "witcher", prefixMatch},
+		{"prefix of two letters", "wi", "witcher", prefixMatch},
+		{"single letter", "w", "witcher", 0},
+		{"one typo", "witchr", "witcher", typoMatch},
+		{"two typos in a short word", "wtchr", "witcher", 0},
+		{"two typos in a long word", "cyberpnuk", "cyberpunk", typo2Match},
+		{"three typos in a long word", "cybrepnk", "cyberpunk", 0},
+		{"typo in a three letter word", "rgp", "rpg", 0},
+		{"typo in a four letter word", "halp", "halo", typoMatch},
+		{"unrelated", "zelda", "witcher", 0},
+	}
+	for _, tt := range tests {
+		if got := similarity(tt.term, tt.word); got != tt.want {
+			t.Errorf("%s: similarity(%q, %q) = %v, want %v", tt.name, tt.term, tt.word, got, tt.want)
+		}
+	}
+}
+
+// TestGames checks which games a query finds and in which order.
+func TestGames(t *testing.T) {
+	cdProjekt := model.Developer{Name: "CD Projekt"}
+	games := []model.Game{
+		{Title: "The Witcher", Genre: "RPG", Developer: cdProjekt},
+		{Title: "The Witcher 3", Genre: "RPG", Developer: cdProjekt},
+		{Title: "Cyberpunk 2077", Genre: "RPG", Developer: cdProjekt},
+		{Title: "Portal", Genre: "Puzzle", Developer: model.Developer{Name: "Valve"}},
+		{Title: "Puzzle Quest", Genre: "RPG", Developer: model.Developer{Name: "Infinite Interactive"}},
+		{Title: "Halo", Genre: "Shooter", Developer: model.Developer{Name: "Bungie"}},
+	}
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{"typo", "witchr", 10, []string{"The Witcher", "The Witcher 3"}},
+		{"prefix", "cyber", 10, []string{"Cyberpunk 2077"}},
+		{"title above genre", "puzzle", 10, []string{"Puzzle Quest", "Portal"}},
+		{"title above developer", "valve portal", 10, []string{"Portal"}},
+		{"developer name", "projekt", 10, []string{"Cyberpunk 2077", "The Witcher", "The Witcher 3"}},
+		{"ties by title", "rpg", 10, []string{"Cyberpunk 2077", "Puzzle Quest", "The Witcher", "The Witcher 3"}},
+		{"more words score higher", "witcher 3", 10, []string{"The Witcher 3", "The Witcher"}},
+		{"limit", "rpg", 2, []string{"Cyberpunk 2077", "Puzzle Quest"}},
+		{"case and punctuation", "HALO!", 10, []string{"Halo"}},
+		{"no match", "zelda", 10, []string{}},
+		{"no words", "  -- ", 10, []string{}},
+	}
+	for _, tt := range tests {
+		results := Games(games, tt.query, tt.limit)
+		titles := make([]string, len(results))
+		for i, result := range results {
+			titles[i] = result.Game.Title
+		}
+		if !slices.Equal(titles, tt.want) {
+			t.Errorf("%s: Games(%q) = %q, want %q", tt.name, tt.query, titles, tt.want)
+		}
+	}
+}
+
+// TestGamesScore checks that a title hit outweighs the same hit in the developer name or the genre.
+func TestGamesScore(t *testing.T) {
+	games := []model.Game{
+		{Title: "Strategy", Genre: "Puzzle", Developer: model.Developer{Name: "Nintendo"}},
+		{Title: "Tetris", Genre: "Strategy", Developer: model.Developer{Name: "Nintendo"}},
+		{Title: "Tetris", Genre: "Puzzle", Developer: model.Developer{Name: "Strategy Games"}},
+	}
+	results := Games(games, "strategy", 10)
+	want := []float64{titleWeight * exactMatch, developerWeight * exactMatch, genreWeight * exactMatch}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, result := range results {
+		if result.Score != want[i] {
+			t.Errorf("result %d (%s, %s) scores %v, want %v", i, result.Game.Genre, result.Game.Developer.Name, result.Score, want[i])
+		}
+	}
+}
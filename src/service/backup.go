@@ -0,0 +1,329 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/backup"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"io"
+	"maps"
+	"slices"
+)
+
+const (
+	// developersCollection and gamesCollection name the collections of a backup archive
+	developersCollection = "developers"
+	gamesCollection      = "games"
+	// restoreBatchSize is the number of records restored per transaction
+	restoreBatchSize = 500
+)
+
+type BackupService struct {
+	developerRepository _interface.DeveloperRepositorer
+	gameRepository      _interface.GameRepositorer
+	copyRepository      _interface.CopyRepositorer
+	transactor          _interface.Transactor
+	logger              *zap.Logger
+}
+
+// NewBackupService creates a new BackupService
+// It returns a pointer to a BackupService and an error
+func NewBackupService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, copyRepository _interface.CopyRepositorer, transactor _interface.Transactor, logger *zap.Logger) (_interface.BackupServicer, error) {
+	return &BackupService{
+		developerRepository: developerRepository,
+		gameRepository:      gameRepository,
+		copyRepository:      copyRepository,
+		transactor:          transactor,
+		logger:              logger,
+	}, nil
+}
+
+// Backup writes the games and developers to w as a backup archive and returns its manifest
+// The games are read first. A developer deleted before the developers are read is saved as its games embed it,
+// so every game of an archive has its developer
+func (s *BackupService) Backup(ctx context.Context, w io.Writer) (*model.BackupManifest, error) {
+	manifest, err := s.backup(ctx, w)
+	if err != nil {
+		s.logger.Error("Error backing up", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("Backed up", zap.Any("collections", manifest.Collections))
+	return manifest, nil
+}
+
+// backup writes the archive of Backup
+func (s *BackupService) backup(ctx context.Context, w io.Writer) (*model.BackupManifest, error) {
+	archive := backup.NewWriter(w, now())
+
+	// missing holds the developers of the games that are not saved yet.
+	missing := make(map[primitive.ObjectID]model.Developer)
+	if err := archive.Create(gamesCollection); err != nil {
+		return nil, err
+	}
+	err := s.gameRepository.StreamGames(ctx, model.GameFilter{}, "", func(g model.Game) error {
+		missing[g.Developer.ID] = g.Developer
+		return archive.Add(g)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := archive.Create(developersCollection); err != nil {
+		return nil, err
+	}
+	err = s.developerRepository.StreamDevelopers(ctx, model.DeveloperFilter{}, "", func(d model.Developer) error {
+		delete(missing, d.ID)
+		return archive.Add(d)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range slices.SortedFunc(maps.Keys(missing), func(a, b primitive.ObjectID) int { return bytes.Compare(a[:], b[:]) }) {
+		if err := archive.Add(missing[id]); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive.Close()
+}
+
+// Restore writes the developers and games of a backup archive, keeping their IDs and versions
+// The archive is checked first: nothing is written if it is corrupt, if a game's developer is neither in it nor
+// stored, or if opts.OnConflict is fail and any of its records is already stored. Records are then written in
+// transactions of restoreBatchSize, developers first. A replaced developer is updated in the games embedding it.
+// A replaced record gets a version above the stored one, and a replaced game keeps its stored availability.
+// The copy counts of a restored game are taken from the stored copies
+func (s *BackupService) Restore(ctx context.Context, archive io.ReaderAt, size int64, opts model.RestoreOptions) (*model.RestoreReport, error) {
+	report, err := s.restore(ctx, archive, size, opts)
+	if err != nil {
+		if errors.Is(err, backup.ErrCorrupt) {
+			err = apperr.Validation("%v", err)
+		}
+		s.logger.Error("Error restoring backup", zap.String("onConflict", string(opts.OnConflict)), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("Restored backup", zap.Bool("dryRun", report.DryRun), zap.Any("developers", report.Developers),
+		zap.Any("games", report.Games))
+	return report, nil
+}
+
+// restore checks and writes the archive of Restore
+func (s *BackupService) restore(ctx context.Context, archive io.ReaderAt, size int64, opts model.RestoreOptions) (*model.RestoreReport, error) {
+	policy := opts.OnConflict
+	if policy == "" {
+		policy = model.ConflictFail
+	}
+	if policy != model.ConflictFail && policy != model.ConflictSkip && policy != model.ConflictReplace {
+		return nil, apperr.Validation("conflict policy must be fail, skip or replace")
+	}
+	reader, err := backup.NewReader(archive, size)
+	if err != nil {
+		return nil, apperr.Validation("%v", err)
+	}
+
+	report, err := s.check(ctx, reader, policy)
+	if err != nil {
+		return nil, err
+	}
+	if policy == model.ConflictFail && report.Developers.Skipped+report.Games.Skipped > 0 {
+		return nil, apperr.Conflict("%d developers and %d games of the archive are already stored",
+			report.Developers.Skipped, report.Games.Skipped)
+	}
+	if opts.DryRun {
+		report.DryRun = true
+		return report, nil
+	}
+
+	report = &model.RestoreReport{}
+	err = restoreBatches(ctx, s.transactor, reader, developersCollection, &report.Developers,
+		func(ctx context.Context, d model.Developer, counts *model.RestoreCounts) error {
+			return s.restoreDeveloper(ctx, d, policy, counts)
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = restoreBatches(ctx, s.transactor, reader, gamesCollection, &report.Games,
+		func(ctx context.Context, g model.Game, counts *model.RestoreCounts) error {
+			return s.restoreGame(ctx, g, policy, counts)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// check reads the whole archive and counts what restoring it would do with the given policy
+// Under the fail policy, the records already stored are counted as skipped
+func (s *BackupService) check(ctx context.Context, reader *backup.Reader, policy model.ConflictPolicy) (*model.RestoreReport, error) {
+	report := &model.RestoreReport{}
+	count := func(counts *model.RestoreCounts, stored bool) {
+		switch {
+		case !stored:
+			counts.Created++
+		case policy == model.ConflictReplace:
+			counts.Replaced++
+		default:
+			counts.Skipped++
+		}
+	}
+
+	// developers holds the IDs of the developers a restored game can have.
+	developers := make(map[primitive.ObjectID]bool)
+	err := backup.Each(reader, developersCollection, func(d model.Developer) error {
+		if d.ID.IsZero() {
+			return apperr.Validation("developer %q has no ID", d.Name)
+		}
+		_, err := s.developerRepository.GetDeveloperById(ctx, d.ID.Hex())
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			return err
+		}
+		count(&report.Developers, err == nil)
+		developers[d.ID] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = backup.Each(reader, gamesCollection, func(g model.Game) error {
+		if g.ID.IsZero() {
+			return apperr.Validation("game %q has no ID", g.Title)
+		}
+		if !developers[g.Developer.ID] {
+			_, err := s.developerRepository.GetDeveloperById(ctx, g.Developer.ID.Hex())
+			if errors.Is(err, apperr.ErrNotFound) {
+				return apperr.Validation("the developer of game %s is neither in the archive nor stored", g.ID.Hex())
+			}
+			if err != nil {
+				return err
+			}
+			developers[g.Developer.ID] = true
+		}
+		_, err := s.gameRepository.GetGameById(ctx, g.ID.Hex())
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			return err
+		}
+		count(&report.Games, err == nil)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// restoreDeveloper writes a developer of an archive according to the conflict policy and counts what it did
+// A replaced developer gets a version above the stored one, so that writes conditional on the stored version fail
+func (s *BackupService) restoreDeveloper(ctx context.Context, developer model.Developer, policy model.ConflictPolicy, counts *model.RestoreCounts) error {
+	current, err := s.developerRepository.GetDeveloperById(ctx, developer.ID.Hex())
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+	stored := err == nil
+	if stored {
+		switch policy {
+		case model.ConflictFail:
+			return apperr.Conflict("developer %s is already stored", developer.ID.Hex())
+		case model.ConflictSkip:
+			counts.Skipped++
+			return nil
+		}
+		developer.Version = max(developer.Version, current.Version+1)
+	}
+
+	if _, err := s.developerRepository.RestoreDeveloper(ctx, developer); err != nil {
+		return err
+	}
+	if !stored {
+		counts.Created++
+		return nil
+	}
+	if _, err := s.gameRepository.UpdateGamesDeveloper(ctx, developer); err != nil {
+		return err
+	}
+	counts.Replaced++
+	return nil
+}
+
+// restoreGame writes a game of an archive according to the conflict policy and counts what it did
+// A replaced game keeps its stored availability, which loans and holds depend on, and gets a version above the stored one
+func (s *BackupService) restoreGame(ctx context.Context, game model.Game, policy model.ConflictPolicy, counts *model.RestoreCounts) error {
+	current, err := s.gameRepository.GetGameById(ctx, game.ID.Hex())
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+	stored := err == nil
+	if stored {
+		switch policy {
+		case model.ConflictFail:
+			return apperr.Conflict("game %s is already stored", game.ID.Hex())
+		case model.ConflictSkip:
+			counts.Skipped++
+			return nil
+		}
+		game.Available = current.Available
+		game.Version = max(game.Version, current.Version+1)
+	}
+
+	total, available, err := s.copyRepository.CountCopies(ctx, game.ID.Hex())
+	if err != nil {
+		return err
+	}
+	game.Copies = total
+	game.AvailableCopies = available
+	if total > 0 {
+		game.Available = available > 0
+	}
+	if _, err := s.gameRepository.RestoreGame(ctx, game); err != nil {
+		return err
+	}
+	if stored {
+		counts.Replaced++
+	} else {
+		counts.Created++
+	}
+	return nil
+}
+
+// restoreBatches restores the records of a collection with restore, in transactions of restoreBatchSize records
+// The counts of a batch are added to counts once it is committed
+func restoreBatches[T any](ctx context.Context, transactor _interface.Transactor, reader *backup.Reader, collection string, counts *model.RestoreCounts, restore func(context.Context, T, *model.RestoreCounts) error) error {
+	batch := make([]T, 0, restoreBatchSize)
+	flush := func() error {
+		var batchCounts model.RestoreCounts
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			// The transaction may be retried, so the counts start over with it.
+			batchCounts = model.RestoreCounts{}
+			for _, record := range batch {
+				if err := restore(ctx, record, &batchCounts); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		counts.Created += batchCounts.Created
+		counts.Replaced += batchCounts.Replaced
+		counts.Skipped += batchCounts.Skipped
+		batch = batch[:0]
+		return nil
+	}
+
+	err := backup.Each(reader, collection, func(record T) error {
+		if batch = append(batch, record); len(batch) == restoreBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err == nil && len(batch) > 0 {
+		err = flush()
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/backup"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+// archive backs up the library and returns the archive
+func (l *library) archive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := l.backup.Backup(context.Background(), &buf); err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// restore restores an archive into the library
+func (l *library) restore(archive []byte, opts model.RestoreOptions) (*model.RestoreReport, error) {
+	return l.backup.Restore(context.Background(), bytes.NewReader(archive), int64(len(archive)), opts)
+}
+
+// TestRestoreReplaceKeepsAvailability checks that replacing a checked out game keeps it unavailable and moves its version on.
+func TestRestoreReplaceKeepsAvailability(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+	archive := l.archive(t)
+	l.checkout(t, game, l.member(t, "geralt"))
+	lent, err := l.games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+
+	if _, err := l.restore(archive, model.RestoreOptions{OnConflict: model.ConflictReplace}); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	restored, err := l.games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if restored.Available {
+		t.Error("replaced game is available while it is checked out")
+	}
+	if restored.Version <= lent.Version {
+		t.Errorf("replaced game has version %d, want more than the stored %d", restored.Version, lent.Version)
+	}
+}
+
+// TestRestoreConflicts checks what each conflict policy does with the records already stored.
+func TestRestoreConflicts(t *testing.T) {
+	ctx := context.Background()
+	source := newLibrary(t)
+	developer := source.developer(t, "CD Projekt")
+	witcher := source.game(t, developer, "The Witcher")
+	source.game(t, developer, "Cyberpunk 2077")
+	archive := source.archive(t)
+
+	tests := []struct {
+		policy     model.ConflictPolicy
+		developers model.RestoreCounts
+		games      model.RestoreCounts
+		conflict   bool
+		title      string
+	}{
+		{policy: model.ConflictFail, conflict: true, title: "The Witcher 3"},
+		{policy: "", conflict: true, title: "The Witcher 3"},
+		{policy: model.ConflictSkip, developers: model.RestoreCounts{Skipped: 1}, games: model.RestoreCounts{Created: 1, Skipped: 1}, title: "The Witcher 3"},
+		{policy: model.ConflictReplace, developers: model.RestoreCounts{Replaced: 1}, games: model.RestoreCounts{Created: 1, Replaced: 1}, title: "The Witcher"},
+	}
+	for _, tt := range tests {
+		l := newLibrary(t)
+		if _, err := l.restore(archive, model.RestoreOptions{}); err != nil {
+			t.Fatalf("%q: Restore into an empty library: %v", tt.policy, err)
+		}
+		stored, err := l.games.GetGameById(ctx, witcher.ID.Hex())
+		if err != nil {
+			t.Fatalf("%q: GetGameById: %v", tt.policy, err)
+		}
+		stored.Title = "The Witcher 3"
+		if _, err := l.games.UpdateGame(ctx, witcher.ID.Hex(), *stored); err != nil {
+			t.Fatalf("%q: UpdateGame: %v", tt.policy, err)
+		}
+		games, err := l.games.ListGames(ctx, model.GameQuery{})
+		if err != nil {
+			t.Fatalf("%q: ListGames: %v", tt.policy, err)
+		}
+		for _, g := range games.Items {
+			if g.ID != witcher.ID {
+				if err := l.games.DeleteGame(ctx, g.ID.Hex(), 0); err != nil {
+					t.Fatalf("%s: DeleteGame: %v", tt.policy, err)
+				}
+			}
+		}
+
+		report, err := l.restore(archive, model.RestoreOptions{OnConflict: tt.policy})
+		if tt.conflict {
+			if !errors.Is(err, apperr.ErrConflict) {
+				t.Errorf("%q: Restore error = %v, want a conflict", tt.policy, err)
+			}
+			if page, _ := l.games.ListGames(ctx, model.GameQuery{}); page == nil || len(page.Items) != 1 {
+				t.Errorf("%q: games were restored despite the conflict", tt.policy)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("%q: Restore: %v", tt.policy, err)
+			}
+			if report.DryRun || report.Developers != tt.developers || report.Games != tt.games {
+				t.Errorf("%q: report = %+v, want developers %+v and games %+v", tt.policy, *report, tt.developers, tt.games)
+			}
+		}
+		game, err := l.games.GetGameById(ctx, witcher.ID.Hex())
+		if err != nil {
+			t.Fatalf("%q: GetGameById: %v", tt.policy, err)
+		}
+		if game.Title != tt.title {
+			t.Errorf("%q: title = %q, want %q", tt.policy, game.Title, tt.title)
+		}
+	}
+}
+
+// TestRestoreDryRun checks that a dry run reports the counts of a restore without writing anything.
+func TestRestoreDryRun(t *testing.T) {
+	ctx := context.Background()
+	source := newLibrary(t)
+	developer := source.developer(t, "CD Projekt")
+	source.game(t, developer, "The Witcher")
+	archive := source.archive(t)
+
+	l := newLibrary(t)
+	for _, policy := range []model.ConflictPolicy{model.ConflictFail, model.ConflictReplace} {
+		report, err := l.restore(archive, model.RestoreOptions{OnConflict: policy, DryRun: true})
+		if err != nil {
+			t.Fatalf("%s: Restore: %v", policy, err)
+		}
+		want := model.RestoreReport{DryRun: true, Developers: model.RestoreCounts{Created: 1}, Games: model.RestoreCounts{Created: 1}}
+		if *report != want {
+			t.Errorf("%s: report = %+v, want %+v", policy, *report, want)
+		}
+	}
+	if _, err := l.developers.GetDeveloperById(ctx, developer.ID.Hex()); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("GetDeveloperById after a dry run error = %v, want not found", err)
+	}
+}
+
+// TestRestoreBatches checks that an archive of more records than fit in one transaction is restored completely.
+func TestRestoreBatches(t *testing.T) {
+	ctx := context.Background()
+	const developers = 1234
+	source := newLibrary(t)
+	for i := range developers {
+		source.developer(t, fmt.Sprintf("Developer %d", i))
+	}
+	archive := source.archive(t)
+
+	l := newLibrary(t)
+	report, err := l.restore(archive, model.RestoreOptions{})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if report.Developers.Created != developers {
+		t.Errorf("report = %+v, want %d developers created", *report, developers)
+	}
+	stored, err := l.developerRepository.GetAllDevelopers(ctx)
+	if err != nil {
+		t.Fatalf("GetAllDevelopers: %v", err)
+	}
+	if len(stored) != developers {
+		t.Errorf("%d developers stored, want %d", len(stored), developers)
+	}
+}
+
+// TestRestoreRefused checks that an archive which cannot be restored as a whole writes nothing.
+func TestRestoreRefused(t *testing.T) {
+	ctx := context.Background()
+	stored := model.Developer{ID: primitive.NewObjectID(), Name: "CD Projekt", MainHq: "Warsaw", Version: 1}
+	archived := model.Developer{ID: primitive.NewObjectID(), Name: "Valve", MainHq: "Bellevue", Version: 1}
+	archive := func(developers []model.Developer, games []model.Game) []byte {
+		var buf bytes.Buffer
+		w := backup.NewWriter(&buf, time.Now())
+		if err := w.Create("games"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		for _, g := range games {
+			if err := w.Add(g); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		if err := w.Create("developers"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		for _, d := range developers {
+			if err := w.Add(d); err != nil {
+				t.Fatalf("Add: %v", err)
+			}
+		}
+		if _, err := w.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		return buf.Bytes()
+	}
+	game := func(developer model.Developer) model.Game {
+		return model.Game{ID: primitive.NewObjectID(), Title: "Portal", Developer: developer, Genre: "Puzzle", PublicationYear: 2007, Version: 1}
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		ok      bool
+	}{
+		{"developer in the archive", archive([]model.Developer{archived}, []model.Game{game(archived)}), true},
+		{"developer stored", archive(nil, []model.Game{game(stored)}), true},
+		{"developer neither in the archive nor stored", archive([]model.Developer{archived}, []model.Game{game(archived), game(model.Developer{ID: primitive.NewObjectID(), Name: "Gone"})}), false},
+		{"corrupt archive", []byte("not a zip file"), false},
+	}
+	for _, tt := range tests {
+		l := newLibrary(t)
+		if _, err := l.developerRepository.RestoreDeveloper(ctx, stored); err != nil {
+			t.Fatalf("RestoreDeveloper: %v", err)
+		}
+		_, err := l.restore(tt.archive, model.RestoreOptions{})
+		if tt.ok {
+			if err != nil {
+				t.Errorf("%s: Restore: %v", tt.name, err)
+			}
+			continue
+		}
+		if !errors.Is(err, apperr.ErrValidation) {
+			t.Errorf("%s: Restore error = %v, want a validation error", tt.name, err)
+		}
+		if _, err := l.developers.GetDeveloperById(ctx, archived.ID.Hex()); !errors.Is(err, apperr.ErrNotFound) {
+			t.Errorf("%s: developer of a refused archive was restored", tt.name)
+		}
+	}
+}
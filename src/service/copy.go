@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// ErrCopyOnLoan is returned when deleting or checking out a copy that is checked out or reserved for a hold.
+var ErrCopyOnLoan = apperr.Conflict("copy is checked out or reserved")
+
+type CopyService struct {
+	copyRepository _interface.CopyRepositorer
+	gameRepository _interface.GameRepositorer
+	transactor     _interface.Transactor
+	holds          holdQueue
+	logger         *zap.Logger
+}
+
+// NewCopyService creates a new CopyService
+// New copies are reserved for the next hold of their game for pickupWindow
+// It returns a pointer to a CopyService and an error
+func NewCopyService(copyRepository _interface.CopyRepositorer, gameRepository _interface.GameRepositorer, holdRepository _interface.HoldRepositorer, memberRepository _interface.MemberRepositorer, transactor _interface.Transactor, pickupWindow time.Duration, logger *zap.Logger) (_interface.CopyServicer, error) {
+	return &CopyService{
+		copyRepository: copyRepository,
+		gameRepository: gameRepository,
+		transactor:     transactor,
+		holds:          newHoldQueue(holdRepository, gameRepository, copyRepository, memberRepository, pickupWindow),
+		logger:         logger,
+	}, nil
+}
+
+// ListCopies gets one page of the copies of a game
+func (s *CopyService) ListCopies(ctx context.Context, query model.CopyQuery) (*model.Page[model.Copy], error) {
+	if _, err := s.gameRepository.GetGameById(ctx, query.GameID); err != nil {
+		return nil, err
+	}
+	page, err := s.copyRepository.PageCopies(ctx, query)
+	if err != nil {
+		s.logger.Error("Error listing copies", zap.Any("query", query), zap.Error(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetCopyById gets a copy by ID
+func (s *CopyService) GetCopyById(ctx context.Context, id string) (*model.Copy, error) {
+	gameCopy, err := s.copyRepository.GetCopyById(ctx, id)
+	if err != nil {
+		s.logger.Error("Error getting copy by ID", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return gameCopy, nil
+}
+
+// GetCopyByBarcode gets a copy by barcode
+func (s *CopyService) GetCopyByBarcode(ctx context.Context, barcode string) (*model.Copy, error) {
+	gameCopy, err := s.copyRepository.GetCopyByBarcode(ctx, strings.TrimSpace(barcode))
+	if err != nil {
+		s.logger.Error("Error getting copy by barcode", zap.String("barcode", barcode), zap.Error(err))
+		return nil, err
+	}
+	return gameCopy, nil
+}
+
+// AddCopy adds a copy to a game and recounts the game's copies in the same transaction
+// The copy is reserved for the hold the game itself was reserved for, or else for the first waiting hold of the game,
+// or else available.
+// The barcode must not belong to another copy
+func (s *CopyService) AddCopy(ctx context.Context, gameID string, gameCopy model.Copy) (*model.Copy, error) {
+	gameCopy.Barcode = strings.TrimSpace(gameCopy.Barcode)
+	if err := copyRules.Validate(gameCopy); err != nil {
+		return nil, err
+	}
+	var newCopy *model.Copy
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		game, err := s.gameRepository.GetGameById(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if err := s.checkUnique(ctx, gameCopy); err != nil {
+			return err
+		}
+
+		gameCopy.GameID = game.ID
+		gameCopy.Available = true
+		added, err := s.copyRepository.AddCopy(ctx, gameCopy)
+		if err != nil {
+			return err
+		}
+		bound, err := s.holds.bindGameHold(ctx, gameID, added.ID)
+		if err != nil {
+			return err
+		}
+		if !bound {
+			if err := s.holds.release(ctx, gameID, &added.ID); err != nil {
+				return err
+			}
+		}
+		newCopy, err = s.copyRepository.GetCopyById(ctx, added.ID.Hex())
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error adding copy", zap.String("game", gameID), zap.Error(err))
+		return nil, err
+	}
+	return newCopy, nil
+}
+
+// UpdateCopy replaces a copy's barcode, condition, platform and shelf location
+// A non-zero gameCopy.Version makes the update conditional on the copy still being at that version
+func (s *CopyService) UpdateCopy(ctx context.Context, id string, gameCopy model.Copy) (*model.Copy, error) {
+	gameCopy.Barcode = strings.TrimSpace(gameCopy.Barcode)
+	if err := copyRules.Validate(gameCopy); err != nil {
+		return nil, err
+	}
+	var updatedCopy *model.Copy
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.copyRepository.GetCopyById(ctx, id)
+		if err != nil {
+			return err
+		}
+		gameCopy.ID = current.ID
+		if err := s.checkUnique(ctx, gameCopy); err != nil {
+			return err
+		}
+		updatedCopy, err = s.copyRepository.UpdateCopy(ctx, id, gameCopy)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error updating copy", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return updatedCopy, nil
+}
+
+// DeleteCopy deletes a copy that is not checked out and recounts its game's copies in the same transaction
+// A non-zero version makes the deletion conditional on the copy still being at that version
+func (s *CopyService) DeleteCopy(ctx context.Context, id string, version int64) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		gameCopy, err := s.copyRepository.GetCopyById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !gameCopy.Available {
+			return ErrCopyOnLoan
+		}
+		if err := s.copyRepository.DeleteCopy(ctx, id, version); err != nil {
+			return err
+		}
+		return syncCopyCounts(ctx, s.copyRepository, s.gameRepository, gameCopy.GameID.Hex())
+	})
+	if err != nil {
+		s.logger.Error("Error deleting copy", zap.String("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// checkUnique returns a Conflict error if another copy has the barcode of gameCopy
+func (s *CopyService) checkUnique(ctx context.Context, gameCopy model.Copy) error {
+	other, err := s.copyRepository.GetCopyByBarcode(ctx, gameCopy.Barcode)
+	if err == nil && other.ID != gameCopy.ID {
+		return apperr.Conflict("barcode %q belongs to another copy", gameCopy.Barcode)
+	}
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// syncCopyCounts stores the number of copies of a game and how many of them are available on the game
+// It must run in the transaction that changed the copies
+func syncCopyCounts(ctx context.Context, copyRepository _interface.CopyRepositorer, gameRepository _interface.GameRepositorer, gameID string) error {
+	total, available, err := copyRepository.CountCopies(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	_, err = gameRepository.SetCopyCounts(ctx, gameID, total, available)
+	return err
+}
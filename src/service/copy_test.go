@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"context"
+	"game-library-management-system/src/model"
+	"testing"
+)
+
+// addCopy adds a copy to a game
+func (l *library) addCopy(t *testing.T, game *model.Game, barcode string) *model.Copy {
+	t.Helper()
+	gameCopy, err := l.copies.AddCopy(context.Background(), game.ID.Hex(), model.Copy{Barcode: barcode, Condition: model.CopyNew, Platform: "PC"})
+	if err != nil {
+		t.Fatalf("AddCopy: %v", err)
+	}
+	return gameCopy
+}
+
+// TestAddCopyHolds checks who a new copy goes to: the hold the game was reserved for, the next waiting hold, or the shelf.
+func TestAddCopyHolds(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+	geralt, yennefer, ciri := l.member(t, "geralt"), l.member(t, "yennefer"), l.member(t, "ciri")
+
+	l.checkout(t, game, geralt)
+	reserved := l.placeHold(t, game, yennefer)
+	waiting := l.placeHold(t, game, ciri)
+	l.giveBack(t, game)
+
+	first := l.addCopy(t, game, "W-1")
+	if first.Available {
+		t.Error("copy reserved for the ready hold of the game is available")
+	}
+	hold, err := l.holds.GetHoldById(ctx, reserved.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetHoldById: %v", err)
+	}
+	if hold.Status != model.HoldReady || hold.CopyID == nil || *hold.CopyID != first.ID {
+		t.Errorf("ready hold is %s for copy %v, want it ready for %s", hold.Status, hold.CopyID, first.ID.Hex())
+	}
+	if status := l.holdStatus(t, waiting); status != model.HoldWaiting {
+		t.Errorf("next hold is %s, want it still %s", status, model.HoldWaiting)
+	}
+
+	second := l.addCopy(t, game, "W-2")
+	if second.Available {
+		t.Error("copy added while a hold is waiting is available")
+	}
+	if status := l.holdStatus(t, waiting); status != model.HoldReady {
+		t.Errorf("next hold is %s after adding a copy, want %s", status, model.HoldReady)
+	}
+	if third := l.addCopy(t, game, "W-3"); !third.Available {
+		t.Error("copy added with nobody waiting is not available")
+	}
+
+	loan, err := l.loans.Checkout(ctx, game.ID.Hex(), yennefer.ID.Hex(), "")
+	if err != nil {
+		t.Fatalf("Checkout of the reserved copy: %v", err)
+	}
+	if loan.CopyID == nil || *loan.CopyID != first.ID {
+		t.Errorf("checked out copy %v, want the reserved %s", loan.CopyID, first.ID.Hex())
+	}
+	stored, err := l.games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if stored.Copies != 3 || stored.AvailableCopies != 1 {
+		t.Errorf("game has %d of %d copies available, want 1 of 3", stored.AvailableCopies, stored.Copies)
+	}
+}
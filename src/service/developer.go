@@ -2,35 +2,46 @@ package service
 
 import (
 	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
 	"game-library-management-system/src/interface"
 	"game-library-management-system/src/model"
 	"go.uber.org/zap"
 )
 
+// ErrDeveloperHasGames is returned when a developer with games is deleted using the restrict policy.
+var ErrDeveloperHasGames = apperr.Conflict("developer still has games")
+
 type DeveloperService struct {
 	developerRepository _interface.DeveloperRepositorer
 	gameRepository      _interface.GameRepositorer
+	loanRepository      _interface.LoanRepositorer
+	holdRepository      _interface.HoldRepositorer
+	transactor          _interface.Transactor
 	logger              *zap.Logger
 }
 
 // NewDeveloperService creates a new DeveloperService
 // It returns a pointer to a DeveloperService and an error
-func NewDeveloperService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, logger *zap.Logger) (_interface.DeveloperServicer, error) {
+func NewDeveloperService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, loanRepository _interface.LoanRepositorer, holdRepository _interface.HoldRepositorer, transactor _interface.Transactor, logger *zap.Logger) (_interface.DeveloperServicer, error) {
 	return &DeveloperService{
 		developerRepository: developerRepository,
 		gameRepository:      gameRepository,
+		loanRepository:      loanRepository,
+		holdRepository:      holdRepository,
+		transactor:          transactor,
 		logger:              logger,
 	}, nil
 }
 
-// GetAllDevelopers gets all developers
-func (s *DeveloperService) GetAllDevelopers(ctx context.Context) ([]model.Developer, error) {
-	developers, err := s.developerRepository.GetAllDevelopers(ctx)
+// ListDevelopers gets one page of developers matching the query
+func (s *DeveloperService) ListDevelopers(ctx context.Context, query model.DeveloperQuery) (*model.Page[model.Developer], error) {
+	page, err := s.developerRepository.ListDevelopers(ctx, query)
 	if err != nil {
-		s.logger.Error("Error getting all developers", zap.Error(err))
+		s.logger.Error("Error listing developers", zap.Any("query", query), zap.Error(err))
 		return nil, err
 	}
-	return developers, nil
+	return page, nil
 }
 
 // GetDeveloperById gets a developer by ID
@@ -45,6 +56,9 @@ func (s *DeveloperService) GetDeveloperById(ctx context.Context, id string) (*mo
 
 // AddDeveloper adds a developer
 func (s *DeveloperService) AddDeveloper(ctx context.Context, developer model.Developer) (*model.Developer, error) {
+	if err := developerRules.Validate(developer); err != nil {
+		return nil, err
+	}
 	newDeveloper, err := s.developerRepository.AddDeveloper(ctx, developer)
 	if err != nil {
 		s.logger.Error("Error adding developer", zap.Error(err))
@@ -54,8 +68,24 @@ func (s *DeveloperService) AddDeveloper(ctx context.Context, developer model.Dev
 }
 
 // UpdateDeveloper updates a developer
+// The embedded developer copies in the developer's games are updated in the same transaction.
+// A non-zero developer.Version makes the update conditional on the developer still being at that version
 func (s *DeveloperService) UpdateDeveloper(ctx context.Context, id string, developer model.Developer) (*model.Developer, error) {
-	updatedDeveloper, err := s.developerRepository.UpdateDeveloper(ctx, id, developer)
+	if err := developerRules.Validate(developer); err != nil {
+		return nil, err
+	}
+	var updatedDeveloper *model.Developer
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		stored, err := s.developerRepository.UpdateDeveloper(ctx, id, developer)
+		if err != nil {
+			return err
+		}
+		if _, err := s.gameRepository.UpdateGamesDeveloper(ctx, *stored); err != nil {
+			return err
+		}
+		updatedDeveloper = stored
+		return nil
+	})
 	if err != nil {
 		s.logger.Error("Error updating developer", zap.String("id", id), zap.Error(err))
 		return nil, err
@@ -64,18 +94,85 @@ func (s *DeveloperService) UpdateDeveloper(ctx context.Context, id string, devel
 }
 
 // DeleteDeveloper deletes a developer
-func (s *DeveloperService) DeleteDeveloper(ctx context.Context, id string) error {
-	err := s.gameRepository.DeleteManyGamesByDeveloper(ctx, id)
+// The developer's games are handled according to opts.OnGames in the same transaction,
+// so either everything is deleted or nothing is
+func (s *DeveloperService) DeleteDeveloper(ctx context.Context, id string, opts model.DeleteDeveloperOptions) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.handleGamesOnDelete(ctx, id, opts); err != nil {
+			return err
+		}
+		return s.developerRepository.DeleteDeveloper(ctx, id, opts.Version)
+	})
 	if err != nil {
-		s.logger.Error("Error deleting games by developer", zap.String("id", id), zap.Error(err))
+		s.logger.Error("Error deleting developer", zap.String("id", id), zap.String("onGames", string(opts.OnGames)), zap.Error(err))
 		return err
 	}
 
-	err = s.developerRepository.DeleteDeveloper(ctx, id)
+	return nil
+}
+
+// handleGamesOnDelete cascades, refuses or reassigns the developer's games
+// Games are not cascaded while one of them is checked out, and their open holds are cancelled with them
+func (s *DeveloperService) handleGamesOnDelete(ctx context.Context, id string, opts model.DeleteDeveloperOptions) error {
+	switch opts.OnGames {
+	case "", model.GamesCascade:
+		gameIDs, err := developerGameIDs(ctx, s.gameRepository, id)
+		if err != nil {
+			return err
+		}
+		if err := checkGamesNotOnLoan(ctx, s.loanRepository, gameIDs); err != nil {
+			return err
+		}
+		if err := cancelGameHolds(ctx, s.holdRepository, gameIDs); err != nil {
+			return err
+		}
+		return s.gameRepository.DeleteManyGamesByDeveloper(ctx, id)
+	case model.GamesRestrict:
+		count, err := s.gameRepository.CountGamesByDeveloper(ctx, id)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrDeveloperHasGames
+		}
+		return nil
+	case model.GamesReassign:
+		if opts.ReassignTo == id {
+			return apperr.Validation("cannot reassign games to the developer being deleted")
+		}
+		target, err := s.developerRepository.GetDeveloperById(ctx, opts.ReassignTo)
+		if errors.Is(err, apperr.ErrNotFound) {
+			return apperr.Validation("developer %q to reassign games to does not exist", opts.ReassignTo)
+		}
+		if err != nil {
+			return err
+		}
+		return s.gameRepository.ReassignGames(ctx, id, *target)
+	default:
+		return apperr.Validation("unknown games policy %q", opts.OnGames)
+	}
+}
+
+// RepairGameDevelopers rewrites game documents whose embedded developer no longer matches the developer
+// It returns the number of games that were repaired
+func (s *DeveloperService) RepairGameDevelopers(ctx context.Context) (int64, error) {
+	developers, err := s.developerRepository.GetAllDevelopers(ctx)
 	if err != nil {
-		s.logger.Error("Error deleting developer", zap.String("id", id), zap.Error(err))
-		return err
+		s.logger.Error("Error getting all developers", zap.Error(err))
+		return 0, err
 	}
 
-	return nil
+	var repaired int64
+	for _, developer := range developers {
+		changed, err := s.gameRepository.UpdateGamesDeveloper(ctx, developer)
+		if err != nil {
+			s.logger.Error("Error repairing games of developer", zap.String("id", developer.ID.Hex()), zap.Error(err))
+			return repaired, err
+		}
+		if changed > 0 {
+			s.logger.Info("Repaired games of developer", zap.String("id", developer.ID.Hex()), zap.Int64("games", changed))
+		}
+		repaired += changed
+	}
+	return repaired, nil
 }
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/tabular"
+	"go.uber.org/zap"
+	"io"
+)
+
+// gameExportColumns are the columns of a game export. Title to DeveloperMainHq are the columns an import reads
+var gameExportColumns = []string{"ID", "Title", "Genre", "PublicationYear", "Available", "Copies", "AvailableCopies", "Developer", "DeveloperMainHq", "DeveloperID"}
+
+// developerExportColumns are the columns of a developer export
+var developerExportColumns = []string{"ID", "Name", "MainHq"}
+
+type ExportService struct {
+	developerRepository _interface.DeveloperRepositorer
+	gameRepository      _interface.GameRepositorer
+	logger              *zap.Logger
+}
+
+// NewExportService creates a new ExportService
+// It returns a pointer to an ExportService and an error
+func NewExportService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, logger *zap.Logger) (_interface.ExportServicer, error) {
+	return &ExportService{
+		developerRepository: developerRepository,
+		gameRepository:      gameRepository,
+		logger:              logger,
+	}, nil
+}
+
+// ExportGames writes the games matching the filter to w in the given format, sorted like a listing
+// Games are written as they are read from the repository, so the catalogue is never held in memory.
+// Nothing is written if the format, filter or sort is invalid
+func (s *ExportService) ExportGames(ctx context.Context, filter model.GameFilter, sort string, format model.FileFormat, w io.Writer) error {
+	writer, err := tabular.NewWriter(w, format, gameExportColumns)
+	if err != nil {
+		return apperr.Validation("%v", err)
+	}
+	err = s.gameRepository.StreamGames(ctx, filter, sort, func(g model.Game) error {
+		return writer.Write(g.ID.Hex(), g.Title, g.Genre, g.PublicationYear, g.Available, g.Copies, g.AvailableCopies,
+			g.Developer.Name, g.Developer.MainHq, g.Developer.ID.Hex())
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		s.logger.Error("Error exporting games", zap.Any("filter", filter), zap.String("format", string(format)), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// ExportDevelopers writes the developers matching the filter to w in the given format, sorted like a listing
+// Nothing is written if the format, filter or sort is invalid
+func (s *ExportService) ExportDevelopers(ctx context.Context, filter model.DeveloperFilter, sort string, format model.FileFormat, w io.Writer) error {
+	writer, err := tabular.NewWriter(w, format, developerExportColumns)
+	if err != nil {
+		return apperr.Validation("%v", err)
+	}
+	err = s.developerRepository.StreamDevelopers(ctx, filter, sort, func(d model.Developer) error {
+		return writer.Write(d.ID.Hex(), d.Name, d.MainHq)
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		s.logger.Error("Error exporting developers", zap.Any("filter", filter), zap.String("format", string(format)), zap.Error(err))
+		return err
+	}
+	return nil
+}
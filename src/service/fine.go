@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.uber.org/zap"
+	"time"
+)
+
+// FinePolicy sets how late fees are computed. Amounts are in cents
+type FinePolicy struct {
+	// DailyRate is charged for each started day a loan is overdue
+	DailyRate int64
+	// Cap is the most a single loan is fined
+	Cap int64
+	// Threshold is the open fine balance above which a member may not borrow
+	Threshold int64
+}
+
+// amount returns the fine for a loan that is daysLate days overdue
+func (p FinePolicy) amount(daysLate int) int64 {
+	return min(int64(daysLate)*p.DailyRate, p.Cap)
+}
+
+// daysLate returns the number of started days between dueAt and at, or 0 if at is not after dueAt
+func daysLate(dueAt time.Time, at time.Time) int {
+	late := at.Sub(dueAt)
+	if late <= 0 {
+		return 0
+	}
+	day := 24 * time.Hour
+	return int((late + day - 1) / day)
+}
+
+type FineService struct {
+	fineRepository   _interface.FineRepositorer
+	memberRepository _interface.MemberRepositorer
+	loanRepository   _interface.LoanRepositorer
+	transactor       _interface.Transactor
+	ledger           fineLedger
+	logger           *zap.Logger
+}
+
+// NewFineService creates a new FineService
+// Overdue loans are fined according to policy
+// It returns a pointer to a FineService and an error
+func NewFineService(fineRepository _interface.FineRepositorer, memberRepository _interface.MemberRepositorer, loanRepository _interface.LoanRepositorer, transactor _interface.Transactor, policy FinePolicy, logger *zap.Logger) (_interface.FineServicer, error) {
+	return &FineService{
+		fineRepository:   fineRepository,
+		memberRepository: memberRepository,
+		loanRepository:   loanRepository,
+		transactor:       transactor,
+		ledger:           newFineLedger(fineRepository, loanRepository, policy),
+		logger:           logger,
+	}, nil
+}
+
+// ListFines gets one page of fines matching the query in the order they were assessed first
+func (s *FineService) ListFines(ctx context.Context, query model.FineQuery) (*model.Page[model.Fine], error) {
+	page, err := s.fineRepository.PageFines(ctx, query)
+	if err != nil {
+		s.logger.Error("Error listing fines", zap.Any("query", query), zap.Error(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetFineById gets a fine by ID
+func (s *FineService) GetFineById(ctx context.Context, id string) (*model.Fine, error) {
+	fine, err := s.fineRepository.GetFineById(ctx, id)
+	if err != nil {
+		s.logger.Error("Error getting fine by ID", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return fine, nil
+}
+
+// GetFineAccount gets the fines of a member and the balance they still owe
+func (s *FineService) GetFineAccount(ctx context.Context, memberID string) (*model.FineAccount, error) {
+	member, err := s.memberRepository.GetMemberById(ctx, memberID)
+	if err != nil {
+		s.logger.Error("Error getting member for fine account", zap.String("member", memberID), zap.Error(err))
+		return nil, err
+	}
+	fines, err := s.fineRepository.ListFines(ctx, model.FineFilter{MemberID: memberID})
+	if err != nil {
+		s.logger.Error("Error listing fines of member", zap.String("member", memberID), zap.Error(err))
+		return nil, err
+	}
+
+	account := &model.FineAccount{MemberID: member.ID, Fines: fines}
+	for _, f := range fines {
+		account.Balance += f.Balance()
+	}
+	return account, nil
+}
+
+// PayFine records a payment of amount cents towards an open fine, which is paid once nothing is left to pay
+// A non-zero version makes the payment conditional on the fine still being at that version
+func (s *FineService) PayFine(ctx context.Context, id string, amount int64, version int64) (*model.Fine, error) {
+	var paid *model.Fine
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		fine, err := s.openFine(ctx, id, version)
+		if err != nil {
+			return err
+		}
+		if amount <= 0 {
+			return apperr.Validation("amount must be positive")
+		}
+		if amount > fine.Balance() {
+			return apperr.Validation("amount %d is more than the balance of %d", amount, fine.Balance())
+		}
+
+		fine.Paid += amount
+		if fine.Balance() == 0 {
+			closedAt := s.ledger.now()
+			fine.Status = model.FinePaid
+			fine.ClosedAt = &closedAt
+		}
+		paid, err = s.fineRepository.UpdateFine(ctx, id, *fine)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error paying fine", zap.String("id", id), zap.Int64("amount", amount), zap.Error(err))
+		return nil, err
+	}
+	return paid, nil
+}
+
+// WaiveFine forgives what is left to pay of an open fine. A waived fine no longer grows
+// A non-zero version makes the waiver conditional on the fine still being at that version
+func (s *FineService) WaiveFine(ctx context.Context, id string, version int64) (*model.Fine, error) {
+	var waived *model.Fine
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		fine, err := s.openFine(ctx, id, version)
+		if err != nil {
+			return err
+		}
+
+		closedAt := s.ledger.now()
+		fine.Status = model.FineWaived
+		fine.ClosedAt = &closedAt
+		waived, err = s.fineRepository.UpdateFine(ctx, id, *fine)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error waiving fine", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return waived, nil
+}
+
+// openFine gets a fine that is at version, unless it is 0, and still open
+func (s *FineService) openFine(ctx context.Context, id string, version int64) (*model.Fine, error) {
+	fine, err := s.fineRepository.GetFineById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && fine.Version != version {
+		return nil, apperr.VersionMismatch("fine", fine.Version, version)
+	}
+	if fine.Status != model.FineOpen {
+		return nil, apperr.Conflict("fine is already %s", fine.Status)
+	}
+	return fine, nil
+}
+
+// AssessOverdueLoans marks the loans still out after their due date as overdue and fines them for the days they are late
+// Each loan is assessed in its own transaction. It returns the number of loans assessed
+func (s *FineService) AssessOverdueLoans(ctx context.Context) (int, error) {
+	at := s.ledger.now()
+	active := true
+	loans, err := s.loanRepository.ListLoans(ctx, model.LoanFilter{Active: &active, DueBefore: at})
+	if err != nil {
+		s.logger.Error("Error listing overdue loans", zap.Error(err))
+		return 0, err
+	}
+
+	assessed := 0
+	for _, loan := range loans {
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return s.ledger.assess(ctx, loan, at)
+		})
+		if errors.Is(err, apperr.ErrVersionMismatch) || errors.Is(err, apperr.ErrConflict) {
+			// The fine was paid or waived since it was read, the next run assesses it again.
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Error assessing overdue loan", zap.String("id", loan.ID.Hex()), zap.Error(err))
+			return assessed, err
+		}
+		assessed++
+	}
+	if assessed > 0 {
+		s.logger.Info("Assessed overdue loans", zap.Int("loans", assessed))
+	}
+	return assessed, nil
+}
+
+// fineLedger keeps the fines of overdue loans up to date
+// It is shared by the services that find loans overdue
+type fineLedger struct {
+	fineRepository _interface.FineRepositorer
+	loanRepository _interface.LoanRepositorer
+	policy         FinePolicy
+	now            func() time.Time
+}
+
+// newFineLedger creates a fineLedger that fines overdue loans according to policy
+func newFineLedger(fineRepository _interface.FineRepositorer, loanRepository _interface.LoanRepositorer, policy FinePolicy) fineLedger {
+	return fineLedger{
+		fineRepository: fineRepository,
+		loanRepository: loanRepository,
+		policy:         policy,
+		now:            now,
+	}
+}
+
+// assess marks a loan that is late at the given time as overdue and creates or updates its fine
+// A waived fine is left alone, and a paid fine that grew is open again. A loan that is not late is left alone,
+// and no fine is created for a loan that would be fined nothing
+func (l fineLedger) assess(ctx context.Context, loan model.Loan, at time.Time) error {
+	days := daysLate(loan.DueAt, at)
+	if days == 0 {
+		return nil
+	}
+	if !loan.Overdue {
+		if _, err := l.loanRepository.MarkOverdue(ctx, loan.ID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	fines, err := l.fineRepository.ListFines(ctx, model.FineFilter{LoanID: loan.ID.Hex()})
+	if err != nil {
+		return err
+	}
+	amount := l.policy.amount(days)
+	if len(fines) == 0 && amount == 0 {
+		return nil
+	}
+	if len(fines) == 0 {
+		_, err = l.fineRepository.AddFine(ctx, model.Fine{
+			LoanID:     loan.ID,
+			MemberID:   loan.MemberID,
+			GameID:     loan.GameID,
+			DaysLate:   days,
+			Amount:     amount,
+			Status:     model.FineOpen,
+			AssessedAt: l.now(),
+		})
+		return err
+	}
+
+	fine := fines[0]
+	if fine.Status == model.FineWaived || fine.DaysLate == days && fine.Amount == amount {
+		return nil
+	}
+	fine.DaysLate = days
+	fine.Amount = amount
+	fine.AssessedAt = l.now()
+	if fine.Amount > fine.Paid {
+		fine.Status = model.FineOpen
+		fine.ClosedAt = nil
+	}
+	_, err = l.fineRepository.UpdateFine(ctx, fine.ID.Hex(), fine)
+	return err
+}
+
+// balance returns what a member still owes in open fines
+func (l fineLedger) balance(ctx context.Context, memberID string) (int64, error) {
+	fines, err := l.fineRepository.ListFines(ctx, model.FineFilter{MemberID: memberID, Status: model.FineOpen})
+	if err != nil {
+		return 0, err
+	}
+	var balance int64
+	for _, f := range fines {
+		balance += f.Balance()
+	}
+	return balance, nil
+}
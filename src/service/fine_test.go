@@ -0,0 +1,277 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// lateLoan adds an active loan of a member that has been due for late
+func (l *library) lateLoan(t *testing.T, member *model.Member, late time.Duration) *model.Loan {
+	t.Helper()
+	dueAt := time.Now().Add(-late).UTC().Truncate(time.Millisecond)
+	loan, err := l.loanRepository.AddLoan(context.Background(), model.Loan{GameID: primitive.NewObjectID(), MemberID: member.ID, CheckedOutAt: dueAt.Add(-loanPeriod), DueAt: dueAt})
+	if err != nil {
+		t.Fatalf("AddLoan: %v", err)
+	}
+	return loan
+}
+
+// fineOf returns the fine of a loan, or nil if it has none
+func (l *library) fineOf(t *testing.T, loan *model.Loan) *model.Fine {
+	t.Helper()
+	fines, err := l.fineRepository.ListFines(context.Background(), model.FineFilter{LoanID: loan.ID.Hex()})
+	if err != nil {
+		t.Fatalf("ListFines: %v", err)
+	}
+	if len(fines) == 0 {
+		return nil
+	}
+	return &fines[0]
+}
+
+// addFine adds an open fine of a member
+func (l *library) addFine(t *testing.T, member *model.Member, amount int64) *model.Fine {
+	t.Helper()
+	fine, err := l.fineRepository.AddFine(context.Background(), model.Fine{LoanID: primitive.NewObjectID(), MemberID: member.ID, GameID: primitive.NewObjectID(), DaysLate: 1, Amount: amount, Status: model.FineOpen, AssessedAt: time.Now().UTC()})
+	if err != nil {
+		t.Fatalf("AddFine: %v", err)
+	}
+	return fine
+}
+
+// TestAssessOverdueLoans checks that started days late are fined at the daily rate up to the cap.
+func TestAssessOverdueLoans(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	member := l.member(t, "geralt")
+	tests := []struct {
+		name   string
+		late   time.Duration
+		days   int
+		amount int64
+	}{
+		{"a minute late", time.Minute, 1, 25},
+		{"almost a day late", 23 * time.Hour, 1, 25},
+		{"a day and an hour late", 25 * time.Hour, 2, 50},
+		{"just under the cap", 38*24*time.Hour + time.Hour, 39, 975},
+		{"at the cap", 39*24*time.Hour + time.Hour, 40, 1000},
+		{"over the cap", 100*24*time.Hour + time.Hour, 101, 1000},
+		{"not due yet", -time.Hour, 0, 0},
+	}
+	loans := make([]*model.Loan, len(tests))
+	for i, tt := range tests {
+		loans[i] = l.lateLoan(t, member, tt.late)
+	}
+
+	for run := 1; run <= 2; run++ {
+		assessed, err := l.fines.AssessOverdueLoans(ctx)
+		if err != nil {
+			t.Fatalf("AssessOverdueLoans: %v", err)
+		}
+		if assessed != len(tests)-1 {
+			t.Errorf("run %d assessed %d loans, want %d", run, assessed, len(tests)-1)
+		}
+	}
+	for i, tt := range tests {
+		fine := l.fineOf(t, loans[i])
+		if tt.days == 0 {
+			if fine != nil {
+				t.Errorf("%s: fined %+v", tt.name, *fine)
+			}
+			continue
+		}
+		if fine == nil {
+			t.Errorf("%s: not fined", tt.name)
+			continue
+		}
+		if fine.DaysLate != tt.days || fine.Amount != tt.amount || fine.Status != model.FineOpen || fine.Version != 1 {
+			t.Errorf("%s: fine = %d days, %d cents, %s, version %d, want %d days, %d cents, open, version 1", tt.name, fine.DaysLate, fine.Amount, fine.Status, fine.Version, tt.days, tt.amount)
+		}
+		loan, err := l.loans.GetLoanById(ctx, loans[i].ID.Hex())
+		if err != nil {
+			t.Fatalf("GetLoanById: %v", err)
+		}
+		if !loan.Overdue {
+			t.Errorf("%s: loan is not marked overdue", tt.name)
+		}
+	}
+}
+
+// TestAssessOverdueLoansClosedFines checks that a waived fine stays waived and a paid fine that grew is open again.
+func TestAssessOverdueLoansClosedFines(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	member := l.member(t, "geralt")
+	closedAt := time.Now().UTC().Truncate(time.Millisecond)
+	closed := map[model.FineStatus]*model.Loan{}
+	for _, status := range []model.FineStatus{model.FineWaived, model.FinePaid} {
+		loan := l.lateLoan(t, member, 49*time.Hour)
+		fine := model.Fine{LoanID: loan.ID, MemberID: member.ID, GameID: loan.GameID, DaysLate: 1, Amount: 25, Status: status, AssessedAt: closedAt, ClosedAt: &closedAt}
+		if status == model.FinePaid {
+			fine.Paid = 25
+		}
+		if _, err := l.fineRepository.AddFine(ctx, fine); err != nil {
+			t.Fatalf("AddFine: %v", err)
+		}
+		closed[status] = loan
+	}
+
+	if _, err := l.fines.AssessOverdueLoans(ctx); err != nil {
+		t.Fatalf("AssessOverdueLoans: %v", err)
+	}
+	if waived := l.fineOf(t, closed[model.FineWaived]); waived.Status != model.FineWaived || waived.Amount != 25 {
+		t.Errorf("waived fine = %s with %d cents, want waived with 25", waived.Status, waived.Amount)
+	}
+	paid := l.fineOf(t, closed[model.FinePaid])
+	if paid.Status != model.FineOpen || paid.DaysLate != 3 || paid.Amount != 75 || paid.Balance() != 50 || paid.ClosedAt != nil {
+		t.Errorf("grown paid fine = %s, %d days, %d cents owing %d, want open, 3 days, 75 cents owing 50", paid.Status, paid.DaysLate, paid.Amount, paid.Balance())
+	}
+}
+
+// failingTransactor fails the transaction numbered fail, counting from 1
+type failingTransactor struct {
+	_interface.Transactor
+	fail  int
+	calls int
+}
+
+var errTransaction = errors.New("transaction failed")
+
+// WithinTransaction fails the transaction numbered fail and runs the others
+func (f *failingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if f.calls++; f.calls == f.fail {
+		return errTransaction
+	}
+	return f.Transactor.WithinTransaction(ctx, fn)
+}
+
+// TestAssessOverdueLoansPerLoan checks that each loan is fined in its own transaction, so a failure keeps the fines already assessed.
+func TestAssessOverdueLoansPerLoan(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	member := l.member(t, "geralt")
+	loans := []*model.Loan{l.lateLoan(t, member, time.Hour), l.lateLoan(t, member, time.Hour), l.lateLoan(t, member, time.Hour)}
+
+	fines, err := service.NewFineService(l.fineRepository, l.memberRepository, l.loanRepository, &failingTransactor{Transactor: l.transactor, fail: 2}, finePolicy, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewFineService: %v", err)
+	}
+	assessed, err := fines.AssessOverdueLoans(ctx)
+	if !errors.Is(err, errTransaction) || assessed != 1 {
+		t.Fatalf("AssessOverdueLoans = %d, %v, want 1 and %v", assessed, err, errTransaction)
+	}
+	fined := 0
+	for _, loan := range loans {
+		if l.fineOf(t, loan) != nil {
+			fined++
+		}
+	}
+	if fined != 1 {
+		t.Errorf("%d loans fined, want the 1 assessed before the failure", fined)
+	}
+
+	if assessed, err := l.fines.AssessOverdueLoans(ctx); err != nil || assessed != len(loans) {
+		t.Errorf("AssessOverdueLoans after the failure = %d, %v, want %d", assessed, err, len(loans))
+	}
+}
+
+// TestAssessOverdueLoansWithoutRate checks that a daily rate of zero marks loans overdue without fining them.
+func TestAssessOverdueLoansWithoutRate(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	loan := l.lateLoan(t, l.member(t, "geralt"), 25*time.Hour)
+	fines, err := service.NewFineService(l.fineRepository, l.memberRepository, l.loanRepository, l.transactor, service.FinePolicy{Cap: 1000, Threshold: 500}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewFineService: %v", err)
+	}
+	if _, err := fines.AssessOverdueLoans(ctx); err != nil {
+		t.Fatalf("AssessOverdueLoans: %v", err)
+	}
+	if fine := l.fineOf(t, loan); fine != nil {
+		t.Errorf("loan fined %d cents without a daily rate", fine.Amount)
+	}
+	if stored, err := l.loans.GetLoanById(ctx, loan.ID.Hex()); err != nil || !stored.Overdue {
+		t.Errorf("loan overdue = %v, %v, want it marked", stored != nil && stored.Overdue, err)
+	}
+}
+
+// TestFineBalance checks that the balance of a member is what is left to pay of their open fines.
+func TestFineBalance(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	member := l.member(t, "geralt")
+	first := l.addFine(t, member, 300)
+	second := l.addFine(t, member, 200)
+	waived := l.addFine(t, member, 1000)
+	l.addFine(t, l.member(t, "yennefer"), 400)
+
+	balance := func() int64 {
+		t.Helper()
+		account, err := l.fines.GetFineAccount(ctx, member.ID.Hex())
+		if err != nil {
+			t.Fatalf("GetFineAccount: %v", err)
+		}
+		return account.Balance
+	}
+	if _, err := l.fines.WaiveFine(ctx, waived.ID.Hex(), 0); err != nil {
+		t.Fatalf("WaiveFine: %v", err)
+	}
+	if got := balance(); got != 500 {
+		t.Errorf("balance = %d, want 500", got)
+	}
+
+	if _, err := l.fines.PayFine(ctx, first.ID.Hex(), 301, 0); !errors.Is(err, apperr.ErrValidation) {
+		t.Errorf("PayFine of more than the balance error = %v, want a validation error", err)
+	}
+	if _, err := l.fines.PayFine(ctx, first.ID.Hex(), 0, 0); !errors.Is(err, apperr.ErrValidation) {
+		t.Errorf("PayFine of nothing error = %v, want a validation error", err)
+	}
+	if paid, err := l.fines.PayFine(ctx, first.ID.Hex(), 100, 0); err != nil || paid.Status != model.FineOpen {
+		t.Fatalf("PayFine of part = %v, %v, want the fine still open", paid, err)
+	}
+	if got := balance(); got != 400 {
+		t.Errorf("balance after paying 100 = %d, want 400", got)
+	}
+	if paid, err := l.fines.PayFine(ctx, second.ID.Hex(), 200, 0); err != nil || paid.Status != model.FinePaid || paid.ClosedAt == nil {
+		t.Fatalf("PayFine of all = %v, %v, want the fine paid", paid, err)
+	}
+	if _, err := l.fines.PayFine(ctx, second.ID.Hex(), 1, 0); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("PayFine of a paid fine error = %v, want a conflict", err)
+	}
+	if got := balance(); got != 200 {
+		t.Errorf("balance after paying a fine = %d, want 200", got)
+	}
+}
+
+// TestCheckoutFineThreshold checks that a member owing more than the threshold cannot borrow until they pay.
+func TestCheckoutFineThreshold(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	developer := l.developer(t, "CD Projekt")
+	member := l.member(t, "geralt")
+	fine := l.addFine(t, member, finePolicy.Threshold)
+
+	l.checkout(t, l.game(t, developer, "The Witcher"), member)
+
+	l.addFine(t, member, 1)
+	game := l.game(t, developer, "The Witcher 2")
+	if _, err := l.loans.Checkout(ctx, game.ID.Hex(), member.ID.Hex(), ""); !errors.Is(err, apperr.ErrConflict) {
+		t.Fatalf("Checkout over the threshold error = %v, want a conflict", err)
+	}
+	if stored, err := l.games.GetGameById(ctx, game.ID.Hex()); err != nil || !stored.Available {
+		t.Errorf("refused game available = %v, %v, want it still available", stored != nil && stored.Available, err)
+	}
+
+	if _, err := l.fines.PayFine(ctx, fine.ID.Hex(), 1, 0); err != nil {
+		t.Fatalf("PayFine: %v", err)
+	}
+	l.checkout(t, game, member)
+}
@@ -1,34 +1,69 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"game-library-management-system/src/apperr"
 	"game-library-management-system/src/interface"
 	"game-library-management-system/src/model"
+	"game-library-management-system/src/patch"
+	"game-library-management-system/src/search"
 	"go.uber.org/zap"
+	"maps"
+	"slices"
+	"time"
 )
 
+// patchAttempts is how often PatchGame reapplies a patch to a game that changed concurrently
+const patchAttempts = 3
+
+// ErrAvailabilityFromCopies is returned when setting the availability of a game that has copies,
+// which is computed from the copies instead
+var ErrAvailabilityFromCopies = apperr.Conflict("the availability of a game with copies follows its copies")
+
+// ErrGameReserved is returned when making a game available while it is reserved for a hold
+var ErrGameReserved = apperr.Conflict("game is reserved for a hold")
+
+// ErrGameOnLoan is returned when making a game available while it is checked out, which only returning it does
+var ErrGameOnLoan = apperr.Conflict("game is checked out, return it to make it available")
+
+// ErrDeleteOnLoan is returned when deleting a game that is checked out, directly or with its developer
+var ErrDeleteOnLoan = apperr.Conflict("game is checked out, return it before deleting it")
+
 type GameService struct {
 	gameRepository _interface.GameRepositorer
+	holdRepository _interface.HoldRepositorer
+	loanRepository _interface.LoanRepositorer
+	transactor     _interface.Transactor
+	holds          holdQueue
 	logger         *zap.Logger
 }
 
 // NewGameService creates a new GameService
+// A game made available again is reserved for the next hold in its queue for pickupWindow
 // It returns a pointer to a GameService and an error
-func NewGameService(gameRepository _interface.GameRepositorer, logger *zap.Logger) (_interface.GameServicer, error) {
+func NewGameService(gameRepository _interface.GameRepositorer, holdRepository _interface.HoldRepositorer, loanRepository _interface.LoanRepositorer, memberRepository _interface.MemberRepositorer, transactor _interface.Transactor, pickupWindow time.Duration, logger *zap.Logger) (_interface.GameServicer, error) {
 	return &GameService{
 		gameRepository: gameRepository,
-		logger:         logger,
+		holdRepository: holdRepository,
+		loanRepository: loanRepository,
+		transactor:     transactor,
+		// Only games without copies have their availability set here, so the queue needs no copies.
+		holds:  newHoldQueue(holdRepository, gameRepository, nil, memberRepository, pickupWindow),
+		logger: logger,
 	}, nil
 }
 
-// GetAllGames gets all games
-func (s *GameService) GetAllGames(ctx context.Context) ([]model.Game, error) {
-	games, err := s.gameRepository.GetAllGames(ctx)
+// ListGames gets one page of games matching the query
+func (s *GameService) ListGames(ctx context.Context, query model.GameQuery) (*model.Page[model.Game], error) {
+	page, err := s.gameRepository.ListGames(ctx, query)
 	if err != nil {
-		s.logger.Error("Error getting all games", zap.Error(err))
+		s.logger.Error("Error listing games", zap.Any("query", query), zap.Error(err))
 		return nil, err
 	}
-	return games, nil
+	return page, nil
 }
 
 // GetGameById gets a game by ID
@@ -41,8 +76,24 @@ func (s *GameService) GetGameById(ctx context.Context, id string) (*model.Game,
 	return game, nil
 }
 
+// SearchGames ranks games by relevance to a free-text query over title, genre and developer name
+// Typo tolerant matching cannot be pushed down to the database, so the whole catalogue is ranked in memory
+func (s *GameService) SearchGames(ctx context.Context, query string, limit int) ([]model.GameSearchResult, error) {
+	games, err := s.gameRepository.GetAllGames(ctx)
+	if err != nil {
+		s.logger.Error("Error searching games", zap.String("query", query), zap.Error(err))
+		return nil, err
+	}
+	return search.Games(games, query, limit), nil
+}
+
 // AddGame adds a game
+// A new game has no copies; they are added afterwards and counted by the copy service
 func (s *GameService) AddGame(ctx context.Context, game model.Game) (*model.Game, error) {
+	if err := gameRules.Validate(game); err != nil {
+		return nil, err
+	}
+	game.Copies, game.AvailableCopies = 0, 0
 	newGame, err := s.gameRepository.AddGame(ctx, game)
 	if err != nil {
 		s.logger.Error("Error adding game", zap.Error(err))
@@ -51,9 +102,237 @@ func (s *GameService) AddGame(ctx context.Context, game model.Game) (*model.Game
 	return newGame, nil
 }
 
+// UpdateGame replaces a game's title, developer, genre, year and availability
+// A non-zero game.Version makes the update conditional on the game still being at that version.
+// The availability of a game with copies cannot be changed, and a game that is checked out cannot be made available.
+// The game is written back conditionally, so that a checkout meanwhile is not overwritten
+func (s *GameService) UpdateGame(ctx context.Context, id string, game model.Game) (*model.Game, error) {
+	if err := gameRules.Validate(game); err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		var updatedGame *model.Game
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			current, err := s.gameRepository.GetGameById(ctx, id)
+			if err != nil {
+				return err
+			}
+			updatedGame, err = s.writeGame(ctx, *current, game)
+			return err
+		})
+		if errors.Is(err, apperr.ErrVersionMismatch) && game.Version == 0 && attempt < patchAttempts {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Error updating game", zap.String("id", id), zap.Error(err))
+			return nil, err
+		}
+		return updatedGame, nil
+	}
+}
+
+// PatchGame applies a patch to a game and stores the result like UpdateGame
+// A Version in the patch must match the stored one. A patch that changes nothing is not written,
+// so repeating it is harmless. If the game changes between reading and writing it, the patch is
+// applied again to the new state, up to patchAttempts times
+func (s *GameService) PatchGame(ctx context.Context, id string, p model.Patch) (*model.Game, error) {
+	for attempt := 1; ; attempt++ {
+		current, err := s.gameRepository.GetGameById(ctx, id)
+		if err != nil {
+			s.logger.Error("Error patching game", zap.String("id", id), zap.Error(err))
+			return nil, err
+		}
+
+		if p.Version != 0 && current.Version != p.Version {
+			return nil, apperr.VersionMismatch("game", current.Version, p.Version)
+		}
+
+		patched, err := applyPatch(*current, p)
+		if err != nil {
+			return nil, err
+		}
+		if patched.ID != current.ID {
+			return nil, apperr.Validation("the ID of a game cannot be changed")
+		}
+		if patched.Version != current.Version {
+			return nil, apperr.VersionMismatch("game", current.Version, patched.Version)
+		}
+		if patched.Copies != current.Copies || patched.AvailableCopies != current.AvailableCopies {
+			return nil, apperr.Validation("the copy counts of a game are computed from its copies")
+		}
+		if patched == *current {
+			return current, nil
+		}
+		if err := gameRules.Validate(patched); err != nil {
+			return nil, err
+		}
+
+		var updatedGame *model.Game
+		err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			updatedGame, err = s.writeGame(ctx, *current, patched)
+			return err
+		})
+		if errors.Is(err, apperr.ErrVersionMismatch) && attempt < patchAttempts {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Error patching game", zap.String("id", id), zap.Int("attempt", attempt), zap.Error(err))
+			return nil, err
+		}
+		return updatedGame, nil
+	}
+}
+
+// writeGame stores a new state of a game that was read as current, on condition that it is still at that version
+// The availability of a game with copies follows its copies. A game without copies that is made available
+// goes through makeAvailable, so that it is refused while the game is checked out and goes to the next hold
+// in its queue if anyone is waiting. It must run in a transaction
+func (s *GameService) writeGame(ctx context.Context, current model.Game, game model.Game) (*model.Game, error) {
+	if game.Available != current.Available && current.Copies > 0 {
+		return nil, ErrAvailabilityFromCopies
+	}
+	release := game.Available && !current.Available
+	if release {
+		game.Available = false
+	}
+	if game.Version == 0 {
+		game.Version = current.Version
+	}
+	updatedGame, err := s.gameRepository.UpdateGame(ctx, current.ID.Hex(), game)
+	if err != nil || !release {
+		return updatedGame, err
+	}
+	return s.makeAvailable(ctx, current.ID.Hex())
+}
+
+// checkNotOnLoan returns ErrGameOnLoan if the game has an active loan
+func (s *GameService) checkNotOnLoan(ctx context.Context, id string) error {
+	loaned, err := onLoan(ctx, s.loanRepository, id)
+	if err != nil {
+		return err
+	}
+	if loaned {
+		return ErrGameOnLoan
+	}
+	return nil
+}
+
+// checkGamesNotOnLoan returns ErrDeleteOnLoan if one of the games is checked out
+func checkGamesNotOnLoan(ctx context.Context, loanRepository _interface.LoanRepositorer, gameIDs []string) error {
+	for _, id := range gameIDs {
+		loaned, err := onLoan(ctx, loanRepository, id)
+		if err != nil {
+			return err
+		}
+		if loaned {
+			return ErrDeleteOnLoan
+		}
+	}
+	return nil
+}
+
+// developerGameIDs returns the IDs of the games of a developer
+func developerGameIDs(ctx context.Context, gameRepository _interface.GameRepositorer, developerID string) ([]string, error) {
+	var ids []string
+	err := gameRepository.StreamGames(ctx, model.GameFilter{DeveloperID: developerID}, "", func(game model.Game) error {
+		ids = append(ids, game.ID.Hex())
+		return nil
+	})
+	return ids, err
+}
+
+// onLoan reports whether a game, or one of its copies, has an active loan
+func onLoan(ctx context.Context, loanRepository _interface.LoanRepositorer, gameID string) (bool, error) {
+	active := true
+	loans, err := loanRepository.ListLoans(ctx, model.LoanFilter{GameID: gameID, Active: &active})
+	return len(loans) > 0, err
+}
+
+// applyPatch applies a patch to the JSON representation of a game
+// Field names are matched exactly, so a patch adding a member the game does not have is refused
+func applyPatch(game model.Game, p model.Patch) (model.Game, error) {
+	original, err := json.Marshal(game)
+	if err != nil {
+		return game, err
+	}
+	var doc []byte
+	switch p.Format {
+	case model.MergePatch:
+		doc, err = patch.Merge(original, p.Document)
+	case model.JSONPatch:
+		doc, err = patch.Apply(original, p.Document)
+	default:
+		return game, apperr.Validation("unsupported patch format %q", p.Format)
+	}
+	if errors.Is(err, patch.ErrTestFailed) {
+		return game, apperr.Conflict("%v", err)
+	}
+	if err != nil {
+		return game, apperr.Validation("%v", err)
+	}
+	var before, after any
+	if err := json.Unmarshal(original, &before); err != nil {
+		return game, err
+	}
+	if err := json.Unmarshal(doc, &after); err != nil {
+		return game, err
+	}
+	if member := newMember(before, after, ""); member != "" {
+		return game, apperr.Validation("the patched game has no field %q", member)
+	}
+
+	var patched model.Game
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patched); err != nil {
+		return game, apperr.Validation("the patched game is not valid: %v", err)
+	}
+	return patched, nil
+}
+
+// newMember returns the path of a member of the object after that the object before does not have,
+// looking into the objects both have, or "" if there is none
+func newMember(before, after any, path string) string {
+	a, ok := after.(map[string]any)
+	if !ok {
+		return ""
+	}
+	b, ok := before.(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, name := range slices.Sorted(maps.Keys(a)) {
+		existing, ok := b[name]
+		if !ok {
+			return path + "/" + name
+		}
+		if member := newMember(existing, a[name], path+"/"+name); member != "" {
+			return member
+		}
+	}
+	return ""
+}
+
 // UpdateAvailability updates a game's availability
+// Only games without copies can be toggled. A game made available goes to the next hold in its queue instead
 func (s *GameService) UpdateAvailability(ctx context.Context, id string) (*model.Game, error) {
-	updatedGame, err := s.gameRepository.UpdateAvailability(ctx, id)
+	var updatedGame *model.Game
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		game, err := s.gameRepository.GetGameById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if game.Copies > 0 {
+			return ErrAvailabilityFromCopies
+		}
+		if !game.Available {
+			updatedGame, err = s.makeAvailable(ctx, id)
+			return err
+		}
+		updatedGame, err = s.gameRepository.UpdateAvailability(ctx, id)
+		return err
+	})
 	if err != nil {
 		s.logger.Error("Error updating game availability", zap.String("id", id), zap.Error(err))
 		return nil, err
@@ -61,9 +340,68 @@ func (s *GameService) UpdateAvailability(ctx context.Context, id string) (*model
 	return updatedGame, nil
 }
 
+// SetAvailability sets a game's availability to an explicit value
+// A non-zero version makes the write conditional on the game still being at that version.
+// Only games without copies can be changed. A game made available goes to the next hold in its queue instead
+func (s *GameService) SetAvailability(ctx context.Context, id string, available bool, version int64) (*model.Game, error) {
+	var updatedGame *model.Game
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		game, err := s.gameRepository.GetGameById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if game.Copies > 0 && available != game.Available {
+			return ErrAvailabilityFromCopies
+		}
+		if available && !game.Available {
+			if version != 0 && game.Version != version {
+				return apperr.VersionMismatch("game", game.Version, version)
+			}
+			updatedGame, err = s.makeAvailable(ctx, id)
+			return err
+		}
+		updatedGame, err = s.gameRepository.SetAvailability(ctx, id, available, version)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error setting game availability", zap.String("id", id), zap.Bool("available", available), zap.Error(err))
+		return nil, err
+	}
+	return updatedGame, nil
+}
+
+// makeAvailable releases an unavailable game without copies to its hold queue
+// A game that is checked out, or already reserved for a ready hold, is not released
+func (s *GameService) makeAvailable(ctx context.Context, id string) (*model.Game, error) {
+	if err := s.checkNotOnLoan(ctx, id); err != nil {
+		return nil, err
+	}
+	ready, err := s.holdRepository.ListHolds(ctx, model.HoldFilter{GameID: id, Status: model.HoldReady})
+	if err != nil {
+		return nil, err
+	}
+	if len(ready) > 0 {
+		return nil, ErrGameReserved
+	}
+	if err := s.holds.release(ctx, id, nil); err != nil {
+		return nil, err
+	}
+	return s.gameRepository.GetGameById(ctx, id)
+}
+
 // DeleteGame deletes a game
-func (s *GameService) DeleteGame(ctx context.Context, id string) error {
-	err := s.gameRepository.DeleteGame(ctx, id)
+// Its copies are deleted with it and its open holds are cancelled. A game that is checked out is not deleted
+// A non-zero version makes the deletion conditional on the game still being at that version
+func (s *GameService) DeleteGame(ctx context.Context, id string, version int64) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := checkGamesNotOnLoan(ctx, s.loanRepository, []string{id}); err != nil {
+			return err
+		}
+		if err := cancelGameHolds(ctx, s.holdRepository, []string{id}); err != nil {
+			return err
+		}
+		return s.gameRepository.DeleteGame(ctx, id, version)
+	})
 	if err != nil {
 		s.logger.Error("Error deleting game", zap.String("id", id), zap.Error(err))
 		return err
@@ -82,8 +420,21 @@ func (s *GameService) FindGamesByDeveloper(ctx context.Context, developer string
 }
 
 // DeleteManyGamesByDeveloper deletes many games by developer
+// No game is deleted while one of them is checked out. The open holds of the games are cancelled
 func (s *GameService) DeleteManyGamesByDeveloper(ctx context.Context, developer string) error {
-	err := s.gameRepository.DeleteManyGamesByDeveloper(ctx, developer)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		gameIDs, err := developerGameIDs(ctx, s.gameRepository, developer)
+		if err != nil {
+			return err
+		}
+		if err := checkGamesNotOnLoan(ctx, s.loanRepository, gameIDs); err != nil {
+			return err
+		}
+		if err := cancelGameHolds(ctx, s.holdRepository, gameIDs); err != nil {
+			return err
+		}
+		return s.gameRepository.DeleteManyGamesByDeveloper(ctx, developer)
+	})
 	if err != nil {
 		s.logger.Error("Error deleting games by developer", zap.String("developer", developer), zap.Error(err))
 		return err
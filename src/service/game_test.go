@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/model"
+	"testing"
+)
+
+// TestDeleteGameOnLoan checks that a checked out game is neither deleted nor cascaded with its developer.
+func TestDeleteGameOnLoan(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	developer := l.developer(t, "CD Projekt")
+	game := l.game(t, developer, "The Witcher")
+	other := l.game(t, developer, "Cyberpunk 2077")
+	loan := l.checkout(t, game, l.member(t, "geralt"))
+
+	if err := l.games.DeleteGame(ctx, game.ID.Hex(), 0); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("DeleteGame error = %v, want a conflict", err)
+	}
+	if err := l.developers.DeleteDeveloper(ctx, developer.ID.Hex(), model.DeleteDeveloperOptions{OnGames: model.GamesCascade}); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("DeleteDeveloper error = %v, want a conflict", err)
+	}
+	for _, id := range []string{game.ID.Hex(), other.ID.Hex()} {
+		if _, err := l.games.GetGameById(ctx, id); err != nil {
+			t.Errorf("GetGameById(%s) after refused deletes: %v", id, err)
+		}
+	}
+	if _, err := l.developers.GetDeveloperById(ctx, developer.ID.Hex()); err != nil {
+		t.Errorf("GetDeveloperById after refused delete: %v", err)
+	}
+
+	if _, err := l.loans.Return(ctx, loan.GameID.Hex(), ""); err != nil {
+		t.Fatalf("Return: %v", err)
+	}
+	if err := l.developers.DeleteDeveloper(ctx, developer.ID.Hex(), model.DeleteDeveloperOptions{OnGames: model.GamesCascade}); err != nil {
+		t.Fatalf("DeleteDeveloper after return: %v", err)
+	}
+	if _, err := l.games.GetGameById(ctx, game.ID.Hex()); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("GetGameById after cascade error = %v, want not found", err)
+	}
+}
+
+// TestPatchGameFieldNames checks that patches name the fields of a game exactly.
+func TestPatchGameFieldNames(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+
+	tests := []struct {
+		name  string
+		patch model.Patch
+		title string
+		err   error
+	}{
+		{"merge patch", model.Patch{Format: model.MergePatch, Document: []byte(`{"Title": "The Witcher 2"}`)}, "The Witcher 2", nil},
+		{"merge patch in lower case", model.Patch{Format: model.MergePatch, Document: []byte(`{"title": "The Witcher 3"}`)}, "", apperr.ErrValidation},
+		{"nested merge patch in lower case", model.Patch{Format: model.MergePatch, Document: []byte(`{"Developer": {"name": "CDPR"}}`)}, "", apperr.ErrValidation},
+		{"json patch", model.Patch{Format: model.JSONPatch, Document: []byte(`[{"op": "replace", "path": "/Title", "value": "The Witcher 3"}]`)}, "The Witcher 3", nil},
+		{"json patch in lower case", model.Patch{Format: model.JSONPatch, Document: []byte(`[{"op": "add", "path": "/title", "value": "Thronebreaker"}]`)}, "", apperr.ErrValidation},
+	}
+	for _, tt := range tests {
+		patched, err := l.games.PatchGame(ctx, game.ID.Hex(), tt.patch)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if patched.Title != tt.title {
+			t.Errorf("%s: title = %q, want %q", tt.name, patched.Title, tt.title)
+		}
+	}
+}
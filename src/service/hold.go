@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"time"
+)
+
+type HoldService struct {
+	holdRepository   _interface.HoldRepositorer
+	gameRepository   _interface.GameRepositorer
+	memberRepository _interface.MemberRepositorer
+	transactor       _interface.Transactor
+	queue            holdQueue
+	logger           *zap.Logger
+}
+
+// NewHoldService creates a new HoldService
+// Games and copies reserved for a hold wait pickupWindow for the member before the hold expires
+// It returns a pointer to a HoldService and an error
+func NewHoldService(holdRepository _interface.HoldRepositorer, gameRepository _interface.GameRepositorer, memberRepository _interface.MemberRepositorer, copyRepository _interface.CopyRepositorer, transactor _interface.Transactor, pickupWindow time.Duration, logger *zap.Logger) (_interface.HoldServicer, error) {
+	return &HoldService{
+		holdRepository:   holdRepository,
+		gameRepository:   gameRepository,
+		memberRepository: memberRepository,
+		transactor:       transactor,
+		queue:            newHoldQueue(holdRepository, gameRepository, copyRepository, memberRepository, pickupWindow),
+		logger:           logger,
+	}, nil
+}
+
+// ListHolds gets one page of holds matching the query in the order they were placed
+func (s *HoldService) ListHolds(ctx context.Context, query model.HoldQuery) (*model.Page[model.Hold], error) {
+	page, err := s.holdRepository.PageHolds(ctx, query)
+	if err != nil {
+		s.logger.Error("Error listing holds", zap.Any("query", query), zap.Error(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetHoldById gets a hold by ID
+func (s *HoldService) GetHoldById(ctx context.Context, id string) (*model.Hold, error) {
+	hold, err := s.holdRepository.GetHoldById(ctx, id)
+	if err != nil {
+		s.logger.Error("Error getting hold by ID", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return hold, nil
+}
+
+// PlaceHold queues an active member for a game that is not available
+// A member has at most one open hold per game
+func (s *HoldService) PlaceHold(ctx context.Context, gameID string, memberID string) (*model.Hold, error) {
+	var hold *model.Hold
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		member, err := s.memberRepository.GetMemberById(ctx, memberID)
+		if errors.Is(err, apperr.ErrNotFound) {
+			return apperr.Validation("member does not exist")
+		}
+		if err != nil {
+			return err
+		}
+		if !member.IsActive() {
+			return apperr.Conflict("member is %s and cannot place holds", member.Status)
+		}
+
+		game, err := s.gameRepository.GetGameById(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if game.Available {
+			return apperr.Conflict("game is available, check it out instead")
+		}
+
+		holds, err := s.holdRepository.ListHolds(ctx, model.HoldFilter{GameID: gameID, MemberID: memberID})
+		if err != nil {
+			return err
+		}
+		for _, h := range holds {
+			if h.Status.IsOpen() {
+				return apperr.Conflict("member already has a hold on this game")
+			}
+		}
+
+		hold, err = s.holdRepository.AddHold(ctx, model.Hold{
+			GameID:   game.ID,
+			MemberID: member.ID,
+			Status:   model.HoldWaiting,
+			PlacedAt: s.queue.now(),
+		})
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error placing hold", zap.String("game", gameID), zap.String("member", memberID), zap.Error(err))
+		return nil, err
+	}
+	return hold, nil
+}
+
+// CancelHold withdraws an open hold. The game or copy reserved for a ready hold goes to the next hold in the queue
+// A non-zero version makes the cancellation conditional on the hold still being at that version
+func (s *HoldService) CancelHold(ctx context.Context, id string, version int64) (*model.Hold, error) {
+	var cancelled *model.Hold
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		hold, err := s.holdRepository.GetHoldById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version != 0 && hold.Version != version {
+			return apperr.VersionMismatch("hold", hold.Version, version)
+		}
+		cancelled, err = s.queue.close(ctx, *hold, model.HoldCancelled)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error cancelling hold", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return cancelled, nil
+}
+
+// ExpireHolds expires the ready holds whose pickup window has ended and passes their games and copies on
+// Each hold is expired in its own transaction. It returns the expired holds
+func (s *HoldService) ExpireHolds(ctx context.Context) ([]model.Hold, error) {
+	due, err := s.holdRepository.ListHolds(ctx, model.HoldFilter{Status: model.HoldReady, ExpiredBy: s.queue.now()})
+	if err != nil {
+		s.logger.Error("Error listing expired holds", zap.Error(err))
+		return nil, err
+	}
+
+	expired := make([]model.Hold, 0, len(due))
+	for _, hold := range due {
+		var closed *model.Hold
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			closed, err = s.queue.close(ctx, hold, model.HoldExpired)
+			return err
+		})
+		if errors.Is(err, apperr.ErrVersionMismatch) || errors.Is(err, apperr.ErrConflict) {
+			// The hold was picked up or cancelled since it was listed.
+			continue
+		}
+		if errors.Is(err, apperr.ErrNotFound) {
+			// The game or copy was deleted since the hold was listed; the other holds can still expire.
+			s.logger.Error("Error expiring hold of a deleted game", zap.String("id", hold.ID.Hex()), zap.Error(err))
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Error expiring hold", zap.String("id", hold.ID.Hex()), zap.Error(err))
+			return expired, err
+		}
+		expired = append(expired, *closed)
+	}
+	if len(expired) > 0 {
+		s.logger.Info("Expired holds", zap.Int("holds", len(expired)))
+	}
+	return expired, nil
+}
+
+// holdQueue serves the holds of games in the order they were placed
+// It is shared by the services that make games and copies available again
+type holdQueue struct {
+	holdRepository   _interface.HoldRepositorer
+	gameRepository   _interface.GameRepositorer
+	copyRepository   _interface.CopyRepositorer
+	memberRepository _interface.MemberRepositorer
+	pickupWindow     time.Duration
+	now              func() time.Time
+}
+
+// newHoldQueue creates a holdQueue that reserves games and copies for pickupWindow
+func newHoldQueue(holdRepository _interface.HoldRepositorer, gameRepository _interface.GameRepositorer, copyRepository _interface.CopyRepositorer, memberRepository _interface.MemberRepositorer, pickupWindow time.Duration) holdQueue {
+	return holdQueue{
+		holdRepository:   holdRepository,
+		gameRepository:   gameRepository,
+		copyRepository:   copyRepository,
+		memberRepository: memberRepository,
+		pickupWindow:     pickupWindow,
+		now:              now,
+	}
+}
+
+// release frees a game without copies, or the copy with copyID, for the next member
+// If a hold of an active member is waiting, the first one becomes ready and the game or copy stays reserved for it.
+// Otherwise the game or copy becomes available. It must run in the transaction that freed the game or copy
+func (q holdQueue) release(ctx context.Context, gameID string, copyID *primitive.ObjectID) error {
+	next, err := q.next(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	available := next == nil
+	if copyID == nil {
+		_, err = q.gameRepository.SetAvailability(ctx, gameID, available, 0)
+	} else if _, err = q.copyRepository.SetCopyAvailability(ctx, copyID.Hex(), available, 0); err == nil {
+		err = syncCopyCounts(ctx, q.copyRepository, q.gameRepository, gameID)
+	}
+	if err != nil || available {
+		return err
+	}
+
+	hold := *next
+	readyAt := q.now()
+	expiresAt := readyAt.Add(q.pickupWindow)
+	hold.Status, hold.CopyID, hold.ReadyAt, hold.ExpiresAt = model.HoldReady, copyID, &readyAt, &expiresAt
+	_, err = q.holdRepository.UpdateHold(ctx, hold.ID.Hex(), hold)
+	return err
+}
+
+// next returns the first waiting hold of a game whose member is active, or nil if there is none
+// The holds of members that no longer exist are cancelled. Those of suspended or expired members
+// keep their place in the queue but are passed over until the member is active again
+func (q holdQueue) next(ctx context.Context, gameID string) (*model.Hold, error) {
+	waiting, err := q.holdRepository.ListHolds(ctx, model.HoldFilter{GameID: gameID, Status: model.HoldWaiting})
+	if err != nil {
+		return nil, err
+	}
+	for _, hold := range waiting {
+		member, err := q.memberRepository.GetMemberById(ctx, hold.MemberID.Hex())
+		if errors.Is(err, apperr.ErrNotFound) {
+			if _, err := q.close(ctx, hold, model.HoldCancelled); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if member.IsActive() {
+			return &hold, nil
+		}
+	}
+	return nil, nil
+}
+
+// close ends an open hold with the given status
+// The game or copy reserved for a ready hold that is cancelled or expires is released to the next hold.
+// It must run in a transaction
+func (q holdQueue) close(ctx context.Context, hold model.Hold, status model.HoldStatus) (*model.Hold, error) {
+	if !hold.Status.IsOpen() {
+		return nil, apperr.Conflict("hold is already %s", hold.Status)
+	}
+	wasReady := hold.Status == model.HoldReady
+
+	closedAt := q.now()
+	hold.Status, hold.ClosedAt = status, &closedAt
+	closed, err := q.holdRepository.UpdateHold(ctx, hold.ID.Hex(), hold)
+	if err != nil {
+		return nil, err
+	}
+	if wasReady && status != model.HoldFulfilled {
+		if err := q.release(ctx, closed.GameID.Hex(), closed.CopyID); err != nil {
+			return nil, err
+		}
+	}
+	return closed, nil
+}
+
+// bindGameHold reserves a copy added to a game for the ready hold of the whole game, if there is one
+// The hold keeps its pickup window and the copy does not go to the queue. It reports whether there was such a hold.
+// It must run in the transaction that added the copy
+func (q holdQueue) bindGameHold(ctx context.Context, gameID string, copyID primitive.ObjectID) (bool, error) {
+	ready, err := q.holdRepository.ListHolds(ctx, model.HoldFilter{GameID: gameID, Status: model.HoldReady})
+	if err != nil {
+		return false, err
+	}
+	for _, hold := range ready {
+		if hold.CopyID != nil {
+			continue
+		}
+		if _, err := q.copyRepository.SetCopyAvailability(ctx, copyID.Hex(), false, 0); err != nil {
+			return false, err
+		}
+		if err := syncCopyCounts(ctx, q.copyRepository, q.gameRepository, gameID); err != nil {
+			return false, err
+		}
+		hold.CopyID = &copyID
+		_, err := q.holdRepository.UpdateHold(ctx, hold.ID.Hex(), hold)
+		return true, err
+	}
+	return false, nil
+}
+
+// cancelGameHolds cancels the open holds of games that are being deleted
+// Nothing is released, as the games and copies reserved for ready holds are deleted too. It must run in a transaction
+func cancelGameHolds(ctx context.Context, holdRepository _interface.HoldRepositorer, gameIDs []string) error {
+	for _, gameID := range gameIDs {
+		holds, err := holdRepository.ListHolds(ctx, model.HoldFilter{GameID: gameID})
+		if err != nil {
+			return err
+		}
+		for _, hold := range holds {
+			if !hold.Status.IsOpen() {
+				continue
+			}
+			closedAt := now()
+			hold.Status, hold.ClosedAt = model.HoldCancelled, &closedAt
+			if _, err := holdRepository.UpdateHold(ctx, hold.ID.Hex(), hold); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readyHold returns the ready hold of a member for a game, or nil if there is none
+func (q holdQueue) readyHold(ctx context.Context, gameID string, memberID string) (*model.Hold, error) {
+	holds, err := q.holdRepository.ListHolds(ctx, model.HoldFilter{GameID: gameID, MemberID: memberID, Status: model.HoldReady})
+	if err != nil || len(holds) == 0 {
+		return nil, err
+	}
+	return &holds[0], nil
+}
@@ -0,0 +1,290 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+// placeHold puts a member in the queue for a game
+func (l *library) placeHold(t *testing.T, game *model.Game, member *model.Member) *model.Hold {
+	t.Helper()
+	hold, err := l.holds.PlaceHold(context.Background(), game.ID.Hex(), member.ID.Hex())
+	if err != nil {
+		t.Fatalf("PlaceHold: %v", err)
+	}
+	return hold
+}
+
+// giveBack returns a game without copies
+func (l *library) giveBack(t *testing.T, game *model.Game) {
+	t.Helper()
+	if _, err := l.loans.Return(context.Background(), game.ID.Hex(), ""); err != nil {
+		t.Fatalf("Return: %v", err)
+	}
+}
+
+// holdStatus returns the stored status of a hold
+func (l *library) holdStatus(t *testing.T, hold *model.Hold) model.HoldStatus {
+	t.Helper()
+	stored, err := l.holds.GetHoldById(context.Background(), hold.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetHoldById: %v", err)
+	}
+	return stored.Status
+}
+
+// makeDue ends the pickup window of a ready hold
+func (l *library) makeDue(t *testing.T, hold *model.Hold) {
+	t.Helper()
+	ctx := context.Background()
+	stored, err := l.holdRepository.GetHoldById(ctx, hold.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetHoldById: %v", err)
+	}
+	expiresAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Millisecond)
+	stored.ExpiresAt = &expiresAt
+	if _, err := l.holdRepository.UpdateHold(ctx, hold.ID.Hex(), *stored); err != nil {
+		t.Fatalf("UpdateHold: %v", err)
+	}
+}
+
+// wantStatuses checks the stored status of each hold
+func (l *library) wantStatuses(t *testing.T, step string, holds []*model.Hold, statuses ...model.HoldStatus) {
+	t.Helper()
+	for i, hold := range holds {
+		if status := l.holdStatus(t, hold); status != statuses[i] {
+			t.Errorf("%s: hold %d is %s, want %s", step, i+1, status, statuses[i])
+		}
+	}
+}
+
+// TestHoldQueueOrder checks that a returned game goes to the holds in the order they were placed, passing over inactive members.
+func TestHoldQueueOrder(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+	geralt := l.member(t, "geralt")
+	members := []*model.Member{l.member(t, "yennefer"), l.member(t, "ciri"), l.member(t, "dandelion")}
+
+	l.checkout(t, game, geralt)
+	var holds []*model.Hold
+	for _, member := range members {
+		holds = append(holds, l.placeHold(t, game, member))
+	}
+	if _, err := l.holds.PlaceHold(ctx, game.ID.Hex(), members[0].ID.Hex()); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("second hold of a member error = %v, want a conflict", err)
+	}
+	l.wantStatuses(t, "placed", holds, model.HoldWaiting, model.HoldWaiting, model.HoldWaiting)
+
+	l.giveBack(t, game)
+	l.wantStatuses(t, "returned", holds, model.HoldReady, model.HoldWaiting, model.HoldWaiting)
+	if _, err := l.loans.Checkout(ctx, game.ID.Hex(), members[1].ID.Hex(), ""); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("Checkout of a game reserved for another member error = %v, want a conflict", err)
+	}
+
+	suspended := *members[1]
+	suspended.Status = model.MemberSuspended
+	if _, err := l.members.UpdateMember(ctx, suspended.ID.Hex(), suspended); err != nil {
+		t.Fatalf("UpdateMember: %v", err)
+	}
+	l.checkout(t, game, members[0])
+	l.giveBack(t, game)
+	l.wantStatuses(t, "returned with the next member suspended", holds, model.HoldFulfilled, model.HoldWaiting, model.HoldReady)
+
+	l.checkout(t, game, members[2])
+	l.giveBack(t, game)
+	l.wantStatuses(t, "returned with only the suspended member waiting", holds, model.HoldFulfilled, model.HoldWaiting, model.HoldFulfilled)
+	stored, err := l.games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if !stored.Available {
+		t.Error("game is not available with only a suspended member waiting")
+	}
+}
+
+// TestExpireHoldsAdvancesQueue checks that an expired hold passes the game on to the next hold, and to the shelf after the last one.
+func TestExpireHoldsAdvancesQueue(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+	l.checkout(t, game, l.member(t, "geralt"))
+	holds := []*model.Hold{l.placeHold(t, game, l.member(t, "yennefer")), l.placeHold(t, game, l.member(t, "ciri"))}
+	l.giveBack(t, game)
+
+	if expired, err := l.holds.ExpireHolds(ctx); err != nil || len(expired) != 0 {
+		t.Fatalf("ExpireHolds within the pickup window = %v, %v, want nothing expired", expired, err)
+	}
+
+	for step, statuses := range [][]model.HoldStatus{
+		{model.HoldExpired, model.HoldReady},
+		{model.HoldExpired, model.HoldExpired},
+	} {
+		l.makeDue(t, holds[step])
+		expired, err := l.holds.ExpireHolds(ctx)
+		if err != nil {
+			t.Fatalf("ExpireHolds: %v", err)
+		}
+		if len(expired) != 1 || expired[0].ID != holds[step].ID || expired[0].ClosedAt == nil {
+			t.Errorf("expiry %d expired %v, want hold %d closed", step+1, expired, step+1)
+		}
+		l.wantStatuses(t, fmt.Sprintf("expiry %d", step+1), holds, statuses...)
+	}
+
+	stored, err := l.games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if !stored.Available {
+		t.Error("game is not available after the last hold expired")
+	}
+}
+
+// TestCancelHold checks that cancelling a ready hold passes the game on, cancelling a waiting one does not, and closed holds stay closed.
+func TestCancelHold(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+	l.checkout(t, game, l.member(t, "geralt"))
+	holds := []*model.Hold{l.placeHold(t, game, l.member(t, "yennefer")), l.placeHold(t, game, l.member(t, "ciri")), l.placeHold(t, game, l.member(t, "dandelion"))}
+	l.giveBack(t, game)
+
+	if _, err := l.holds.CancelHold(ctx, holds[2].ID.Hex(), holds[2].Version); err != nil {
+		t.Fatalf("CancelHold of a waiting hold: %v", err)
+	}
+	l.wantStatuses(t, "waiting hold cancelled", holds, model.HoldReady, model.HoldWaiting, model.HoldCancelled)
+
+	if _, err := l.holds.CancelHold(ctx, holds[0].ID.Hex(), holds[0].Version); !errors.Is(err, apperr.ErrVersionMismatch) {
+		t.Errorf("CancelHold at a stale version error = %v, want a version mismatch", err)
+	}
+	if _, err := l.holds.CancelHold(ctx, holds[0].ID.Hex(), 0); err != nil {
+		t.Fatalf("CancelHold of the ready hold: %v", err)
+	}
+	l.wantStatuses(t, "ready hold cancelled", holds, model.HoldCancelled, model.HoldReady, model.HoldCancelled)
+
+	for _, hold := range []*model.Hold{holds[0], holds[2]} {
+		if _, err := l.holds.CancelHold(ctx, hold.ID.Hex(), 0); !errors.Is(err, apperr.ErrConflict) {
+			t.Errorf("CancelHold of a closed hold error = %v, want a conflict", err)
+		}
+	}
+	if _, err := l.holds.CancelHold(ctx, holds[1].ID.Hex(), 0); err != nil {
+		t.Fatalf("CancelHold of the last hold: %v", err)
+	}
+	stored, err := l.games.GetGameById(ctx, game.ID.Hex())
+	if err != nil {
+		t.Fatalf("GetGameById: %v", err)
+	}
+	if !stored.Available {
+		t.Error("game is not available after every hold was cancelled")
+	}
+}
+
+// TestDeleteGameCancelsHolds checks that deleting a game, alone or with its developer, cancels its open holds.
+func TestDeleteGameCancelsHolds(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	developer := l.developer(t, "CD Projekt")
+	game := l.game(t, developer, "The Witcher")
+	other := l.game(t, developer, "Cyberpunk 2077")
+	geralt, yennefer, ciri := l.member(t, "geralt"), l.member(t, "yennefer"), l.member(t, "ciri")
+
+	l.checkout(t, game, geralt)
+	ready := l.placeHold(t, game, yennefer)
+	waiting := l.placeHold(t, game, ciri)
+	l.giveBack(t, game)
+	l.checkout(t, other, geralt)
+	otherHold := l.placeHold(t, other, yennefer)
+	l.giveBack(t, other)
+
+	if err := l.games.DeleteGame(ctx, game.ID.Hex(), 0); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	if err := l.developers.DeleteDeveloper(ctx, developer.ID.Hex(), model.DeleteDeveloperOptions{OnGames: model.GamesCascade}); err != nil {
+		t.Fatalf("DeleteDeveloper: %v", err)
+	}
+	for _, hold := range []*model.Hold{ready, waiting, otherHold} {
+		if status := l.holdStatus(t, hold); status != model.HoldCancelled {
+			t.Errorf("hold %s is %s after deleting its game, want %s", hold.ID.Hex(), status, model.HoldCancelled)
+		}
+	}
+	if _, err := l.holds.ExpireHolds(ctx); err != nil {
+		t.Errorf("ExpireHolds after deleting the games: %v", err)
+	}
+}
+
+// TestExpireHoldsSkipsDeletedGame checks that a due hold of a game that no longer exists does not stop the others from expiring.
+func TestExpireHoldsSkipsDeletedGame(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	game := l.game(t, l.developer(t, "CD Projekt"), "The Witcher")
+	geralt, yennefer := l.member(t, "geralt"), l.member(t, "yennefer")
+
+	readyAt := time.Now().Add(-2 * pickupWindow).UTC().Truncate(time.Millisecond)
+	expiresAt := readyAt.Add(pickupWindow)
+	orphan, err := l.holdRepository.AddHold(ctx, model.Hold{GameID: primitive.NewObjectID(), MemberID: geralt.ID, Status: model.HoldReady, PlacedAt: readyAt, ReadyAt: &readyAt, ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatalf("AddHold: %v", err)
+	}
+	l.checkout(t, game, geralt)
+	due := l.placeHold(t, game, yennefer)
+	l.giveBack(t, game)
+	l.makeDue(t, due)
+
+	expired, err := l.holds.ExpireHolds(ctx)
+	if err != nil {
+		t.Fatalf("ExpireHolds: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != due.ID {
+		t.Errorf("ExpireHolds expired %v, want only %s", expired, due.ID.Hex())
+	}
+	if status := l.holdStatus(t, orphan); status != model.HoldReady {
+		t.Errorf("hold of the deleted game is %s, want it left %s", status, model.HoldReady)
+	}
+}
+
+// TestExpireHoldsStops checks that a failure expiring a hold stops the run and keeps the holds already expired.
+func TestExpireHoldsStops(t *testing.T) {
+	ctx := context.Background()
+	l := newLibrary(t)
+	developer := l.developer(t, "CD Projekt")
+	geralt, yennefer := l.member(t, "geralt"), l.member(t, "yennefer")
+	var holds []*model.Hold
+	for _, title := range []string{"The Witcher", "The Witcher 2", "The Witcher 3"} {
+		game := l.game(t, developer, title)
+		l.checkout(t, game, geralt)
+		hold := l.placeHold(t, game, yennefer)
+		l.giveBack(t, game)
+		l.makeDue(t, hold)
+		holds = append(holds, hold)
+	}
+
+	holdService, err := service.NewHoldService(l.holdRepository, l.gameRepository, l.memberRepository, l.copyRepository, &failingTransactor{Transactor: l.transactor, fail: 2}, pickupWindow, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewHoldService: %v", err)
+	}
+	expired, err := holdService.ExpireHolds(ctx)
+	if !errors.Is(err, errTransaction) || len(expired) != 1 {
+		t.Fatalf("ExpireHolds = %d holds, %v, want 1 and %v", len(expired), err, errTransaction)
+	}
+	due := 0
+	for _, hold := range holds {
+		if l.holdStatus(t, hold) == model.HoldReady {
+			due++
+		}
+	}
+	if due != 2 {
+		t.Errorf("%d holds left ready, want the 2 not expired before the failure", due)
+	}
+
+	if expired, err := l.holds.ExpireHolds(ctx); err != nil || len(expired) != 2 {
+		t.Errorf("ExpireHolds after the failure = %d holds, %v, want the 2 left", len(expired), err)
+	}
+}
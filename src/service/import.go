@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/tabular"
+	"game-library-management-system/src/validation"
+	"go.uber.org/zap"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultImportBatchSize is the number of rows written per transaction when the options do not say
+	defaultImportBatchSize = 100
+	// maxImportBatchSize keeps a single transaction of an import reasonably small
+	maxImportBatchSize = 1000
+)
+
+type ImportService struct {
+	developerRepository _interface.DeveloperRepositorer
+	gameRepository      _interface.GameRepositorer
+	transactor          _interface.Transactor
+	logger              *zap.Logger
+}
+
+// NewImportService creates a new ImportService
+// It returns a pointer to an ImportService and an error
+func NewImportService(developerRepository _interface.DeveloperRepositorer, gameRepository _interface.GameRepositorer, transactor _interface.Transactor, logger *zap.Logger) (_interface.ImportServicer, error) {
+	return &ImportService{
+		developerRepository: developerRepository,
+		gameRepository:      gameRepository,
+		transactor:          transactor,
+		logger:              logger,
+	}, nil
+}
+
+// Import adds the developers and games of a CSV or NDJSON file
+// A row is a developer (Name, MainHq) or a game (Title, Genre, PublicationYear, Available) whose Developer column
+// names its developer. Developers are looked up by name, case-insensitively, and created from the game row's
+// DeveloperMainHq when there is none. Rows with errors are skipped and reported, the others are written in
+// transactions of opts.BatchSize rows. Batches written before a read or storage error stay written, and the error
+// comes with a report of them
+func (s *ImportService) Import(ctx context.Context, file model.ImportFile, opts model.ImportOptions) (*model.ImportReport, error) {
+	batchSize := opts.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultImportBatchSize
+	}
+	if batchSize < 0 || batchSize > maxImportBatchSize {
+		return nil, apperr.Validation("batch size must be between 1 and %d", maxImportBatchSize)
+	}
+
+	rows, err := tabular.NewReader(file.Body, file.Format)
+	if err != nil {
+		return nil, apperr.Validation("%v", err)
+	}
+	developers, err := s.developerRepository.GetAllDevelopers(ctx)
+	if err != nil {
+		s.logger.Error("Error getting developers for import", zap.Error(err))
+		return nil, err
+	}
+
+	plan := newImportPlan(developers)
+	plan.report.DryRun = opts.DryRun
+	batch := make([]importItem, 0, batchSize)
+	for {
+		row, err := rows.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr *tabular.RowError
+		if errors.As(err, &rowErr) {
+			plan.report.Rows++
+			plan.fail(rowErr.Line, "", rowErr.Err.Error())
+			continue
+		}
+		if err != nil {
+			s.logger.Error("Error reading import file", zap.Error(err))
+			plan.stop(batch)
+			return plan.report, err
+		}
+
+		plan.report.Rows++
+		item, ok := plan.add(row)
+		if !ok || opts.DryRun {
+			continue
+		}
+		if batch = append(batch, item); len(batch) == batchSize {
+			if err := s.write(ctx, batch, plan.report); err != nil {
+				plan.stop(batch)
+				return plan.report, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := s.write(ctx, batch, plan.report); err != nil {
+			plan.stop(batch)
+			return plan.report, err
+		}
+	}
+
+	s.logger.Info("Imported catalogue", zap.Bool("dryRun", opts.DryRun), zap.Int("rows", plan.report.Rows),
+		zap.Int("developers", plan.report.DevelopersCreated), zap.Int("games", plan.report.GamesCreated),
+		zap.Int("errors", len(plan.report.Errors)))
+	return plan.report, nil
+}
+
+// write adds the developers and games of a batch in one transaction
+func (s *ImportService) write(ctx context.Context, batch []importItem, report *model.ImportReport) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range batch {
+			if item.newDeveloper != nil {
+				created, err := s.developerRepository.AddDeveloper(ctx, *item.newDeveloper)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", item.line, err)
+				}
+				// Later rows linking to the developer see its ID through the plan.
+				*item.newDeveloper = *created
+			}
+			if item.game != nil {
+				game := *item.game
+				game.Developer = *item.developer
+				if _, err := s.gameRepository.AddGame(ctx, game); err != nil {
+					return fmt.Errorf("line %d: %w", item.line, err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Error writing import batch", zap.Int("batch", report.Batches+1), zap.Error(err))
+		return err
+	}
+	report.Batches++
+	return nil
+}
+
+// importItem is what one row of an import file writes: a new developer, a game, or both
+type importItem struct {
+	line int
+	// newDeveloper is the developer to create, shared with the plan so that it gets its ID once written
+	newDeveloper *model.Developer
+	game         *model.Game
+	// developer is the developer of the game
+	developer *model.Developer
+}
+
+// importPlan turns rows into items and keeps track of the developers known by name, including those still to be written
+type importPlan struct {
+	developers map[string][]*model.Developer
+	report     *model.ImportReport
+}
+
+// newImportPlan creates an importPlan that links to the stored developers
+func newImportPlan(developers []model.Developer) *importPlan {
+	plan := &importPlan{
+		developers: make(map[string][]*model.Developer),
+		report:     &model.ImportReport{Errors: make([]model.ImportRowError, 0)},
+	}
+	for _, d := range developers {
+		plan.remember(&d)
+	}
+	return plan
+}
+
+// remember makes a developer known by its name
+func (p *importPlan) remember(developer *model.Developer) {
+	key := strings.ToLower(strings.TrimSpace(developer.Name))
+	p.developers[key] = append(p.developers[key], developer)
+}
+
+// lookup returns the developer with the given name, or nil if there is none
+func (p *importPlan) lookup(name string) (*model.Developer, error) {
+	found := p.developers[strings.ToLower(strings.TrimSpace(name))]
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%d developers are named %q", len(found), name)
+	}
+}
+
+// stop marks the report as stopped early and takes the items of the unwritten batch out of its counts
+func (p *importPlan) stop(unwritten []importItem) {
+	p.report.Stopped = true
+	for _, item := range unwritten {
+		if item.newDeveloper != nil {
+			p.report.DevelopersCreated--
+		}
+		if item.game != nil {
+			p.report.GamesCreated--
+		}
+	}
+}
+
+// fail reports an error with a row
+func (p *importPlan) fail(line int, field string, message string) {
+	p.report.Errors = append(p.report.Errors, model.ImportRowError{Line: line, Field: field, Message: message})
+}
+
+// failValidation reports the rule violations of a row, renaming fields with rename if it has them
+func (p *importPlan) failValidation(line int, err error, rename map[string]string) {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		p.fail(line, "", err.Error())
+		return
+	}
+	for _, fe := range errs {
+		field := fe.Field
+		if renamed, ok := rename[field]; ok {
+			field = renamed
+		}
+		p.fail(line, field, fe.Message)
+	}
+}
+
+// add plans a row and counts what it adds. It returns false if the row has errors or there is nothing to write
+func (p *importPlan) add(row tabular.Row) (importItem, bool) {
+	kind := strings.ToLower(row.Get("Type"))
+	if kind == "" {
+		kind = "developer"
+		if row.Get("Title") != "" {
+			kind = "game"
+		}
+	}
+	switch kind {
+	case "developer":
+		return p.addDeveloper(row)
+	case "game":
+		return p.addGame(row)
+	default:
+		p.fail(row.Line, "Type", "must be developer or game")
+		return importItem{}, false
+	}
+}
+
+// addDeveloper plans a developer row. A developer that already exists is linked to, not changed
+func (p *importPlan) addDeveloper(row tabular.Row) (importItem, bool) {
+	developer := model.Developer{Name: row.Get("Name"), MainHq: row.Get("MainHq")}
+	if err := developerRules.Validate(developer); err != nil {
+		p.failValidation(row.Line, err, nil)
+		return importItem{}, false
+	}
+	existing, err := p.lookup(developer.Name)
+	if err != nil {
+		p.fail(row.Line, "Name", err.Error())
+		return importItem{}, false
+	}
+	if existing != nil {
+		p.report.DevelopersExisting++
+		return importItem{}, false
+	}
+
+	p.remember(&developer)
+	p.report.DevelopersCreated++
+	return importItem{line: row.Line, newDeveloper: &developer}, true
+}
+
+// developerColumns maps the developer fields to the columns of a game row that set them
+var developerColumns = map[string]string{"Name": "Developer", "MainHq": "DeveloperMainHq"}
+
+// addGame plans a game row, and its developer if it does not exist yet. An empty Available column means available
+func (p *importPlan) addGame(row tabular.Row) (importItem, bool) {
+	game := model.Game{Title: row.Get("Title"), Genre: row.Get("Genre"), Available: true}
+	// The developer is resolved by name below, so its ID is not known yet.
+	unchecked := map[string]bool{"Developer.ID": true}
+	ok := true
+	if year := row.Get("PublicationYear"); year != "" {
+		n, err := strconv.Atoi(year)
+		if err != nil {
+			p.fail(row.Line, "PublicationYear", "must be a whole number")
+			unchecked["PublicationYear"], ok = true, false
+		}
+		game.PublicationYear = n
+	}
+	if available := row.Get("Available"); available != "" {
+		b, err := strconv.ParseBool(available)
+		if err != nil {
+			p.fail(row.Line, "Available", "must be true or false")
+			ok = false
+		}
+		game.Available = b
+	}
+	if err := gameRules.Validate(game); err != nil {
+		var errs validation.Errors
+		errors.As(err, &errs)
+		for _, fe := range errs {
+			if !unchecked[fe.Field] {
+				p.fail(row.Line, fe.Field, fe.Message)
+				ok = false
+			}
+		}
+	}
+
+	name := row.Get("Developer")
+	if name == "" {
+		p.fail(row.Line, "Developer", "is required")
+		return importItem{}, false
+	}
+	developer, err := p.lookup(name)
+	if err != nil {
+		p.fail(row.Line, "Developer", err.Error())
+		return importItem{}, false
+	}
+	var newDeveloper *model.Developer
+	if developer == nil {
+		newDeveloper = &model.Developer{Name: name, MainHq: row.Get("DeveloperMainHq")}
+		if newDeveloper.MainHq == "" {
+			p.fail(row.Line, "DeveloperMainHq", fmt.Sprintf("is required to create developer %q", name))
+			return importItem{}, false
+		}
+		if err := developerRules.Validate(*newDeveloper); err != nil {
+			p.failValidation(row.Line, err, developerColumns)
+			return importItem{}, false
+		}
+		developer = newDeveloper
+	}
+	if !ok {
+		return importItem{}, false
+	}
+
+	if newDeveloper != nil {
+		p.remember(newDeveloper)
+		p.report.DevelopersCreated++
+	}
+	p.report.GamesCreated++
+	return importItem{line: row.Line, newDeveloper: newDeveloper, game: &game, developer: developer}, true
+}
@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/memory"
+	"game-library-management-system/src/service"
+	"go.uber.org/zap"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestImportStoppedByReadError checks that an import cut short by the file reports the batches it wrote.
+func TestImportStoppedByReadError(t *testing.T) {
+	store := memory.NewStore()
+	developers := memory.NewDeveloperRepository(store)
+	imports, err := service.NewImportService(developers, memory.NewGameRepository(store), memory.NewTransactor(store), zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewImportService: %v", err)
+	}
+
+	errBroken := errors.New("connection reset")
+	body := io.MultiReader(strings.NewReader("Name,MainHq\nCD Projekt,Warsaw\nValve,Bellevue\nNintendo,Kyoto\n"), iotest.ErrReader(errBroken))
+	report, err := imports.Import(context.Background(), model.ImportFile{Format: model.FormatCSV, Body: body}, model.ImportOptions{BatchSize: 2})
+	if !errors.Is(err, errBroken) {
+		t.Fatalf("Import error = %v, want %v", err, errBroken)
+	}
+	if report == nil {
+		t.Fatal("Import returned no report with the error")
+	}
+	if !report.Stopped || report.Rows != 3 || report.Batches != 1 || report.DevelopersCreated != 2 {
+		t.Errorf("report = %+v, want stopped after 3 rows with 1 batch of 2 developers", *report)
+	}
+
+	stored, err := developers.GetAllDevelopers(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllDevelopers: %v", err)
+	}
+	if len(stored) != report.DevelopersCreated {
+		t.Errorf("%d developers stored, report says %d", len(stored), report.DevelopersCreated)
+	}
+}
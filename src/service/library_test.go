@@ -0,0 +1,128 @@
+package service_test
+
+import (
+	"context"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/repository/memory"
+	"game-library-management-system/src/service"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+const (
+	loanPeriod   = 14 * 24 * time.Hour
+	pickupWindow = 48 * time.Hour
+)
+
+var finePolicy = service.FinePolicy{DailyRate: 25, Cap: 1000, Threshold: 500}
+
+// library is an in-memory library: the services and the repositories under them
+type library struct {
+	developerRepository _interface.DeveloperRepositorer
+	gameRepository      _interface.GameRepositorer
+	memberRepository    _interface.MemberRepositorer
+	loanRepository      _interface.LoanRepositorer
+	copyRepository      _interface.CopyRepositorer
+	holdRepository      _interface.HoldRepositorer
+	fineRepository      _interface.FineRepositorer
+	transactor          _interface.Transactor
+
+	developers _interface.DeveloperServicer
+	games      _interface.GameServicer
+	members    _interface.MemberServicer
+	copies     _interface.CopyServicer
+	loans      _interface.LoanServicer
+	holds      _interface.HoldServicer
+	fines      _interface.FineServicer
+	backup     _interface.BackupServicer
+}
+
+// newLibrary wires every service to a new memory store
+func newLibrary(t *testing.T) *library {
+	t.Helper()
+	store := memory.NewStore()
+	l := &library{
+		developerRepository: memory.NewDeveloperRepository(store),
+		gameRepository:      memory.NewGameRepository(store),
+		memberRepository:    memory.NewMemberRepository(store),
+		loanRepository:      memory.NewLoanRepository(store),
+		copyRepository:      memory.NewCopyRepository(store),
+		holdRepository:      memory.NewHoldRepository(store),
+		fineRepository:      memory.NewFineRepository(store),
+		transactor:          memory.NewTransactor(store),
+	}
+	logger := zap.NewNop()
+
+	var err error
+	if l.developers, err = service.NewDeveloperService(l.developerRepository, l.gameRepository, l.loanRepository, l.holdRepository, l.transactor, logger); err != nil {
+		t.Fatalf("NewDeveloperService: %v", err)
+	}
+	if l.games, err = service.NewGameService(l.gameRepository, l.holdRepository, l.loanRepository, l.memberRepository, l.transactor, pickupWindow, logger); err != nil {
+		t.Fatalf("NewGameService: %v", err)
+	}
+	if l.members, err = service.NewMemberService(l.memberRepository, l.loanRepository, l.holdRepository, l.gameRepository, l.copyRepository, l.fineRepository, l.transactor, pickupWindow, logger); err != nil {
+		t.Fatalf("NewMemberService: %v", err)
+	}
+	if l.copies, err = service.NewCopyService(l.copyRepository, l.gameRepository, l.holdRepository, l.memberRepository, l.transactor, pickupWindow, logger); err != nil {
+		t.Fatalf("NewCopyService: %v", err)
+	}
+	if l.loans, err = service.NewLoanService(l.loanRepository, l.gameRepository, l.memberRepository, l.copyRepository, l.holdRepository, l.fineRepository, l.transactor, loanPeriod, pickupWindow, finePolicy, logger); err != nil {
+		t.Fatalf("NewLoanService: %v", err)
+	}
+	if l.holds, err = service.NewHoldService(l.holdRepository, l.gameRepository, l.memberRepository, l.copyRepository, l.transactor, pickupWindow, logger); err != nil {
+		t.Fatalf("NewHoldService: %v", err)
+	}
+	if l.fines, err = service.NewFineService(l.fineRepository, l.memberRepository, l.loanRepository, l.transactor, finePolicy, logger); err != nil {
+		t.Fatalf("NewFineService: %v", err)
+	}
+	if l.backup, err = service.NewBackupService(l.developerRepository, l.gameRepository, l.copyRepository, l.transactor, logger); err != nil {
+		t.Fatalf("NewBackupService: %v", err)
+	}
+	return l
+}
+
+// developer adds a developer
+func (l *library) developer(t *testing.T, name string) *model.Developer {
+	t.Helper()
+	developer, err := l.developers.AddDeveloper(context.Background(), model.Developer{Name: name, MainHq: "Warsaw"})
+	if err != nil {
+		t.Fatalf("AddDeveloper: %v", err)
+	}
+	return developer
+}
+
+// game adds an available game of a developer
+func (l *library) game(t *testing.T, developer *model.Developer, title string) *model.Game {
+	t.Helper()
+	ctx := context.Background()
+	game, err := l.games.AddGame(ctx, model.Game{Title: title, Developer: *developer, Genre: "RPG", PublicationYear: 2015})
+	if err != nil {
+		t.Fatalf("AddGame: %v", err)
+	}
+	if game, err = l.games.SetAvailability(ctx, game.ID.Hex(), true, game.Version); err != nil {
+		t.Fatalf("SetAvailability: %v", err)
+	}
+	return game
+}
+
+// member adds an active member
+func (l *library) member(t *testing.T, name string) *model.Member {
+	t.Helper()
+	member, err := l.members.AddMember(context.Background(), model.Member{Name: name, Email: name + "@example.com", CardNumber: "card-" + name, Status: model.MemberActive})
+	if err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	return member
+}
+
+// checkout lends a game to a member
+func (l *library) checkout(t *testing.T, game *model.Game, member *model.Member) *model.Loan {
+	t.Helper()
+	loan, err := l.loans.Checkout(context.Background(), game.ID.Hex(), member.ID.Hex(), "")
+	if err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	return loan
+}
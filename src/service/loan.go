@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrGameUnavailable is returned when checking out a game that is not available.
+	ErrGameUnavailable = apperr.Conflict("game is not available")
+	// ErrGameNotCheckedOut is returned when returning a game that has no active loan.
+	ErrGameNotCheckedOut = apperr.Conflict("game is not checked out")
+)
+
+type LoanService struct {
+	loanRepository   _interface.LoanRepositorer
+	gameRepository   _interface.GameRepositorer
+	memberRepository _interface.MemberRepositorer
+	copyRepository   _interface.CopyRepositorer
+	transactor       _interface.Transactor
+	holds            holdQueue
+	fines            fineLedger
+	loanPeriod       time.Duration
+	now              func() time.Time
+	logger           *zap.Logger
+}
+
+// NewLoanService creates a new LoanService
+// Games checked out through it are due back after loanPeriod. Returned games are reserved for
+// the next hold in their queue for pickupWindow. Late returns are fined and members owing more than
+// the policy threshold may not borrow
+// It returns a pointer to a LoanService and an error
+func NewLoanService(loanRepository _interface.LoanRepositorer, gameRepository _interface.GameRepositorer, memberRepository _interface.MemberRepositorer, copyRepository _interface.CopyRepositorer, holdRepository _interface.HoldRepositorer, fineRepository _interface.FineRepositorer, transactor _interface.Transactor, loanPeriod time.Duration, pickupWindow time.Duration, policy FinePolicy, logger *zap.Logger) (_interface.LoanServicer, error) {
+	return &LoanService{
+		loanRepository:   loanRepository,
+		gameRepository:   gameRepository,
+		memberRepository: memberRepository,
+		copyRepository:   copyRepository,
+		transactor:       transactor,
+		holds:            newHoldQueue(holdRepository, gameRepository, copyRepository, memberRepository, pickupWindow),
+		fines:            newFineLedger(fineRepository, loanRepository, policy),
+		loanPeriod:       loanPeriod,
+		now:              now,
+		logger:           logger,
+	}, nil
+}
+
+// now returns the current time as stored by every backend: UTC with millisecond precision
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+// ListLoans gets one page of loans matching the query
+func (s *LoanService) ListLoans(ctx context.Context, query model.LoanQuery) (*model.Page[model.Loan], error) {
+	page, err := s.loanRepository.PageLoans(ctx, query)
+	if err != nil {
+		s.logger.Error("Error listing loans", zap.Any("query", query), zap.Error(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetLoanById gets a loan by ID
+func (s *LoanService) GetLoanById(ctx context.Context, id string) (*model.Loan, error) {
+	loan, err := s.loanRepository.GetLoanById(ctx, id)
+	if err != nil {
+		s.logger.Error("Error getting loan by ID", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return loan, nil
+}
+
+// Checkout lends an available game to an active member whose open fines are not above the threshold
+// For a game with copies the copy with the given barcode is lent, or the first available copy if the
+// barcode is empty, and the game's copy counts are updated. A game without copies is lent as a whole.
+// A game or copy reserved for a ready hold of the member is lent to them and fulfills the hold.
+// The availability is only changed if the game or copy is still at the version that was read as
+// available, so two members checking out the same one at once cannot both succeed. Everything
+// happens in the same transaction as recording the loan
+func (s *LoanService) Checkout(ctx context.Context, gameID string, memberID string, barcode string) (*model.Loan, error) {
+	var loan *model.Loan
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		member, err := s.memberRepository.GetMemberById(ctx, memberID)
+		if errors.Is(err, apperr.ErrNotFound) {
+			return apperr.Validation("member does not exist")
+		}
+		if err != nil {
+			return err
+		}
+		if !member.IsActive() {
+			return apperr.Conflict("member is %s and cannot borrow games", member.Status)
+		}
+		balance, err := s.fines.balance(ctx, memberID)
+		if err != nil {
+			return err
+		}
+		if balance > s.fines.policy.Threshold {
+			return apperr.Conflict("member owes %d in fines, more than the %d allowed to borrow games", balance, s.fines.policy.Threshold)
+		}
+
+		game, err := s.gameRepository.GetGameById(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		if game.Copies == 0 && barcode != "" {
+			return apperr.Validation("game has no copies to pick by barcode")
+		}
+
+		hold, err := s.reservedHold(ctx, gameID, memberID, barcode)
+		if err != nil {
+			return err
+		}
+		var copyID *primitive.ObjectID
+		switch {
+		case hold != nil:
+			// The game or copy was kept unavailable for this member.
+			if _, err := s.holds.close(ctx, *hold, model.HoldFulfilled); err != nil {
+				return err
+			}
+			copyID = hold.CopyID
+		case game.Copies == 0:
+			if !game.Available {
+				return ErrGameUnavailable
+			}
+			if _, err := s.gameRepository.SetAvailability(ctx, gameID, false, game.Version); err != nil {
+				return err
+			}
+		default:
+			gameCopy, err := s.pickCopy(ctx, game, barcode)
+			if err != nil {
+				return err
+			}
+			if _, err := s.copyRepository.SetCopyAvailability(ctx, gameCopy.ID.Hex(), false, gameCopy.Version); err != nil {
+				return err
+			}
+			if err := syncCopyCounts(ctx, s.copyRepository, s.gameRepository, gameID); err != nil {
+				return err
+			}
+			copyID = &gameCopy.ID
+		}
+
+		checkedOutAt := s.now()
+		loan, err = s.loanRepository.AddLoan(ctx, model.Loan{
+			GameID:       game.ID,
+			CopyID:       copyID,
+			MemberID:     member.ID,
+			CheckedOutAt: checkedOutAt,
+			DueAt:        checkedOutAt.Add(s.loanPeriod),
+		})
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error checking out game", zap.String("game", gameID), zap.String("member", memberID), zap.String("barcode", barcode), zap.Error(err))
+		return nil, err
+	}
+	return loan, nil
+}
+
+// reservedHold returns the ready hold of a member for a game, or nil if there is none
+// or the barcode asks for another copy than the reserved one
+func (s *LoanService) reservedHold(ctx context.Context, gameID string, memberID string, barcode string) (*model.Hold, error) {
+	hold, err := s.holds.readyHold(ctx, gameID, memberID)
+	if err != nil || hold == nil || barcode == "" || hold.CopyID == nil {
+		return hold, err
+	}
+	gameCopy, err := s.copyRepository.GetCopyByBarcode(ctx, strings.TrimSpace(barcode))
+	if errors.Is(err, apperr.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if gameCopy.ID != *hold.CopyID {
+		return nil, nil
+	}
+	return hold, nil
+}
+
+// pickCopy returns the available copy of a game to lend: the one with the barcode, or the first one if it is empty
+func (s *LoanService) pickCopy(ctx context.Context, game *model.Game, barcode string) (*model.Copy, error) {
+	if barcode == "" {
+		copies, err := s.copyRepository.ListCopies(ctx, game.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range copies {
+			if c.Available {
+				return &c, nil
+			}
+		}
+		return nil, ErrGameUnavailable
+	}
+
+	gameCopy, err := s.copyRepository.GetCopyByBarcode(ctx, strings.TrimSpace(barcode))
+	if errors.Is(err, apperr.ErrNotFound) || err == nil && gameCopy.GameID != game.ID {
+		return nil, apperr.Validation("game has no copy with barcode %q", barcode)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !gameCopy.Available {
+		return nil, ErrCopyOnLoan
+	}
+	return gameCopy, nil
+}
+
+// Return closes an active loan of a game and, in the same transaction, reserves the game or the lent copy
+// for the next hold in the game's queue, or makes it available again if nobody is waiting
+// The barcode picks the copy whose loan is closed. It may be empty if only one copy of the game is out
+// A loan returned after its due date is marked overdue and fined for the days it was late
+func (s *LoanService) Return(ctx context.Context, gameID string, barcode string) (*model.Loan, error) {
+	var loan *model.Loan
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		game, err := s.gameRepository.GetGameById(ctx, gameID)
+		if err != nil {
+			return err
+		}
+		active := true
+		loans, err := s.loanRepository.ListLoans(ctx, model.LoanFilter{GameID: gameID, Active: &active})
+		if err != nil {
+			return err
+		}
+		if barcode != "" {
+			gameCopy, err := s.copyRepository.GetCopyByBarcode(ctx, strings.TrimSpace(barcode))
+			if errors.Is(err, apperr.ErrNotFound) || err == nil && gameCopy.GameID != game.ID {
+				return apperr.Validation("game has no copy with barcode %q", barcode)
+			}
+			if err != nil {
+				return err
+			}
+			loans = slices.DeleteFunc(loans, func(l model.Loan) bool { return l.CopyID == nil || *l.CopyID != gameCopy.ID })
+		}
+		switch {
+		case len(loans) == 0:
+			return ErrGameNotCheckedOut
+		case len(loans) > 1:
+			return apperr.Validation("several copies of the game are checked out, give the barcode of the returned one")
+		}
+
+		if loan, err = s.loanRepository.CloseLoan(ctx, loans[0].ID.Hex(), s.now()); err != nil {
+			return err
+		}
+		if daysLate(loan.DueAt, *loan.ReturnedAt) > 0 {
+			if err := s.fines.assess(ctx, *loan, *loan.ReturnedAt); err != nil {
+				return err
+			}
+			loan.Overdue = true
+		}
+		if loan.CopyID == nil && game.Copies > 0 {
+			// A loan from before the game had copies leaves the availability to the copies.
+			return nil
+		}
+		return s.holds.release(ctx, gameID, loan.CopyID)
+	})
+	if err != nil {
+		s.logger.Error("Error returning game", zap.String("game", gameID), zap.String("barcode", barcode), zap.Error(err))
+		return nil, err
+	}
+	return loan, nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/interface"
+	"game-library-management-system/src/model"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+// ErrMemberHasLoans is returned when deleting a member who still has games checked out.
+var ErrMemberHasLoans = apperr.Conflict("member still has games checked out")
+
+// ErrMemberHasFines is returned when deleting a member who still owes fines.
+var ErrMemberHasFines = apperr.Conflict("member still owes fines")
+
+type MemberService struct {
+	memberRepository _interface.MemberRepositorer
+	loanRepository   _interface.LoanRepositorer
+	holdRepository   _interface.HoldRepositorer
+	transactor       _interface.Transactor
+	holds            holdQueue
+	fines            fineLedger
+	logger           *zap.Logger
+}
+
+// NewMemberService creates a new MemberService
+// Games and copies reserved for a deleted member go to the next hold in their queue for pickupWindow
+// It returns a pointer to a MemberService and an error
+func NewMemberService(memberRepository _interface.MemberRepositorer, loanRepository _interface.LoanRepositorer, holdRepository _interface.HoldRepositorer, gameRepository _interface.GameRepositorer, copyRepository _interface.CopyRepositorer, fineRepository _interface.FineRepositorer, transactor _interface.Transactor, pickupWindow time.Duration, logger *zap.Logger) (_interface.MemberServicer, error) {
+	return &MemberService{
+		memberRepository: memberRepository,
+		loanRepository:   loanRepository,
+		holdRepository:   holdRepository,
+		transactor:       transactor,
+		holds:            newHoldQueue(holdRepository, gameRepository, copyRepository, memberRepository, pickupWindow),
+		// Only the balance is read, which does not depend on the policy.
+		fines:  newFineLedger(fineRepository, loanRepository, FinePolicy{}),
+		logger: logger,
+	}, nil
+}
+
+// ListMembers gets one page of members matching the query
+func (s *MemberService) ListMembers(ctx context.Context, query model.MemberQuery) (*model.Page[model.Member], error) {
+	page, err := s.memberRepository.ListMembers(ctx, query)
+	if err != nil {
+		s.logger.Error("Error listing members", zap.Any("query", query), zap.Error(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetMemberById gets a member by ID
+func (s *MemberService) GetMemberById(ctx context.Context, id string) (*model.Member, error) {
+	member, err := s.memberRepository.GetMemberById(ctx, id)
+	if err != nil {
+		s.logger.Error("Error getting member by ID", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return member, nil
+}
+
+// GetMemberByEmail gets a member by email address, ignoring case
+func (s *MemberService) GetMemberByEmail(ctx context.Context, email string) (*model.Member, error) {
+	member, err := s.memberRepository.GetMemberByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		s.logger.Error("Error getting member by email", zap.String("email", email), zap.Error(err))
+		return nil, err
+	}
+	return member, nil
+}
+
+// GetMemberByCardNumber gets a member by library card number
+func (s *MemberService) GetMemberByCardNumber(ctx context.Context, cardNumber string) (*model.Member, error) {
+	member, err := s.memberRepository.GetMemberByCardNumber(ctx, strings.TrimSpace(cardNumber))
+	if err != nil {
+		s.logger.Error("Error getting member by card number", zap.String("cardNumber", cardNumber), zap.Error(err))
+		return nil, err
+	}
+	return member, nil
+}
+
+// AddMember adds a member
+// A member without a status is active. The email and card number must not belong to another member
+func (s *MemberService) AddMember(ctx context.Context, member model.Member) (*model.Member, error) {
+	member = normalizeMember(member)
+	if member.Status == "" {
+		member.Status = model.MemberActive
+	}
+	if err := memberRules.Validate(member); err != nil {
+		return nil, err
+	}
+	var newMember *model.Member
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkUnique(ctx, member); err != nil {
+			return err
+		}
+		var err error
+		newMember, err = s.memberRepository.AddMember(ctx, member)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error adding member", zap.Error(err))
+		return nil, err
+	}
+	return newMember, nil
+}
+
+// UpdateMember replaces a member's name, email, card number and status
+// A non-zero member.Version makes the update conditional on the member still being at that version
+func (s *MemberService) UpdateMember(ctx context.Context, id string, member model.Member) (*model.Member, error) {
+	member = normalizeMember(member)
+	if err := memberRules.Validate(member); err != nil {
+		return nil, err
+	}
+	var updatedMember *model.Member
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.memberRepository.GetMemberById(ctx, id)
+		if err != nil {
+			return err
+		}
+		member.ID = current.ID
+		if err := s.checkUnique(ctx, member); err != nil {
+			return err
+		}
+		updatedMember, err = s.memberRepository.UpdateMember(ctx, id, member)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Error updating member", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+	return updatedMember, nil
+}
+
+// DeleteMember deletes a member who has no games checked out and owes no fines
+// The member's open holds are cancelled with it, passing the games and copies reserved for them on.
+// A non-zero version makes the deletion conditional on the member still being at that version
+func (s *MemberService) DeleteMember(ctx context.Context, id string, version int64) error {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		active := true
+		loans, err := s.loanRepository.ListLoans(ctx, model.LoanFilter{MemberID: id, Active: &active})
+		if err != nil {
+			return err
+		}
+		if len(loans) > 0 {
+			return ErrMemberHasLoans
+		}
+		balance, err := s.fines.balance(ctx, id)
+		if err != nil {
+			return err
+		}
+		if balance > 0 {
+			return ErrMemberHasFines
+		}
+		holds, err := s.holdRepository.ListHolds(ctx, model.HoldFilter{MemberID: id})
+		if err != nil {
+			return err
+		}
+		for _, hold := range holds {
+			if !hold.Status.IsOpen() {
+				continue
+			}
+			if _, err := s.holds.close(ctx, hold, model.HoldCancelled); err != nil {
+				return err
+			}
+		}
+		return s.memberRepository.DeleteMember(ctx, id, version)
+	})
+	if err != nil {
+		s.logger.Error("Error deleting member", zap.String("id", id), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// checkUnique returns a Conflict error if another member has the email or card number of member
+func (s *MemberService) checkUnique(ctx context.Context, member model.Member) error {
+	other, err := s.memberRepository.GetMemberByEmail(ctx, member.Email)
+	if err == nil && other.ID != member.ID {
+		return apperr.Conflict("email %q belongs to another member", member.Email)
+	}
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+
+	other, err = s.memberRepository.GetMemberByCardNumber(ctx, member.CardNumber)
+	if err == nil && other.ID != member.ID {
+		return apperr.Conflict("card number %q belongs to another member", member.CardNumber)
+	}
+	if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// normalizeMember trims the identifying fields of a member and lower-cases the email,
+// so lookups match however the client spelled them
+func normalizeMember(member model.Member) model.Member {
+	member.Email = normalizeEmail(member.Email)
+	member.CardNumber = strings.TrimSpace(member.CardNumber)
+	return member
+}
+
+// normalizeEmail returns the form in which email addresses are stored
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/validation"
+)
+
+// earliestPublicationYear predates the first video games.
+const earliestPublicationYear = 1950
+
+var gameRules = validation.Rules[model.Game]{
+	{Name: "Title", Value: func(g model.Game) any { return g.Title }, Checks: []validation.Check{validation.Required(), validation.MaxLength(200)}},
+	{Name: "Developer.ID", Value: func(g model.Game) any { return g.Developer.ID }, Checks: []validation.Check{validation.Required()}},
+	{Name: "Genre", Value: func(g model.Game) any { return g.Genre }, Checks: []validation.Check{validation.Required(), validation.MaxLength(100)}},
+	{Name: "PublicationYear", Value: func(g model.Game) any { return g.PublicationYear }, Checks: []validation.Check{validation.Min(earliestPublicationYear), validation.NotInFuture()}},
+}
+
+var developerRules = validation.Rules[model.Developer]{
+	{Name: "Name", Value: func(d model.Developer) any { return d.Name }, Checks: []validation.Check{validation.Required(), validation.MaxLength(200)}},
+	{Name: "MainHq", Value: func(d model.Developer) any { return d.MainHq }, Checks: []validation.Check{validation.Required(), validation.MaxLength(200)}},
+}
+
+var memberStatuses = []string{string(model.MemberActive), string(model.MemberSuspended), string(model.MemberExpired)}
+
+var memberRules = validation.Rules[model.Member]{
+	{Name: "Name", Value: func(m model.Member) any { return m.Name }, Checks: []validation.Check{validation.Required(), validation.MaxLength(200)}},
+	{Name: "Email", Value: func(m model.Member) any { return m.Email }, Checks: []validation.Check{validation.Required(), validation.MaxLength(254), validation.Email()}},
+	{Name: "CardNumber", Value: func(m model.Member) any { return m.CardNumber }, Checks: []validation.Check{validation.Required(), validation.MaxLength(32)}},
+	{Name: "Status", Value: func(m model.Member) any { return string(m.Status) }, Checks: []validation.Check{validation.Required(), validation.OneOf(memberStatuses...)}},
+}
+
+var copyConditions = []string{string(model.CopyNew), string(model.CopyGood), string(model.CopyFair), string(model.CopyPoor)}
+
+var copyRules = validation.Rules[model.Copy]{
+	{Name: "Barcode", Value: func(c model.Copy) any { return c.Barcode }, Checks: []validation.Check{validation.Required(), validation.MaxLength(64)}},
+	{Name: "Condition", Value: func(c model.Copy) any { return string(c.Condition) }, Checks: []validation.Check{validation.Required(), validation.OneOf(copyConditions...)}},
+	{Name: "Platform", Value: func(c model.Copy) any { return c.Platform }, Checks: []validation.Check{validation.Required(), validation.MaxLength(100)}},
+	{Name: "ShelfLocation", Value: func(c model.Copy) any { return c.ShelfLocation }, Checks: []validation.Check{validation.MaxLength(100)}},
+}
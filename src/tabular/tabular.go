@@ -0,0 +1,179 @@
+// Package tabular reads rows of named values from CSV and NDJSON files, and writes them as CSV, NDJSON or XLSX.
+// Column names are matched case-insensitively when reading, so a CSV header and JSON keys spelled like
+// Go field names, as the API bodies are, both work.
+package tabular
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"game-library-management-system/src/model"
+	"io"
+	"strings"
+)
+
+// maxLineSize caps the length of an NDJSON line.
+const maxLineSize = 1 << 20
+
+// Row is one record of a file, keyed by lower-cased column name. Line is where it starts in the file.
+type Row struct {
+	Line   int
+	Values map[string]string
+}
+
+// Get returns the trimmed value of a column, or "" if the row does not have it.
+func (r Row) Get(column string) string {
+	return strings.TrimSpace(r.Values[strings.ToLower(column)])
+}
+
+// RowError is a malformed row. Reading can go on with the next row after it.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads the rows of a file one at a time.
+// Read returns io.EOF after the last row, a *RowError for a malformed row, or another error
+// if the file cannot be read any further.
+type Reader interface {
+	Read() (Row, error)
+}
+
+// NewReader returns a Reader for a file in the given format.
+func NewReader(r io.Reader, format model.FileFormat) (Reader, error) {
+	switch format {
+	case model.FormatCSV:
+		return newCSVReader(r)
+	case model.FormatNDJSON:
+		return &ndjsonReader{scanner: newScanner(r)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, use csv or ndjson", format)
+	}
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+// newCSVReader reads the header row of a CSV file.
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the CSV file has no header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range header {
+		// Spreadsheet programs may start the file with a byte order mark.
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+	// Every row must have as many fields as the header.
+	reader.FieldsPerRecord = len(header)
+	return &csvReader{reader: reader, header: header}, nil
+}
+
+// Read skips rows whose fields are all empty, as spreadsheet programs may write them after the data.
+func (c *csvReader) Read() (Row, error) {
+	record, err := c.reader.Read()
+	for err == nil && blank(record) {
+		record, err = c.reader.Read()
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return Row{}, &RowError{Line: parseErr.StartLine, Err: parseErr.Err}
+	}
+	if err != nil {
+		return Row{}, err
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	row := Row{Line: line, Values: make(map[string]string, len(record))}
+	for i, value := range record {
+		row.Values[c.header[i]] = value
+	}
+	return row, nil
+}
+
+// blank reports whether all fields of a record are empty or white space.
+func blank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// newScanner returns a scanner over the lines of r that accepts lines up to maxLineSize.
+func newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return scanner
+}
+
+func (n *ndjsonReader) Read() (Row, error) {
+	for n.scanner.Scan() {
+		n.line++
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		values, err := decodeObject(line)
+		if err != nil {
+			return Row{}, &RowError{Line: n.line, Err: err}
+		}
+		return Row{Line: n.line, Values: values}, nil
+	}
+	if err := n.scanner.Err(); err != nil {
+		return Row{}, err
+	}
+	return Row{}, io.EOF
+}
+
+// decodeObject decodes a JSON object of scalar values into strings keyed by lower-cased name.
+// Null members are left out.
+func decodeObject(data []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("not a JSON object: %v", err)
+	}
+
+	values := make(map[string]string, len(object))
+	for name, value := range object {
+		key := strings.ToLower(name)
+		switch v := value.(type) {
+		case nil:
+		case string:
+			values[key] = v
+		case json.Number:
+			values[key] = v.String()
+		case bool:
+			values[key] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("%s must be a string, number or boolean", name)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,270 @@
+package tabular_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"game-library-management-system/src/model"
+	"game-library-management-system/src/tabular"
+	"io"
+	"strings"
+	"testing"
+)
+
+var columns = []string{"Name", "MainHq", "Games", "Founded", "Active"}
+
+// rows are written with columns. Their strings need quoting or escaping in every format
+var rows = [][]any{
+	{"CD Projekt", "Warsaw, Poland", 12, int64(1994), true},
+	{`Valve "Software"`, "Bellevue\nWashington", 0, int64(-1), false},
+	{"  Ünïcödé & <Co>  ", "", 7, int64(1 << 40), true},
+}
+
+// want are rows as they are read back: strings of every value
+var want = []map[string]string{
+	{"name": "CD Projekt", "mainhq": "Warsaw, Poland", "games": "12", "founded": "1994", "active": "true"},
+	{"name": `Valve "Software"`, "mainhq": "Bellevue\nWashington", "games": "0", "founded": "-1", "active": "false"},
+	{"name": "  Ünïcödé & <Co>  ", "mainhq": "", "games": "7", "founded": "1099511627776", "active": "true"},
+}
+
+// write writes rows in a format
+func write(t *testing.T, format model.FileFormat) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := tabular.NewWriter(&buf, format, columns)
+	if err != nil {
+		t.Fatalf("NewWriter(%s): %v", format, err)
+	}
+	for _, row := range rows {
+		if err := w.Write(row...); err != nil {
+			t.Fatalf("Write(%s): %v", format, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close(%s): %v", format, err)
+	}
+	return buf.Bytes()
+}
+
+// readAll reads every row of a file, stopping at the first error
+func readAll(t *testing.T, data []byte, format model.FileFormat) []tabular.Row {
+	t.Helper()
+	r, err := tabular.NewReader(bytes.NewReader(data), format)
+	if err != nil {
+		t.Fatalf("NewReader(%s): %v", format, err)
+	}
+	var read []tabular.Row
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return read
+		}
+		if err != nil {
+			t.Fatalf("Read(%s): %v", format, err)
+		}
+		read = append(read, row)
+	}
+}
+
+// TestRoundTrip checks that rows written as CSV and NDJSON are read back with the same values.
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []model.FileFormat{model.FormatCSV, model.FormatNDJSON} {
+		read := readAll(t, write(t, format), format)
+		if len(read) != len(want) {
+			t.Fatalf("%s: read %d rows, want %d", format, len(read), len(want))
+		}
+		for i, row := range read {
+			for column, value := range want[i] {
+				if got := row.Values[column]; got != value {
+					t.Errorf("%s row %d: %s = %q, want %q", format, i+1, column, got, value)
+				}
+				if got := row.Get(strings.ToUpper(column)); got != strings.TrimSpace(value) {
+					t.Errorf("%s row %d: Get(%s) = %q, want %q", format, i+1, column, got, strings.TrimSpace(value))
+				}
+			}
+		}
+	}
+}
+
+// TestCSVQuoting checks that values with commas, quotes, line breaks and leading spaces are quoted, and the lines of the rows.
+func TestCSVQuoting(t *testing.T) {
+	data := write(t, model.FormatCSV)
+	wantFile := "Name,MainHq,Games,Founded,Active\n" +
+		"CD Projekt,\"Warsaw, Poland\",12,1994,true\n" +
+		"\"Valve \"\"Software\"\"\",\"Bellevue\nWashington\",0,-1,false\n" +
+		"\"  Ünïcödé & <Co>  \",,7,1099511627776,true\n"
+	if string(data) != wantFile {
+		t.Errorf("CSV file =\n%s\nwant\n%s", data, wantFile)
+	}
+	read := readAll(t, data, model.FormatCSV)
+	if lines := [3]int{read[0].Line, read[1].Line, read[2].Line}; lines != [3]int{2, 3, 5} {
+		t.Errorf("rows start on lines %v, want 2, 3 and 5", lines)
+	}
+}
+
+// TestNDJSONWrite checks that NDJSON rows are objects with typed members in column order.
+func TestNDJSONWrite(t *testing.T) {
+	lines := strings.Split(strings.TrimSuffix(string(write(t, model.FormatNDJSON)), "\n"), "\n")
+	if len(lines) != len(rows) {
+		t.Fatalf("%d lines, want %d", len(lines), len(rows))
+	}
+	wantLine := `{"Name":"Valve \"Software\"","MainHq":"Bellevue\nWashington","Games":0,"Founded":-1,"Active":false}`
+	if lines[1] != wantLine {
+		t.Errorf("line 2 = %s, want %s", lines[1], wantLine)
+	}
+}
+
+// TestRead checks what the readers skip and reject.
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name   string
+		format model.FileFormat
+		file   string
+		rows   int
+		errs   int
+	}{
+		{"csv with byte order mark", model.FormatCSV, "\ufeffName,MainHq\nValve,Bellevue\n", 1, 0},
+		{"csv with blank rows", model.FormatCSV, "Name,MainHq\nValve,Bellevue\n,\n ,  \n", 1, 0},
+		{"csv with a short row", model.FormatCSV, "Name,MainHq\nValve\nNintendo,Kyoto\n", 1, 1},
+		{"ndjson with blank lines", model.FormatNDJSON, "{\"Name\":\"Valve\"}\n\n  \n{\"Name\":\"Nintendo\",\"MainHq\":null}\n", 2, 0},
+		{"ndjson with a nested value", model.FormatNDJSON, "{\"Name\":{\"first\":\"Valve\"}}\n{\"Name\":\"Nintendo\"}\n", 1, 1},
+		{"ndjson with a broken line", model.FormatNDJSON, "{\"Name\":\n{\"Name\":\"Nintendo\"}\n", 1, 1},
+	}
+	for _, tt := range tests {
+		r, err := tabular.NewReader(strings.NewReader(tt.file), tt.format)
+		if err != nil {
+			t.Fatalf("%s: NewReader: %v", tt.name, err)
+		}
+		rowCount, errCount := 0, 0
+		for {
+			_, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			var rowErr *tabular.RowError
+			if errors.As(err, &rowErr) {
+				errCount++
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%s: Read: %v", tt.name, err)
+			}
+			rowCount++
+		}
+		if rowCount != tt.rows || errCount != tt.errs {
+			t.Errorf("%s: %d rows and %d row errors, want %d and %d", tt.name, rowCount, errCount, tt.rows, tt.errs)
+		}
+	}
+
+	if _, err := tabular.NewReader(strings.NewReader(""), model.FormatCSV); err == nil {
+		t.Error("NewReader of an empty CSV file succeeded")
+	}
+	if _, err := tabular.NewReader(strings.NewReader(""), model.FormatXLSX); err == nil {
+		t.Error("NewReader of xlsx succeeded")
+	}
+}
+
+// cell is a cell of an XLSX sheet
+type cell struct {
+	Type   string `xml:"t,attr"`
+	Value  string `xml:"v"`
+	Inline string `xml:"is>t"`
+}
+
+// sheet is the worksheet part of an XLSX workbook
+type sheet struct {
+	Rows []struct {
+		Cells []cell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readSheet unzips a workbook and decodes its sheet
+func readSheet(t *testing.T, data []byte) sheet {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("workbook is not a zip archive: %v", err)
+	}
+	for _, part := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if _, err := archive.Open(part); err != nil {
+			t.Errorf("workbook has no %s", part)
+		}
+	}
+	file, err := archive.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("workbook has no sheet: %v", err)
+	}
+	defer file.Close()
+	var s sheet
+	if err := xml.NewDecoder(file).Decode(&s); err != nil {
+		t.Fatalf("sheet is not valid XML: %v", err)
+	}
+	return s
+}
+
+// TestXLSX checks that a workbook holds a header row and typed, escaped cells.
+func TestXLSX(t *testing.T) {
+	s := readSheet(t, write(t, model.FormatXLSX))
+	if len(s.Rows) != len(rows)+1 {
+		t.Fatalf("sheet has %d rows, want %d", len(s.Rows), len(rows)+1)
+	}
+	for i, c := range s.Rows[0].Cells {
+		if c.Type != "inlineStr" || c.Inline != columns[i] {
+			t.Errorf("header cell %d = %+v, want the inline string %q", i, c, columns[i])
+		}
+	}
+	wantCells := [][]cell{
+		{{"inlineStr", "", "CD Projekt"}, {"inlineStr", "", "Warsaw, Poland"}, {"", "12", ""}, {"", "1994", ""}, {"b", "1", ""}},
+		{{"inlineStr", "", `Valve "Software"`}, {"inlineStr", "", "Bellevue\nWashington"}, {"", "0", ""}, {"", "-1", ""}, {"b", "0", ""}},
+		{{"inlineStr", "", "  Ünïcödé & <Co>  "}, {"inlineStr", "", ""}, {"", "7", ""}, {"", "1099511627776", ""}, {"b", "1", ""}},
+	}
+	for i, want := range wantCells {
+		got := s.Rows[i+1].Cells
+		if len(got) != len(want) {
+			t.Errorf("row %d has %d cells, want %d", i+1, len(got), len(want))
+			continue
+		}
+		for j := range want {
+			if got[j] != want[j] {
+				t.Errorf("row %d cell %d = %+v, want %+v", i+1, j, got[j], want[j])
+			}
+		}
+	}
+}
+
+// TestNothingWrittenBeforeFirstRow checks that a writer leaves the output alone until its first row, and that
+// closing a writer without rows writes a file with only the header.
+func TestNothingWrittenBeforeFirstRow(t *testing.T) {
+	for _, format := range []model.FileFormat{model.FormatCSV, model.FormatNDJSON, model.FormatXLSX} {
+		var buf bytes.Buffer
+		w, err := tabular.NewWriter(&buf, format, columns)
+		if err != nil {
+			t.Fatalf("NewWriter(%s): %v", format, err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("%s: %d bytes written before the first row", format, buf.Len())
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close(%s): %v", format, err)
+		}
+		switch format {
+		case model.FormatCSV:
+			if buf.String() != "Name,MainHq,Games,Founded,Active\n" {
+				t.Errorf("empty CSV file = %q, want the header", buf.String())
+			}
+		case model.FormatNDJSON:
+			if buf.Len() != 0 {
+				t.Errorf("empty NDJSON file = %q, want nothing", buf.String())
+			}
+		case model.FormatXLSX:
+			if s := readSheet(t, buf.Bytes()); len(s.Rows) != 1 {
+				t.Errorf("empty workbook has %d rows, want the header", len(s.Rows))
+			}
+		}
+	}
+
+	if _, err := tabular.NewWriter(io.Discard, model.FileFormat("pdf"), columns); err == nil {
+		t.Error("NewWriter of pdf succeeded")
+	}
+}
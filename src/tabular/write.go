@@ -0,0 +1,121 @@
+package tabular
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"game-library-management-system/src/model"
+	"io"
+	"strconv"
+)
+
+// Writer writes rows with a fixed list of columns. Nothing is written before the first row or Close,
+// so a caller can still report an error instead of an empty file.
+// Values are strings, ints, int64s or bools, one per column.
+type Writer interface {
+	Write(values ...any) error
+	// Close finishes the file. It does not close the underlying writer.
+	Close() error
+}
+
+// NewWriter returns a Writer of a file in the given format with the given columns.
+func NewWriter(w io.Writer, format model.FileFormat, columns []string) (Writer, error) {
+	switch format {
+	case model.FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w), columns: columns}, nil
+	case model.FormatNDJSON:
+		return newNDJSONWriter(w, columns), nil
+	case model.FormatXLSX:
+		return newXLSXWriter(w, columns), nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q, use csv, ndjson or xlsx", format)
+	}
+}
+
+// format returns the text of a value as it is written to CSV and XLSX.
+func format(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []string
+	started bool
+}
+
+// start writes the header row if it has not been written yet.
+func (c *csvWriter) start() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	return c.writer.Write(c.columns)
+}
+
+func (c *csvWriter) Write(values ...any) error {
+	if err := c.start(); err != nil {
+		return err
+	}
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = format(v)
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.start(); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type ndjsonWriter struct {
+	writer *bufio.Writer
+	// keys holds the JSON encoded column names.
+	keys [][]byte
+}
+
+func newNDJSONWriter(w io.Writer, columns []string) *ndjsonWriter {
+	keys := make([][]byte, len(columns))
+	for i, c := range columns {
+		keys[i], _ = json.Marshal(c)
+	}
+	return &ndjsonWriter{writer: bufio.NewWriter(w), keys: keys}
+}
+
+// Write writes the row as a JSON object whose members are in column order.
+func (n *ndjsonWriter) Write(values ...any) error {
+	n.writer.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			n.writer.WriteByte(',')
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		n.writer.Write(n.keys[i])
+		n.writer.WriteByte(':')
+		n.writer.Write(value)
+	}
+	_, err := n.writer.WriteString("}\n")
+	return err
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.writer.Flush()
+}
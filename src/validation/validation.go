@@ -0,0 +1,140 @@
+// Package validation checks models against declarative per-field rules.
+// All violations are collected, so a client can fix every field in one round trip.
+package validation
+
+import (
+	"fmt"
+	"game-library-management-system/src/apperr"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError is a single rule violation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Errors lists every rule violation of a value. It is an apperr.ErrValidation error.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Field + " " + fe.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (e Errors) Unwrap() error {
+	return apperr.ErrValidation
+}
+
+// Check inspects a field value and returns a violation message, or "" if the value is fine.
+type Check func(value any) string
+
+// Field declares the checks for one field of T.
+// Name is reported to clients and should match the field's JSON name.
+type Field[T any] struct {
+	Name   string
+	Value  func(T) any
+	Checks []Check
+}
+
+// Rules declares the checks for every validated field of T.
+type Rules[T any] []Field[T]
+
+// Validate runs all checks against v.
+// Returns nil when v is valid and Errors otherwise. Only the first failing check of each field is reported.
+func (rules Rules[T]) Validate(v T) error {
+	var errs Errors
+	for _, field := range rules {
+		value := field.Value(v)
+		for _, check := range field.Checks {
+			if msg := check(value); msg != "" {
+				errs = append(errs, FieldError{Field: field.Name, Message: msg})
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Required rejects empty or blank strings and zero ObjectIDs.
+func Required() Check {
+	return func(value any) string {
+		switch v := value.(type) {
+		case string:
+			if strings.TrimSpace(v) == "" {
+				return "is required"
+			}
+		case primitive.ObjectID:
+			if v.IsZero() {
+				return "is required"
+			}
+		}
+		return ""
+	}
+}
+
+// MaxLength rejects strings longer than n characters.
+func MaxLength(n int) Check {
+	return func(value any) string {
+		if s, ok := value.(string); ok && utf8.RuneCountInString(s) > n {
+			return fmt.Sprintf("must be at most %d characters long", n)
+		}
+		return ""
+	}
+}
+
+// Min rejects integers smaller than n.
+func Min(n int) Check {
+	return func(value any) string {
+		if v, ok := value.(int); ok && v < n {
+			return fmt.Sprintf("must be at least %d", n)
+		}
+		return ""
+	}
+}
+
+// NotInFuture rejects years after the current one.
+func NotInFuture() Check {
+	return func(value any) string {
+		if v, ok := value.(int); ok && v > time.Now().Year() {
+			return "must not be in the future"
+		}
+		return ""
+	}
+}
+
+// OneOf rejects strings other than the given values. Empty strings are left to Required.
+func OneOf(values ...string) Check {
+	return func(value any) string {
+		if s, ok := value.(string); ok && s != "" && !slices.Contains(values, s) {
+			return "must be one of " + strings.Join(values, ", ")
+		}
+		return ""
+	}
+}
+
+// Email rejects strings that are not a bare email address such as "ada@example.com".
+// Empty strings are left to Required.
+func Email() Check {
+	return func(value any) string {
+		s, ok := value.(string)
+		if !ok || s == "" {
+			return ""
+		}
+		if address, err := mail.ParseAddress(s); err != nil || address.Address != s {
+			return "must be an email address"
+		}
+		return ""
+	}
+}
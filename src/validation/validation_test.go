@@ -0,0 +1,110 @@
+package validation_test
+
+import (
+	"errors"
+	"game-library-management-system/src/apperr"
+	"game-library-management-system/src/validation"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChecks checks the message of every rule for values it accepts and rejects.
+func TestChecks(t *testing.T) {
+	year := time.Now().Year()
+	tests := []struct {
+		name  string
+		check validation.Check
+		value any
+		want  string
+	}{
+		{"required string", validation.Required(), "Witcher", ""},
+		{"required empty string", validation.Required(), "", "is required"},
+		{"required blank string", validation.Required(), " \t\n", "is required"},
+		{"required id", validation.Required(), primitive.NewObjectID(), ""},
+		{"required zero id", validation.Required(), primitive.NilObjectID, "is required"},
+		{"required other type", validation.Required(), 0, ""},
+
+		{"max length below", validation.MaxLength(5), "abcd", ""},
+		{"max length at", validation.MaxLength(5), "abcde", ""},
+		{"max length above", validation.MaxLength(5), "abcdef", "must be at most 5 characters long"},
+		{"max length counts characters", validation.MaxLength(5), "ÿöüäé", ""},
+		{"max length other type", validation.MaxLength(0), 123456, ""},
+
+		{"min above", validation.Min(1), 2, ""},
+		{"min at", validation.Min(1), 1, ""},
+		{"min below", validation.Min(1), 0, "must be at least 1"},
+		{"min negative", validation.Min(0), -1, "must be at least 0"},
+		{"min other type", validation.Min(1), "0", ""},
+
+		{"not in future past", validation.NotInFuture(), 1994, ""},
+		{"not in future this year", validation.NotInFuture(), year, ""},
+		{"not in future next year", validation.NotInFuture(), year + 1, "must not be in the future"},
+		{"not in future other type", validation.NotInFuture(), "3000", ""},
+
+		{"one of listed", validation.OneOf("new", "good", "worn"), "good", ""},
+		{"one of unlisted", validation.OneOf("new", "good", "worn"), "broken", "must be one of new, good, worn"},
+		{"one of is case-sensitive", validation.OneOf("new", "good", "worn"), "Good", "must be one of new, good, worn"},
+		{"one of empty", validation.OneOf("new", "good", "worn"), "", ""},
+
+		{"email", validation.Email(), "ada@example.com", ""},
+		{"email empty", validation.Email(), "", ""},
+		{"email without domain", validation.Email(), "ada", "must be an email address"},
+		{"email with a name", validation.Email(), "Ada <ada@example.com>", "must be an email address"},
+		{"email with spaces", validation.Email(), " ada@example.com ", "must be an email address"},
+		{"email with two addresses", validation.Email(), "ada@example.com, bob@example.com", "must be an email address"},
+	}
+	for _, tt := range tests {
+		if got := tt.check(tt.value); got != tt.want {
+			t.Errorf("%s: check(%v) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+// account is a value validated in the tests
+type account struct {
+	Name      string
+	Email     string
+	Age       int
+	OwnerID   primitive.ObjectID
+	Condition string
+}
+
+var accountRules = validation.Rules[account]{
+	{Name: "name", Value: func(a account) any { return a.Name }, Checks: []validation.Check{validation.Required(), validation.MaxLength(8)}},
+	{Name: "email", Value: func(a account) any { return a.Email }, Checks: []validation.Check{validation.Required(), validation.Email()}},
+	{Name: "age", Value: func(a account) any { return a.Age }, Checks: []validation.Check{validation.Min(18)}},
+	{Name: "ownerId", Value: func(a account) any { return a.OwnerID }, Checks: []validation.Check{validation.Required()}},
+	{Name: "condition", Value: func(a account) any { return a.Condition }, Checks: []validation.Check{validation.OneOf("new", "worn")}},
+}
+
+// TestValidate checks that every invalid field is reported once, with its first failing check and in the order of the rules, as a validation error.
+func TestValidate(t *testing.T) {
+	valid := account{Name: "ada", Email: "ada@example.com", Age: 36, OwnerID: primitive.NewObjectID(), Condition: "new"}
+	if err := accountRules.Validate(valid); err != nil {
+		t.Fatalf("Validate of a valid account: %v", err)
+	}
+
+	err := accountRules.Validate(account{Name: strings.Repeat(" ", 10), Email: "ada", Age: 17, Condition: "new"})
+	if !errors.Is(err, apperr.ErrValidation) {
+		t.Fatalf("Validate error = %v, want a validation error", err)
+	}
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate error is a %T, want validation.Errors", err)
+	}
+	want := validation.Errors{
+		{Field: "name", Message: "is required"},
+		{Field: "email", Message: "must be an email address"},
+		{Field: "age", Message: "must be at least 18"},
+		{Field: "ownerId", Message: "is required"},
+	}
+	if !slices.Equal(errs, want) {
+		t.Errorf("Validate errors = %v, want %v", errs, want)
+	}
+	if msg := err.Error(); !strings.HasPrefix(msg, "name is required; email must be an email address; ") {
+		t.Errorf("Error() = %q", msg)
+	}
+}